@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/wallix/awless/aws/config"
 	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/aws/test"
 	"github.com/wallix/awless/cloud/properties"
 	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/graph/resourcetest"
@@ -21,7 +22,7 @@ func TestAccesskey(t *testing.T) {
 		t.Run("no-prompt", func(t *testing.T) {
 
 			Template("create accesskey user=jdoe no-prompt=true").
-				Mock(&iamMock{
+				Mock(&awstest.IamMock{
 					CreateAccessKeyFunc: func(*iam.CreateAccessKeyInput) (*iam.CreateAccessKeyOutput, error) {
 						return &iam.CreateAccessKeyOutput{AccessKey: &iam.AccessKey{AccessKeyId: String("new-keypair-id")}}, nil
 					},
@@ -40,7 +41,7 @@ func TestAccesskey(t *testing.T) {
 			}
 			awsspec.AWSCredFilepath = filepath.Join(awsFolder, "credentials")
 			Template("create accesskey user=jdoe save=true").
-				Mock(&iamMock{
+				Mock(&awstest.IamMock{
 					CreateAccessKeyFunc: func(*iam.CreateAccessKeyInput) (*iam.CreateAccessKeyOutput, error) {
 						return &iam.CreateAccessKeyOutput{AccessKey: &iam.AccessKey{AccessKeyId: String("0123456EXAMPLE"), SecretAccessKey: String("MYSECRETKEY")}}, nil
 					},
@@ -64,7 +65,7 @@ aws_secret_access_key = MYSECRETKEY
 	t.Run("delete", func(t *testing.T) {
 		t.Run("with user", func(t *testing.T) {
 			Template("delete accesskey id=ACCESSKEYID user=jdoe").
-				Mock(&iamMock{
+				Mock(&awstest.IamMock{
 					DeleteAccessKeyFunc: func(param0 *iam.DeleteAccessKeyInput) (*iam.DeleteAccessKeyOutput, error) {
 						return nil, nil
 					},
@@ -78,7 +79,7 @@ aws_secret_access_key = MYSECRETKEY
 			g.AddResource(resourcetest.AccessKey("ACCESSKEYID").Prop(properties.Username, "myusername").Build())
 			g.AddResource(resourcetest.AccessKey("OTHERACCESSKEYID").Prop(properties.Username, "notthis").Build())
 			Template("delete accesskey id=ACCESSKEYID").
-				Mock(&iamMock{
+				Mock(&awstest.IamMock{
 					DeleteAccessKeyFunc: func(param0 *iam.DeleteAccessKeyInput) (*iam.DeleteAccessKeyOutput, error) {
 						return nil, nil
 					},
@@ -89,7 +90,7 @@ aws_secret_access_key = MYSECRETKEY
 		})
 		t.Run("without user and id not in local graph", func(t *testing.T) {
 			Template("delete accesskey id=ACCESSKEYID").
-				Mock(&iamMock{
+				Mock(&awstest.IamMock{
 					DeleteAccessKeyFunc: func(param0 *iam.DeleteAccessKeyInput) (*iam.DeleteAccessKeyOutput, error) {
 						return nil, nil
 					},
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestAlarm(t *testing.T) {
@@ -13,7 +14,7 @@ func TestAlarm(t *testing.T) {
 			"alarm-actions=arn:my:alarm:action1,arn:my:alarm:action2 description='This is a test alarm' "+
 			"dimensions=DimKey1:val1,DimKey2:val2 enabled=true insufficientdata-actions=arn:my:action:insufficientdata "+
 			"ok-actions=arn:my:ok-action unit=Bytes").
-			Mock(&cloudwatchMock{
+			Mock(&awstest.CloudwatchMock{
 				PutMetricAlarmFunc: func(param0 *cloudwatch.PutMetricAlarmInput) (*cloudwatch.PutMetricAlarmOutput, error) {
 					return &cloudwatch.PutMetricAlarmOutput{}, nil
 				},
@@ -37,26 +38,26 @@ func TestAlarm(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete alarm name=my-alarm-to-delete").Mock(&cloudwatchMock{
+		Template("delete alarm name=my-alarm-to-delete").Mock(&awstest.CloudwatchMock{
 			DeleteAlarmsFunc: func(param0 *cloudwatch.DeleteAlarmsInput) (*cloudwatch.DeleteAlarmsOutput, error) { return nil, nil },
 		}).ExpectInput("DeleteAlarms", &cloudwatch.DeleteAlarmsInput{AlarmNames: []*string{String("my-alarm-to-delete")}}).
 			ExpectCalls("DeleteAlarms").Run(t)
 
-		Template("delete alarm name=alarm1,alarm2").Mock(&cloudwatchMock{
+		Template("delete alarm name=alarm1,alarm2").Mock(&awstest.CloudwatchMock{
 			DeleteAlarmsFunc: func(param0 *cloudwatch.DeleteAlarmsInput) (*cloudwatch.DeleteAlarmsOutput, error) { return nil, nil },
 		}).ExpectInput("DeleteAlarms", &cloudwatch.DeleteAlarmsInput{AlarmNames: []*string{String("alarm1"), String("alarm2")}}).
 			ExpectCalls("DeleteAlarms").Run(t)
 	})
 
 	t.Run("start", func(t *testing.T) {
-		Template("start alarm names=my-alarm-to-start").Mock(&cloudwatchMock{
+		Template("start alarm names=my-alarm-to-start").Mock(&awstest.CloudwatchMock{
 			EnableAlarmActionsFunc: func(param0 *cloudwatch.EnableAlarmActionsInput) (*cloudwatch.EnableAlarmActionsOutput, error) {
 				return nil, nil
 			},
 		}).ExpectInput("EnableAlarmActions", &cloudwatch.EnableAlarmActionsInput{AlarmNames: []*string{String("my-alarm-to-start")}}).
 			ExpectCalls("EnableAlarmActions").Run(t)
 
-		Template("start alarm names=alarm1,alarm2").Mock(&cloudwatchMock{
+		Template("start alarm names=alarm1,alarm2").Mock(&awstest.CloudwatchMock{
 			EnableAlarmActionsFunc: func(param0 *cloudwatch.EnableAlarmActionsInput) (*cloudwatch.EnableAlarmActionsOutput, error) {
 				return nil, nil
 			},
@@ -65,14 +66,14 @@ func TestAlarm(t *testing.T) {
 	})
 
 	t.Run("stop", func(t *testing.T) {
-		Template("stop alarm names=my-alarm-to-stop").Mock(&cloudwatchMock{
+		Template("stop alarm names=my-alarm-to-stop").Mock(&awstest.CloudwatchMock{
 			DisableAlarmActionsFunc: func(param0 *cloudwatch.DisableAlarmActionsInput) (*cloudwatch.DisableAlarmActionsOutput, error) {
 				return nil, nil
 			},
 		}).ExpectInput("DisableAlarmActions", &cloudwatch.DisableAlarmActionsInput{AlarmNames: []*string{String("my-alarm-to-stop")}}).
 			ExpectCalls("DisableAlarmActions").Run(t)
 
-		Template("stop alarm names=alarm1,alarm2").Mock(&cloudwatchMock{
+		Template("stop alarm names=alarm1,alarm2").Mock(&awstest.CloudwatchMock{
 			DisableAlarmActionsFunc: func(param0 *cloudwatch.DisableAlarmActionsInput) (*cloudwatch.DisableAlarmActionsOutput, error) {
 				return nil, nil
 			},
@@ -81,7 +82,7 @@ func TestAlarm(t *testing.T) {
 	})
 
 	t.Run("attach", func(t *testing.T) {
-		Template("attach alarm name=my-alarm-to-attach action-arn=arn:of:new_action").Mock(&cloudwatchMock{
+		Template("attach alarm name=my-alarm-to-attach action-arn=arn:of:new_action").Mock(&awstest.CloudwatchMock{
 			DescribeAlarmsFunc: func(param0 *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error) {
 				return &cloudwatch.DescribeAlarmsOutput{
 					MetricAlarms: []*cloudwatch.MetricAlarm{{
@@ -134,7 +135,7 @@ func TestAlarm(t *testing.T) {
 			ExpectCalls("PutMetricAlarm", "DescribeAlarms").Run(t)
 	})
 	t.Run("detach", func(t *testing.T) {
-		Template("detach alarm name=my-alarm-to-detach action-arn=old_action_2").Mock(&cloudwatchMock{
+		Template("detach alarm name=my-alarm-to-detach action-arn=old_action_2").Mock(&awstest.CloudwatchMock{
 			DescribeAlarmsFunc: func(param0 *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error) {
 				return &cloudwatch.DescribeAlarmsOutput{
 					MetricAlarms: []*cloudwatch.MetricAlarm{{
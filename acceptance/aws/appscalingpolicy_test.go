@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/applicationautoscaling"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestAppscalingPolicy(t *testing.T) {
@@ -11,7 +12,7 @@ func TestAppscalingPolicy(t *testing.T) {
 		Template("create appscalingpolicy name=my-new-policy-name type=StepScaling resource=service/default/sample-webapp dimension=ecs:service:DesiredCount "+
 			"service-namespace=ecs stepscaling-adjustment-type=ChangeInCapacity stepscaling-cooldown=12 stepscaling-aggregation-type=Average stepscaling-min-adjustment-magnitude=2 "+
 			"stepscaling-adjustments=75::+1,0:25:-1").
-			Mock(&applicationautoscalingMock{
+			Mock(&awstest.ApplicationautoscalingMock{
 				PutScalingPolicyFunc: func(param0 *applicationautoscaling.PutScalingPolicyInput) (*applicationautoscaling.PutScalingPolicyOutput, error) {
 					return &applicationautoscaling.PutScalingPolicyOutput{PolicyARN: String("new-appscalingpolicy-arn")}, nil
 				},
@@ -45,7 +46,7 @@ func TestAppscalingPolicy(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete appscalingpolicy name=my-policy-to-delete resource=service/default/sample-webapp dimension=ecs:service:DesiredCount service-namespace=ecs").
-			Mock(&applicationautoscalingMock{
+			Mock(&awstest.ApplicationautoscalingMock{
 				DeleteScalingPolicyFunc: func(param0 *applicationautoscaling.DeleteScalingPolicyInput) (*applicationautoscaling.DeleteScalingPolicyOutput, error) {
 					return nil, nil
 				},
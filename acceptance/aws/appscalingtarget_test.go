@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/applicationautoscaling"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestAppscalingtarget(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create appscalingtarget resource=service/default/sample-webapp service-namespace=ecs dimension=ecs:service:DesiredCount role=arn:of:my-scaling-role min-capacity=2 max-capacity=11").
-			Mock(&applicationautoscalingMock{
+			Mock(&awstest.ApplicationautoscalingMock{
 				RegisterScalableTargetFunc: func(param0 *applicationautoscaling.RegisterScalableTargetInput) (*applicationautoscaling.RegisterScalableTargetOutput, error) {
 					return nil, nil
 				},
@@ -25,7 +26,7 @@ func TestAppscalingtarget(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete appscalingtarget resource=service/default/sample-webapp service-namespace=ecs dimension=ecs:service:DesiredCount").
-			Mock(&applicationautoscalingMock{
+			Mock(&awstest.ApplicationautoscalingMock{
 				DeregisterScalableTargetFunc: func(param0 *applicationautoscaling.DeregisterScalableTargetInput) (*applicationautoscaling.DeregisterScalableTargetOutput, error) {
 					return nil, nil
 				},
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/aws/test"
 	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/template"
@@ -15,9 +16,10 @@ import (
 type ATBuilder struct {
 	template    string
 	cmdResult   *string
+	revert      *string
 	expectCalls map[string]int
 	expectInput map[string]interface{}
-	mock        mock
+	mock        awstest.Mock
 	graph       *graph.Graph
 }
 
@@ -30,6 +32,11 @@ func (b *ATBuilder) ExpectCommandResult(key string) *ATBuilder {
 	return b
 }
 
+func (b *ATBuilder) ExpectRevert(revert string) *ATBuilder {
+	b.revert = &revert
+	return b
+}
+
 func (b *ATBuilder) ExpectCalls(expects ...string) *ATBuilder {
 	for _, expect := range expects {
 		b.expectCalls[expect]++
@@ -59,7 +66,7 @@ func (b *ATBuilder) Run(t *testing.T, l ...*logger.Logger) {
 	if b.graph == nil {
 		b.graph = graph.NewGraph()
 	}
-	awsspec.CommandFactory = NewAcceptanceFactory(b.mock, b.graph, l...)
+	awsspec.CommandFactory = awstest.NewFactory(b.mock, b.graph, l...)
 
 	env := template.NewEnv()
 	env.Lookuper = func(tokens ...string) interface{} {
@@ -91,9 +98,18 @@ func (b *ATBuilder) Run(t *testing.T, l ...*logger.Logger) {
 			t.Fatalf("got %s, want %s", got, want)
 		}
 	}
+	if b.revert != nil {
+		reverted, err := ran.Revert()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := reverted.String(), StringValue(b.revert); got != want {
+			t.Fatalf("got revert %s, want %s", got, want)
+		}
+	}
 }
 
-func (b *ATBuilder) Mock(i mock) *ATBuilder {
+func (b *ATBuilder) Mock(i awstest.Mock) *ATBuilder {
 	b.mock = i
 	return b
 }
@@ -3,13 +3,15 @@ package awsat
 import (
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestBucket(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create bucket name=my-new-bucket acl=public-read").
-			Mock(&s3Mock{
+			Mock(&awstest.S3Mock{
 				CreateBucketFunc: func(param0 *s3.CreateBucketInput) (*s3.CreateBucketOutput, error) {
 					return &s3.CreateBucketOutput{}, nil
 				},
@@ -21,17 +23,28 @@ func TestBucket(t *testing.T) {
 
 	t.Run("update", func(t *testing.T) {
 		Template("update bucket name=my-bucket-to-update acl=public-read").
-			Mock(&s3Mock{
+			Mock(&awstest.S3Mock{
+				GetBucketAclFunc: func(param0 *s3.GetBucketAclInput) (*s3.GetBucketAclOutput, error) {
+					return &s3.GetBucketAclOutput{
+						Owner:  &s3.Owner{ID: String("owner-id")},
+						Grants: []*s3.Grant{{Grantee: &s3.Grantee{ID: String("owner-id")}, Permission: String("FULL_CONTROL")}},
+					}, nil
+				},
 				PutBucketAclFunc: func(param0 *s3.PutBucketAclInput) (*s3.PutBucketAclOutput, error) {
 					return nil, nil
 				},
-			}).ExpectInput("PutBucketAcl", &s3.PutBucketAclInput{
-			Bucket: String("my-bucket-to-update"),
-			ACL:    String("public-read"),
-		}).ExpectCalls("PutBucketAcl").Run(t)
+			}).ExpectInput("GetBucketAcl", &s3.GetBucketAclInput{Bucket: String("my-bucket-to-update")}).
+			ExpectInput("PutBucketAcl", &s3.PutBucketAclInput{
+				Bucket: String("my-bucket-to-update"),
+				ACL:    String("public-read"),
+			}).ExpectCalls("GetBucketAcl", "PutBucketAcl").
+			ExpectRevert("update bucket acl=private name=my-bucket-to-update").Run(t)
 
 		Template("update bucket name=my-bucket-to-update public-website=true redirect-hostname='http://myhostname.com' enforce-https=true").
-			Mock(&s3Mock{
+			Mock(&awstest.S3Mock{
+				GetBucketWebsiteFunc: func(param0 *s3.GetBucketWebsiteInput) (*s3.GetBucketWebsiteOutput, error) {
+					return &s3.GetBucketWebsiteOutput{IndexDocument: &s3.IndexDocument{Suffix: String("index.html")}}, nil
+				},
 				PutBucketWebsiteFunc: func(param0 *s3.PutBucketWebsiteInput) (*s3.PutBucketWebsiteOutput, error) {
 					return nil, nil
 				},
@@ -40,10 +53,15 @@ func TestBucket(t *testing.T) {
 			WebsiteConfiguration: &s3.WebsiteConfiguration{
 				RedirectAllRequestsTo: &s3.RedirectAllRequestsTo{HostName: String("http://myhostname.com"), Protocol: String("https")},
 			},
-		}).ExpectCalls("PutBucketWebsite").Run(t)
+		}).ExpectInput("GetBucketWebsite", &s3.GetBucketWebsiteInput{Bucket: String("my-bucket-to-update")}).
+			ExpectCalls("GetBucketWebsite", "PutBucketWebsite").
+			ExpectRevert("update bucket index-suffix=index.html name=my-bucket-to-update public-website=true").Run(t)
 
 		Template("update bucket name=my-bucket-to-update public-website=true index-suffix='index.go'").
-			Mock(&s3Mock{
+			Mock(&awstest.S3Mock{
+				GetBucketWebsiteFunc: func(param0 *s3.GetBucketWebsiteInput) (*s3.GetBucketWebsiteOutput, error) {
+					return nil, awserr.New("NoSuchWebsiteConfiguration", "The specified bucket does not have a website configuration", nil)
+				},
 				PutBucketWebsiteFunc: func(param0 *s3.PutBucketWebsiteInput) (*s3.PutBucketWebsiteOutput, error) {
 					return nil, nil
 				},
@@ -52,21 +70,28 @@ func TestBucket(t *testing.T) {
 			WebsiteConfiguration: &s3.WebsiteConfiguration{
 				IndexDocument: &s3.IndexDocument{Suffix: String("index.go")},
 			},
-		}).ExpectCalls("PutBucketWebsite").Run(t)
+		}).ExpectInput("GetBucketWebsite", &s3.GetBucketWebsiteInput{Bucket: String("my-bucket-to-update")}).
+			ExpectCalls("GetBucketWebsite", "PutBucketWebsite").
+			ExpectRevert("update bucket name=my-bucket-to-update public-website=false").Run(t)
 
 		Template("update bucket name=my-bucket-to-update public-website=false").
-			Mock(&s3Mock{
+			Mock(&awstest.S3Mock{
+				GetBucketWebsiteFunc: func(param0 *s3.GetBucketWebsiteInput) (*s3.GetBucketWebsiteOutput, error) {
+					return &s3.GetBucketWebsiteOutput{RedirectAllRequestsTo: &s3.RedirectAllRequestsTo{HostName: String("myhostname.com"), Protocol: String("https")}}, nil
+				},
 				DeleteBucketWebsiteFunc: func(param0 *s3.DeleteBucketWebsiteInput) (*s3.DeleteBucketWebsiteOutput, error) {
 					return nil, nil
 				},
 			}).ExpectInput("DeleteBucketWebsite", &s3.DeleteBucketWebsiteInput{
 			Bucket: String("my-bucket-to-update"),
-		}).ExpectCalls("DeleteBucketWebsite").Run(t)
+		}).ExpectInput("GetBucketWebsite", &s3.GetBucketWebsiteInput{Bucket: String("my-bucket-to-update")}).
+			ExpectCalls("GetBucketWebsite", "DeleteBucketWebsite").
+			ExpectRevert("update bucket enforce-https=true name=my-bucket-to-update public-website=true redirect-hostname=myhostname.com").Run(t)
 	})
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete bucket name=my-bucket-to-delete").
-			Mock(&s3Mock{
+			Mock(&awstest.S3Mock{
 				DeleteBucketFunc: func(param0 *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error) {
 					return nil, nil
 				},
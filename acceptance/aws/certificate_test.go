@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestCertificate(t *testing.T) {
@@ -49,7 +50,7 @@ func TestCertificate(t *testing.T) {
 		for _, tcase := range tcases {
 
 			Template(tcase.template).
-				Mock(&acmMock{
+				Mock(&awstest.AcmMock{
 					RequestCertificateFunc: func(param0 *acm.RequestCertificateInput) (*acm.RequestCertificateOutput, error) {
 						return &acm.RequestCertificateOutput{CertificateArn: String("arn:my:new:certificate")}, nil
 					},
@@ -59,7 +60,7 @@ func TestCertificate(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete certificate arn=arn:certificate:to:delete").
-			Mock(&acmMock{
+			Mock(&awstest.AcmMock{
 				DeleteCertificateFunc: func(param0 *acm.DeleteCertificateInput) (*acm.DeleteCertificateOutput, error) {
 					return nil, nil
 				},
@@ -68,7 +69,7 @@ func TestCertificate(t *testing.T) {
 	})
 
 	t.Run("check", func(t *testing.T) {
-		Template("check certificate arn=arn:certificate:to:check state=issued timeout=1").Mock(&acmMock{
+		Template("check certificate arn=arn:certificate:to:check state=issued timeout=1").Mock(&awstest.AcmMock{
 			DescribeCertificateFunc: func(input *acm.DescribeCertificateInput) (*acm.DescribeCertificateOutput, error) {
 				return &acm.DescribeCertificateOutput{Certificate: &acm.CertificateDetail{CertificateArn: String("arn:certificate:to:check"), Status: String("issued")}}, nil
 			}}).ExpectInput("DescribeCertificate", &acm.DescribeCertificateInput{CertificateArn: String("arn:certificate:to:check")}).
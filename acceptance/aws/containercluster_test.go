@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestContainerCluster(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create containercluster name=my-new-containercluster").
-			Mock(&ecsMock{
+			Mock(&awstest.EcsMock{
 				CreateClusterFunc: func(param0 *ecs.CreateClusterInput) (*ecs.CreateClusterOutput, error) {
 					return &ecs.CreateClusterOutput{
 						Cluster: &ecs.Cluster{ClusterArn: String("arn:of:my:new:cluster")},
@@ -23,7 +24,7 @@ func TestContainerCluster(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete containercluster id=arn:of:cluster:to:delete").
-			Mock(&ecsMock{
+			Mock(&awstest.EcsMock{
 				DeleteClusterFunc: func(param0 *ecs.DeleteClusterInput) (*ecs.DeleteClusterOutput, error) {
 					return nil, nil
 				},
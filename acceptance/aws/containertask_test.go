@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestContainerTask(t *testing.T) {
@@ -14,7 +15,7 @@ func TestContainerTask(t *testing.T) {
 			Template("start containertask name=my-new-service cluster=my-cluster-name desired-count=3 type=service "+
 				"role=arn:of:container:role deployment-name=prod loadbalancer.container-name=redis loadbalancer.container-port=6379 "+
 				"loadbalancer.targetgroup=arn:of:my:targetgroup").
-				Mock(&ecsMock{
+				Mock(&awstest.EcsMock{
 					CreateServiceFunc: func(param0 *ecs.CreateServiceInput) (*ecs.CreateServiceOutput, error) {
 						return &ecs.CreateServiceOutput{
 							Service: &ecs.Service{ServiceArn: String("arn:of:my:new:service")},
@@ -38,7 +39,7 @@ func TestContainerTask(t *testing.T) {
 		})
 		t.Run("task", func(t *testing.T) {
 			Template("start containertask name=my-new-task cluster=my-cluster-name desired-count=3 type=task").
-				Mock(&ecsMock{
+				Mock(&awstest.EcsMock{
 					RunTaskFunc: func(param0 *ecs.RunTaskInput) (*ecs.RunTaskOutput, error) {
 						return &ecs.RunTaskOutput{
 							Tasks: []*ecs.Task{{TaskArn: String("arn:of:new:task")}},
@@ -56,7 +57,7 @@ func TestContainerTask(t *testing.T) {
 	t.Run("stop", func(t *testing.T) {
 		t.Run("service", func(t *testing.T) {
 			Template("stop containertask cluster=my-cluster-name type=service deployment-name=prod").
-				Mock(&ecsMock{
+				Mock(&awstest.EcsMock{
 					DeleteServiceFunc: func(param0 *ecs.DeleteServiceInput) (*ecs.DeleteServiceOutput, error) {
 						return nil, nil
 					},
@@ -68,7 +69,7 @@ func TestContainerTask(t *testing.T) {
 
 		t.Run("task", func(t *testing.T) {
 			Template("stop containertask cluster=my-cluster-name type=task run-arn=arn:task:to:stop").
-				Mock(&ecsMock{
+				Mock(&awstest.EcsMock{
 					StopTaskFunc: func(param0 *ecs.StopTaskInput) (*ecs.StopTaskOutput, error) {
 						return nil, nil
 					},
@@ -81,7 +82,7 @@ func TestContainerTask(t *testing.T) {
 
 	t.Run("update", func(t *testing.T) {
 		Template("update containertask name=my-service cluster=my-cluster-name deployment-name=prod desired-count=5").
-			Mock(&ecsMock{
+			Mock(&awstest.EcsMock{
 				UpdateServiceFunc: func(param0 *ecs.UpdateServiceInput) (*ecs.UpdateServiceOutput, error) {
 					return nil, nil
 				},
@@ -96,7 +97,7 @@ func TestContainerTask(t *testing.T) {
 	t.Run("attach", func(t *testing.T) {
 		t.Run("first container in task", func(t *testing.T) {
 			Template("attach containertask name=my-task container-name=redis image=redis/redis memory-hard-limit=128 command='redis --start --fake-param' env=User:Jdoe,DbPasswd:VERYSECRET privileged=true workdir=/home ports=6379,8080:80").
-				Mock(&ecsMock{
+				Mock(&awstest.EcsMock{
 					DescribeTaskDefinitionFunc: func(param0 *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error) {
 						return nil, awserr.New("ClientException", "unable to describe task definition", errors.New("task does not exist"))
 					},
@@ -135,7 +136,7 @@ func TestContainerTask(t *testing.T) {
 			}
 
 			Template("attach containertask name=my-task container-name=postgresql image=postgresql memory-hard-limit=64 command=postgresql,--port,3306 ports=3306:3306/tcp").
-				Mock(&ecsMock{
+				Mock(&awstest.EcsMock{
 					DescribeTaskDefinitionFunc: func(param0 *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error) {
 						return &ecs.DescribeTaskDefinitionOutput{
 							TaskDefinition: &ecs.TaskDefinition{
@@ -177,7 +178,7 @@ func TestContainerTask(t *testing.T) {
 		}
 		t.Run("at least 2 containers in task", func(t *testing.T) {
 			Template("detach containertask name=my-task container-name=posgresql").
-				Mock(&ecsMock{
+				Mock(&awstest.EcsMock{
 					DescribeTaskDefinitionFunc: func(param0 *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error) {
 						return &ecs.DescribeTaskDefinitionOutput{
 							TaskDefinition: &ecs.TaskDefinition{
@@ -199,7 +200,7 @@ func TestContainerTask(t *testing.T) {
 
 		t.Run("last container in task", func(t *testing.T) {
 			Template("detach containertask name=my-task container-name=redis/redis").
-				Mock(&ecsMock{
+				Mock(&awstest.EcsMock{
 					DescribeTaskDefinitionFunc: func(param0 *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error) {
 						return &ecs.DescribeTaskDefinitionOutput{
 							TaskDefinition: &ecs.TaskDefinition{
@@ -222,7 +223,7 @@ func TestContainerTask(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete containertask name=my-task-to-delete all-versions=true").
-			Mock(&ecsMock{
+			Mock(&awstest.EcsMock{
 				ListTaskDefinitionsFunc: func(param0 *ecs.ListTaskDefinitionsInput) (*ecs.ListTaskDefinitionsOutput, error) {
 					return &ecs.ListTaskDefinitionsOutput{TaskDefinitionArns: []*string{String("arn:of:task:to:delete")}}, nil
 				},
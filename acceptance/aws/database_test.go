@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestDatabase(t *testing.T) {
@@ -15,33 +16,33 @@ func TestDatabase(t *testing.T) {
 				"domain=my-db-domain iamrole=my-db-iamrole version=my-db-version iops=1024 license=my-db-license multiaz=true "+
 				"optiongroup=my-db-optiongroup port=3306 backupwindow=my-db-backupwindow maintenancewindow=my-db-maintenancewindow "+
 				"public=true encrypted=true storagetype=my-db-storagetype timezone=my-db-timezone vpcsecuritygroups=my-db-vpcsecuritygroup-1,my-db-vpcsecuritygroup-2").
-				Mock(&rdsMock{
+				Mock(&awstest.RdsMock{
 					CreateDBInstanceFunc: func(param0 *rds.CreateDBInstanceInput) (*rds.CreateDBInstanceOutput, error) {
 						return &rds.CreateDBInstanceOutput{DBInstance: &rds.DBInstance{DBInstanceIdentifier: String("new-database-id")}}, nil
 					},
 				}).ExpectInput("CreateDBInstance", &rds.CreateDBInstanceInput{
-				DBInstanceClass:         String("my-db-type"),
-				DBInstanceIdentifier:    String("my-db-id"),
-				Engine:                  String("my-db-engine"),
-				MasterUserPassword:      String("my-db-password"),
-				MasterUsername:          String("my-db-username"),
-				AllocatedStorage:        Int64(12),
-				AutoMinorVersionUpgrade: Bool(true),
-				AvailabilityZone:        String("my-db-availabilityzone"),
-				BackupRetentionPeriod:   Int64(10),
-				DBClusterIdentifier:     String("my-db-cluster"),
-				DBName:                  String("my-db-dbname"),
-				DBParameterGroupName:    String("my-db-parametergroup"),
-				DBSecurityGroups:        []*string{String("my-db-dbsecuritygroup-1"), String("my-db-dbsecuritygroup-2")},
-				DBSubnetGroupName:       String("my-db-subnetgroup"),
-				Domain:                  String("my-db-domain"),
-				DomainIAMRoleName:       String("my-db-iamrole"),
-				EngineVersion:           String("my-db-version"),
-				Iops:                    Int64(1024),
-				LicenseModel:            String("my-db-license"),
-				MultiAZ:                 Bool(true),
-				OptionGroupName:         String("my-db-optiongroup"),
-				Port:                    Int64(3306),
+				DBInstanceClass:            String("my-db-type"),
+				DBInstanceIdentifier:       String("my-db-id"),
+				Engine:                     String("my-db-engine"),
+				MasterUserPassword:         String("my-db-password"),
+				MasterUsername:             String("my-db-username"),
+				AllocatedStorage:           Int64(12),
+				AutoMinorVersionUpgrade:    Bool(true),
+				AvailabilityZone:           String("my-db-availabilityzone"),
+				BackupRetentionPeriod:      Int64(10),
+				DBClusterIdentifier:        String("my-db-cluster"),
+				DBName:                     String("my-db-dbname"),
+				DBParameterGroupName:       String("my-db-parametergroup"),
+				DBSecurityGroups:           []*string{String("my-db-dbsecuritygroup-1"), String("my-db-dbsecuritygroup-2")},
+				DBSubnetGroupName:          String("my-db-subnetgroup"),
+				Domain:                     String("my-db-domain"),
+				DomainIAMRoleName:          String("my-db-iamrole"),
+				EngineVersion:              String("my-db-version"),
+				Iops:                       Int64(1024),
+				LicenseModel:               String("my-db-license"),
+				MultiAZ:                    Bool(true),
+				OptionGroupName:            String("my-db-optiongroup"),
+				Port:                       Int64(3306),
 				PreferredBackupWindow:      String("my-db-backupwindow"),
 				PreferredMaintenanceWindow: String("my-db-maintenancewindow"),
 				PubliclyAccessible:         Bool(true),
@@ -53,7 +54,7 @@ func TestDatabase(t *testing.T) {
 		})
 		t.Run("read replica db", func(t *testing.T) {
 			Template("create database replica=my-replica-id replica-source=my-source-id").
-				Mock(&rdsMock{
+				Mock(&awstest.RdsMock{
 					CreateDBInstanceReadReplicaFunc: func(param0 *rds.CreateDBInstanceReadReplicaInput) (*rds.CreateDBInstanceReadReplicaOutput, error) {
 						return &rds.CreateDBInstanceReadReplicaOutput{DBInstance: &rds.DBInstance{DBInstanceIdentifier: String("new-replica-id")}}, nil
 					},
@@ -66,7 +67,7 @@ func TestDatabase(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete database id=db-1234 skip-snapshot=false snapshot=my-snapshot-id").
-			Mock(&rdsMock{
+			Mock(&awstest.RdsMock{
 				DeleteDBInstanceFunc: func(param0 *rds.DeleteDBInstanceInput) (*rds.DeleteDBInstanceOutput, error) {
 					return nil, nil
 				},
@@ -79,7 +80,7 @@ func TestDatabase(t *testing.T) {
 
 	t.Run("check", func(t *testing.T) {
 		Template("check database id=db-1234 state=creating timeout=1").
-			Mock(&rdsMock{
+			Mock(&awstest.RdsMock{
 				DescribeDBInstancesFunc: func(param0 *rds.DescribeDBInstancesInput) (*rds.DescribeDBInstancesOutput, error) {
 					return &rds.DescribeDBInstancesOutput{
 						DBInstances: []*rds.DBInstance{
@@ -97,7 +98,7 @@ func TestDatabase(t *testing.T) {
 
 	t.Run("start", func(t *testing.T) {
 		Template("start database id=db-1234").
-			Mock(&rdsMock{
+			Mock(&awstest.RdsMock{
 				StartDBInstanceFunc: func(param0 *rds.StartDBInstanceInput) (*rds.StartDBInstanceOutput, error) {
 					return nil, nil
 				},
@@ -108,7 +109,7 @@ func TestDatabase(t *testing.T) {
 
 	t.Run("stop", func(t *testing.T) {
 		Template("stop database id=db-1234").
-			Mock(&rdsMock{
+			Mock(&awstest.RdsMock{
 				StopDBInstanceFunc: func(param0 *rds.StopDBInstanceInput) (*rds.StopDBInstanceOutput, error) {
 					return nil, nil
 				},
@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestDbsubnetgroup(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create dbsubnetgroup name=my-dbsubnetgroup description=db-subnet-description subnets=sub-1234,sub-2345").
-			Mock(&rdsMock{
+			Mock(&awstest.RdsMock{
 				CreateDBSubnetGroupFunc: func(param0 *rds.CreateDBSubnetGroupInput) (*rds.CreateDBSubnetGroupOutput, error) {
 					return &rds.CreateDBSubnetGroupOutput{
 						DBSubnetGroup: &rds.DBSubnetGroup{DBSubnetGroupName: String("new-dbsubnetgroup-name")},
@@ -24,7 +25,7 @@ func TestDbsubnetgroup(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete dbsubnetgroup name=dbsubnetgroup-to-delete").
-			Mock(&rdsMock{
+			Mock(&awstest.RdsMock{
 				DeleteDBSubnetGroupFunc: func(param0 *rds.DeleteDBSubnetGroupInput) (*rds.DeleteDBSubnetGroupOutput, error) {
 					return nil, nil
 				},
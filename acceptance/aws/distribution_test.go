@@ -6,6 +6,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestDistribution(t *testing.T) {
@@ -17,7 +18,7 @@ func TestDistribution(t *testing.T) {
 			Template("create distribution origin-domain=my.test.domain.com certificate=arn:of:the:certificate comment='useless comment' default-file=index.go "+
 				"domain-aliases=any.domain.com,other.domain.com enable=true forward-cookies=whitelist forward-queries=true https-behaviour=redirect-to-https "+
 				"origin-path=/my/custom/path price-class=PriceClass_All min-ttl=42").
-				Mock(&cloudfrontMock{
+				Mock(&awstest.CloudfrontMock{
 					CreateDistributionFunc: func(param0 *cloudfront.CreateDistributionInput) (*cloudfront.CreateDistributionOutput, error) {
 						return &cloudfront.CreateDistributionOutput{Distribution: &cloudfront.Distribution{Id: String("new-distribution-id")}}, nil
 					},
@@ -67,7 +68,7 @@ func TestDistribution(t *testing.T) {
 		})
 		t.Run("one parameter", func(t *testing.T) {
 			Template("create distribution origin-domain=my.test.domain.com").
-				Mock(&cloudfrontMock{
+				Mock(&awstest.CloudfrontMock{
 					CreateDistributionFunc: func(param0 *cloudfront.CreateDistributionInput) (*cloudfront.CreateDistributionOutput, error) {
 						return &cloudfront.CreateDistributionOutput{Distribution: &cloudfront.Distribution{Id: String("new-distribution-id")}}, nil
 					},
@@ -106,7 +107,7 @@ func TestDistribution(t *testing.T) {
 
 	t.Run("update", func(t *testing.T) {
 		t.Run("already enabled", func(t *testing.T) {
-			Template("update distribution id=my-distribution-to-update enable=true").Mock(&cloudfrontMock{
+			Template("update distribution id=my-distribution-to-update enable=true").Mock(&awstest.CloudfrontMock{
 				GetDistributionFunc: func(input *cloudfront.GetDistributionInput) (*cloudfront.GetDistributionOutput, error) {
 					return &cloudfront.GetDistributionOutput{
 						ETag: String("etag-already-enabled"),
@@ -123,7 +124,7 @@ func TestDistribution(t *testing.T) {
 		})
 
 		t.Run("to enable", func(t *testing.T) {
-			Template("update distribution id=my-distribution-to-update enable=true").Mock(&cloudfrontMock{
+			Template("update distribution id=my-distribution-to-update enable=true").Mock(&awstest.CloudfrontMock{
 				GetDistributionFunc: func(input *cloudfront.GetDistributionInput) (*cloudfront.GetDistributionOutput, error) {
 					return &cloudfront.GetDistributionOutput{
 						Distribution: &cloudfront.Distribution{
@@ -153,7 +154,7 @@ func TestDistribution(t *testing.T) {
 		t.Run("already enabled and change other params", func(t *testing.T) {
 			Template("update distribution id=my-distribution-to-update origin-domain=my.test.domain.com certificate=arn:of:the:certificate comment='useless comment' default-file=index.go "+
 				"domain-aliases=any.domain.com,other.domain.com enable=true forward-cookies=whitelist forward-queries=true https-behaviour=redirect-to-https "+
-				"origin-path=/my/custom/path price-class=PriceClass_All min-ttl=42").Mock(&cloudfrontMock{
+				"origin-path=/my/custom/path price-class=PriceClass_All min-ttl=42").Mock(&awstest.CloudfrontMock{
 				GetDistributionFunc: func(input *cloudfront.GetDistributionInput) (*cloudfront.GetDistributionOutput, error) {
 					return &cloudfront.GetDistributionOutput{
 						ETag: String("etag-already-enabled"),
@@ -213,7 +214,7 @@ func TestDistribution(t *testing.T) {
 	})
 
 	t.Run("check", func(t *testing.T) {
-		Template("check distribution id=my-distribution-id state=deployed timeout=1").Mock(&cloudfrontMock{
+		Template("check distribution id=my-distribution-id state=deployed timeout=1").Mock(&awstest.CloudfrontMock{
 			GetDistributionFunc: func(input *cloudfront.GetDistributionInput) (*cloudfront.GetDistributionOutput, error) {
 				return &cloudfront.GetDistributionOutput{
 					Distribution: &cloudfront.Distribution{
@@ -226,7 +227,7 @@ func TestDistribution(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete distribution id=my-distribution-to-delete").Mock(&cloudfrontMock{
+		Template("delete distribution id=my-distribution-to-delete").Mock(&awstest.CloudfrontMock{
 			GetDistributionFunc: func(input *cloudfront.GetDistributionInput) (*cloudfront.GetDistributionOutput, error) {
 				return &cloudfront.GetDistributionOutput{
 					Distribution: &cloudfront.Distribution{
@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestElasticip(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create elasticip domain=vpc").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				AllocateAddressFunc: func(param0 *ec2.AllocateAddressInput) (*ec2.AllocateAddressOutput, error) {
 					return &ec2.AllocateAddressOutput{AllocationId: String("new-elasticip-allocation-id")}, nil
 				},
@@ -22,7 +23,7 @@ func TestElasticip(t *testing.T) {
 	t.Run("delete", func(t *testing.T) {
 		t.Run("by id", func(t *testing.T) {
 			Template("delete elasticip id=eipalloc-0123456").
-				Mock(&ec2Mock{
+				Mock(&awstest.Ec2Mock{
 					ReleaseAddressFunc: func(param0 *ec2.ReleaseAddressInput) (*ec2.ReleaseAddressOutput, error) {
 						return nil, nil
 					},
@@ -31,7 +32,7 @@ func TestElasticip(t *testing.T) {
 		})
 		t.Run("by ip", func(t *testing.T) {
 			Template("delete elasticip ip=127.0.0.1").
-				Mock(&ec2Mock{
+				Mock(&awstest.Ec2Mock{
 					ReleaseAddressFunc: func(param0 *ec2.ReleaseAddressInput) (*ec2.ReleaseAddressOutput, error) {
 						return nil, nil
 					},
@@ -42,7 +43,7 @@ func TestElasticip(t *testing.T) {
 
 	t.Run("attach", func(t *testing.T) {
 		Template("attach elasticip id=eipalloc-0123456 instance=i-1234 networkinterface=eni-2345 privateip=10.0.0.42 allow-reassociation=true").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				AssociateAddressFunc: func(param0 *ec2.AssociateAddressInput) (*ec2.AssociateAddressOutput, error) {
 					return &ec2.AssociateAddressOutput{AssociationId: String("ip-assoc-id")}, nil
 				},
@@ -57,7 +58,7 @@ func TestElasticip(t *testing.T) {
 
 	t.Run("detach", func(t *testing.T) {
 		Template("detach elasticip association=ipassoc-12345").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				DisassociateAddressFunc: func(param0 *ec2.DisassociateAddressInput) (*ec2.DisassociateAddressOutput, error) {
 					return nil, nil
 				},
@@ -9,6 +9,7 @@ import (
 	"os"
 
 	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestFunction(t *testing.T) {
@@ -16,7 +17,7 @@ func TestFunction(t *testing.T) {
 		t.Run("from s3 file", func(t *testing.T) {
 			Template("create function name=my-function-name handler=lambda_handler role=arn:of:function:role runtime=python3.6 "+
 				"bucket=my-function-bucket object=my/function/object.zip objectversion=v3 description='this is my function' memory=128 publish=true timeout=60").
-				Mock(&lambdaMock{
+				Mock(&awstest.LambdaMock{
 					CreateFunctionFunc: func(param0 *lambda.CreateFunctionInput) (*lambda.FunctionConfiguration, error) {
 						return &lambda.FunctionConfiguration{FunctionArn: String("new-function-id")}, nil
 					},
@@ -47,7 +48,7 @@ func TestFunction(t *testing.T) {
 			ioutil.WriteFile(tmpFile.Name(), []byte("this is the content of my file"), 0777)
 			Template(fmt.Sprintf("create function name=my-function-name handler=lambda_handler role=arn:of:function:role runtime=python3.6 "+
 				"zipfile=%s", tmpFile.Name())).
-				Mock(&lambdaMock{
+				Mock(&awstest.LambdaMock{
 					CreateFunctionFunc: func(param0 *lambda.CreateFunctionInput) (*lambda.FunctionConfiguration, error) {
 						return &lambda.FunctionConfiguration{FunctionArn: String("new-function-id")}, nil
 					},
@@ -65,7 +66,7 @@ func TestFunction(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete function id=function-to-delete version=v2").
-			Mock(&lambdaMock{
+			Mock(&awstest.LambdaMock{
 				DeleteFunctionFunc: func(param0 *lambda.DeleteFunctionInput) (*lambda.DeleteFunctionOutput, error) { return nil, nil },
 			}).ExpectInput("DeleteFunction", &lambda.DeleteFunctionInput{
 			FunctionName: String("function-to-delete"),
@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestGroup(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create group name=my-group").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
 				CreateGroupFunc: func(param0 *iam.CreateGroupInput) (*iam.CreateGroupOutput, error) {
 					return &iam.CreateGroupOutput{Group: &iam.Group{GroupId: String("new-group-id")}}, nil
 				},
@@ -19,7 +20,7 @@ func TestGroup(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete group name=my-group").Mock(&iamMock{
+		Template("delete group name=my-group").Mock(&awstest.IamMock{
 			DeleteGroupFunc: func(param0 *iam.DeleteGroupInput) (*iam.DeleteGroupOutput, error) { return nil, nil },
 		}).ExpectInput("DeleteGroup", &iam.DeleteGroupInput{GroupName: String("my-group")}).
 			ExpectCalls("DeleteGroup").Run(t)
@@ -4,13 +4,14 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestImage(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		t.Run("with reboot", func(t *testing.T) {
 			Template("create image name=my-image-name instance=my-instance-id reboot=true description='an new image'").
-				Mock(&ec2Mock{
+				Mock(&awstest.Ec2Mock{
 					CreateImageFunc: func(param0 *ec2.CreateImageInput) (*ec2.CreateImageOutput, error) {
 						return &ec2.CreateImageOutput{ImageId: String("new-image-id")}, nil
 					},
@@ -23,7 +24,7 @@ func TestImage(t *testing.T) {
 
 		t.Run("with no reboot", func(t *testing.T) {
 			Template("create image name=my-image-name instance=my-instance-id description='an new image'").
-				Mock(&ec2Mock{
+				Mock(&awstest.Ec2Mock{
 					CreateImageFunc: func(param0 *ec2.CreateImageInput) (*ec2.CreateImageOutput, error) {
 						return &ec2.CreateImageOutput{ImageId: String("new-image-id")}, nil
 					},
@@ -38,7 +39,7 @@ func TestImage(t *testing.T) {
 
 	t.Run("copy", func(t *testing.T) {
 		Template("copy image name=my-image-name source-id=my-origin-id source-region=my-origin-region encrypted=true description='an encrypted image'").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				CopyImageFunc: func(param0 *ec2.CopyImageInput) (*ec2.CopyImageOutput, error) {
 					return &ec2.CopyImageOutput{ImageId: String("my-imagecopy-id")}, nil
 				},
@@ -54,7 +55,7 @@ func TestImage(t *testing.T) {
 	t.Run("import", func(t *testing.T) {
 		t.Run("from ebs snapshot", func(t *testing.T) {
 			Template("import image architecture=x86_64 description='my image desc' license=BYOL platform=Linux role=vmimport snapshot=my-ebs-snapshot").
-				Mock(&ec2Mock{
+				Mock(&awstest.Ec2Mock{
 					ImportImageFunc: func(param0 *ec2.ImportImageInput) (*ec2.ImportImageOutput, error) {
 						return &ec2.ImportImageOutput{ImportTaskId: String("my-import-task-id")}, nil
 					},
@@ -71,7 +72,7 @@ func TestImage(t *testing.T) {
 		})
 		t.Run("from url", func(t *testing.T) {
 			Template("import image url=http://download.image.from.here").
-				Mock(&ec2Mock{
+				Mock(&awstest.Ec2Mock{
 					ImportImageFunc: func(param0 *ec2.ImportImageInput) (*ec2.ImportImageOutput, error) {
 						return &ec2.ImportImageOutput{ImportTaskId: String("my-import-task-id")}, nil
 					},
@@ -83,7 +84,7 @@ func TestImage(t *testing.T) {
 		})
 		t.Run("from s3", func(t *testing.T) {
 			Template("import image bucket=my-bucket s3object=my/s3/image/file.img").
-				Mock(&ec2Mock{
+				Mock(&awstest.Ec2Mock{
 					ImportImageFunc: func(param0 *ec2.ImportImageInput) (*ec2.ImportImageOutput, error) {
 						return &ec2.ImportImageOutput{ImportTaskId: String("my-import-task-id")}, nil
 					},
@@ -100,7 +101,7 @@ func TestImage(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete image id=ami-to-delete delete-snapshots=true").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				DescribeImagesFunc: func(param0 *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
 					return &ec2.DescribeImagesOutput{
 						Images: []*ec2.Image{{BlockDeviceMappings: []*ec2.BlockDeviceMapping{
@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestInstance(t *testing.T) {
@@ -22,7 +23,7 @@ func TestInstance(t *testing.T) {
 			"create instance count=3 image=ami-1234 "+
 			"name=myinstance subnet=sub_1 type=t2.nano keypair=mykp ip=10.2.3.4 "+
 			"userdata="+userdataFile+" securitygroup=sg-1234 lock=true role=myrole").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				RunInstancesFunc: func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
 					return &ec2.Reservation{Instances: []*ec2.Instance{{InstanceId: String("new-instance-id")}}}, nil
 				},
@@ -51,29 +52,68 @@ func TestInstance(t *testing.T) {
 		}).ExpectCommandResult("new-instance-id").ExpectCalls("RunInstances", "CreateTagsRequest").Run(t)
 	})
 
+	t.Run("create with inline userdata", func(t *testing.T) {
+		Template("oneRef=awesome\n"+
+			"create instance count=1 image=ami-1234 name=myinstance subnet=sub_1 type=t2.nano userdata=<<EOF\n"+
+			"#!/bin/bash\n"+
+			"echo {{ .Variables.oneRef }}\n"+
+			"EOF").
+			Mock(&awstest.Ec2Mock{
+				RunInstancesFunc: func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+					return &ec2.Reservation{Instances: []*ec2.Instance{{InstanceId: String("new-instance-id")}}}, nil
+				},
+				CreateTagsRequestFunc: func(input *ec2.CreateTagsInput) (req *request.Request, output *ec2.CreateTagsOutput) {
+					output = &ec2.CreateTagsOutput{}
+					req = request.New(aws.Config{}, metadata.ClientInfo{}, request.Handlers{}, nil, &request.Operation{}, input, output)
+					return
+				},
+			}).ExpectInput("RunInstances", &ec2.RunInstancesInput{
+			SubnetId:     String("sub_1"),
+			ImageId:      String("ami-1234"),
+			InstanceType: String("t2.nano"),
+			MinCount:     Int64(1),
+			MaxCount:     Int64(1),
+			UserData:     String(base64.StdEncoding.EncodeToString([]byte("#!/bin/bash\necho awesome"))),
+		}).ExpectInput("CreateTagsRequest", &ec2.CreateTagsInput{
+			Resources: []*string{String("new-instance-id")},
+			Tags: []*ec2.Tag{
+				{Key: String("Name"), Value: String("myinstance")},
+			},
+		}).ExpectCommandResult("new-instance-id").ExpectCalls("RunInstances", "CreateTagsRequest").Run(t)
+	})
+
 	t.Run("update", func(t *testing.T) {
-		Template("update instance id=id-1234 type=t2.micro lock=true").Mock(&ec2Mock{
+		Template("update instance id=id-1234 type=t2.micro lock=true").Mock(&awstest.Ec2Mock{
+			DescribeInstancesFunc: func(param0 *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+				return &ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{{InstanceId: String("id-1234"), InstanceType: String("t2.nano")}}}}}, nil
+			},
+			DescribeInstanceAttributeFunc: func(param0 *ec2.DescribeInstanceAttributeInput) (*ec2.DescribeInstanceAttributeOutput, error) {
+				return &ec2.DescribeInstanceAttributeOutput{DisableApiTermination: &ec2.AttributeBooleanValue{Value: Bool(false)}}, nil
+			},
 			ModifyInstanceAttributeFunc: func(param0 *ec2.ModifyInstanceAttributeInput) (*ec2.ModifyInstanceAttributeOutput, error) {
 				return nil, nil
 			},
-		}).ExpectInput("ModifyInstanceAttribute", &ec2.ModifyInstanceAttributeInput{
-			InstanceId:            String("id-1234"),
-			InstanceType:          &ec2.AttributeValue{Value: String("t2.micro")},
-			DisableApiTermination: &ec2.AttributeBooleanValue{Value: Bool(true)},
-		}).
-			ExpectCalls("ModifyInstanceAttribute").Run(t)
+		}).ExpectInput("DescribeInstances", &ec2.DescribeInstancesInput{InstanceIds: []*string{String("id-1234")}}).
+			ExpectInput("DescribeInstanceAttribute", &ec2.DescribeInstanceAttributeInput{InstanceId: String("id-1234"), Attribute: String("disableApiTermination")}).
+			ExpectInput("ModifyInstanceAttribute", &ec2.ModifyInstanceAttributeInput{
+				InstanceId:            String("id-1234"),
+				InstanceType:          &ec2.AttributeValue{Value: String("t2.micro")},
+				DisableApiTermination: &ec2.AttributeBooleanValue{Value: Bool(true)},
+			}).
+			ExpectCalls("DescribeInstances", "DescribeInstanceAttribute", "ModifyInstanceAttribute").
+			ExpectRevert("update instance id=id-1234 lock=false type=t2.nano").Run(t)
 	})
 
 	t.Run("delete", func(t *testing.T) {
 		t.Run("one id", func(t *testing.T) {
-			Template("delete instance id=id-1234").Mock(&ec2Mock{
+			Template("delete instance id=id-1234").Mock(&awstest.Ec2Mock{
 				TerminateInstancesFunc: func(param0 *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error) { return nil, nil },
 			}).ExpectInput("TerminateInstances", &ec2.TerminateInstancesInput{InstanceIds: []*string{String("id-1234")}}).
 				ExpectCalls("TerminateInstances").Run(t)
 		})
 
 		t.Run("multiple ids", func(t *testing.T) {
-			Template("delete instance ids=id-1234,id-2345").Mock(&ec2Mock{
+			Template("delete instance ids=id-1234,id-2345").Mock(&awstest.Ec2Mock{
 				TerminateInstancesFunc: func(param0 *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error) { return nil, nil },
 			}).ExpectInput("TerminateInstances", &ec2.TerminateInstancesInput{InstanceIds: []*string{String("id-1234"), String("id-2345")}}).
 				ExpectCalls("TerminateInstances").Run(t)
@@ -82,7 +122,7 @@ func TestInstance(t *testing.T) {
 
 	t.Run("start", func(t *testing.T) {
 		t.Run("one id", func(t *testing.T) {
-			Template("start instance id=id-1234").Mock(&ec2Mock{
+			Template("start instance id=id-1234").Mock(&awstest.Ec2Mock{
 				StartInstancesFunc: func(param0 *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error) {
 					return &ec2.StartInstancesOutput{
 						StartingInstances: []*ec2.InstanceStateChange{{InstanceId: String("id-1234")}}}, nil
@@ -92,7 +132,7 @@ func TestInstance(t *testing.T) {
 		})
 
 		t.Run("multiple ids", func(t *testing.T) {
-			Template("start instance id=id-1234,id-2345").Mock(&ec2Mock{
+			Template("start instance id=id-1234,id-2345").Mock(&awstest.Ec2Mock{
 				StartInstancesFunc: func(param0 *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error) {
 					return &ec2.StartInstancesOutput{
 						StartingInstances: []*ec2.InstanceStateChange{{InstanceId: String("id-1234")}, {InstanceId: String("id-2345")}}}, nil
@@ -104,7 +144,7 @@ func TestInstance(t *testing.T) {
 
 	t.Run("stop", func(t *testing.T) {
 		t.Run("one id", func(t *testing.T) {
-			Template("stop instance id=id-1234").Mock(&ec2Mock{
+			Template("stop instance id=id-1234").Mock(&awstest.Ec2Mock{
 				StopInstancesFunc: func(param0 *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error) {
 					return &ec2.StopInstancesOutput{
 						StoppingInstances: []*ec2.InstanceStateChange{{InstanceId: String("id-1234")}}}, nil
@@ -114,7 +154,7 @@ func TestInstance(t *testing.T) {
 		})
 
 		t.Run("multiple ids", func(t *testing.T) {
-			Template("stop instance id=id-1234,id-2345").Mock(&ec2Mock{
+			Template("stop instance id=id-1234,id-2345").Mock(&awstest.Ec2Mock{
 				StopInstancesFunc: func(param0 *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error) {
 					return &ec2.StopInstancesOutput{
 						StoppingInstances: []*ec2.InstanceStateChange{{InstanceId: String("id-1234")}, {InstanceId: String("id-2345")}}}, nil
@@ -125,7 +165,7 @@ func TestInstance(t *testing.T) {
 	})
 
 	t.Run("check", func(t *testing.T) {
-		Template("check instance id=id-1234 state=running timeout=0").Mock(&ec2Mock{
+		Template("check instance id=id-1234 state=running timeout=0").Mock(&awstest.Ec2Mock{
 			DescribeInstancesFunc: func(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
 				return &ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{
 					{Instances: []*ec2.Instance{{InstanceId: input.InstanceIds[0], State: &ec2.InstanceState{Name: String("running")}}}},
@@ -135,7 +175,7 @@ func TestInstance(t *testing.T) {
 	})
 
 	t.Run("attach", func(t *testing.T) {
-		Template("attach instance id=id-1234 targetgroup=arn:of:target:group port=8080").Mock(&elbv2Mock{
+		Template("attach instance id=id-1234 targetgroup=arn:of:target:group port=8080").Mock(&awstest.Elbv2Mock{
 			RegisterTargetsFunc: func(param0 *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error) {
 				return nil, nil
 			},
@@ -149,7 +189,7 @@ func TestInstance(t *testing.T) {
 	})
 
 	t.Run("detach", func(t *testing.T) {
-		Template("detach instance id=id-1234 targetgroup=arn:of:target:group").Mock(&elbv2Mock{
+		Template("detach instance id=id-1234 targetgroup=arn:of:target:group").Mock(&awstest.Elbv2Mock{
 			DeregisterTargetsFunc: func(param0 *elbv2.DeregisterTargetsInput) (*elbv2.DeregisterTargetsOutput, error) {
 				return nil, nil
 			},
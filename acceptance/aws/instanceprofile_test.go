@@ -5,11 +5,12 @@ import (
 
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestInstanceprofile(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
-		Template("create instanceprofile name=my-instance-profile").Mock(&iamMock{
+		Template("create instanceprofile name=my-instance-profile").Mock(&awstest.IamMock{
 			CreateInstanceProfileFunc: func(input *iam.CreateInstanceProfileInput) (*iam.CreateInstanceProfileOutput, error) {
 				return nil, nil
 			}}).
@@ -19,7 +20,7 @@ func TestInstanceprofile(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete instanceprofile name=my-instance-profile").Mock(&iamMock{
+		Template("delete instanceprofile name=my-instance-profile").Mock(&awstest.IamMock{
 			DeleteInstanceProfileFunc: func(input *iam.DeleteInstanceProfileInput) (*iam.DeleteInstanceProfileOutput, error) {
 				return nil, nil
 			}}).
@@ -30,7 +31,7 @@ func TestInstanceprofile(t *testing.T) {
 
 	t.Run("attach", func(t *testing.T) {
 		t.Run("without previous associations", func(t *testing.T) {
-			Template("attach instanceprofile name=my-instance-profile instance=i-12345 replace=true").Mock(&ec2Mock{
+			Template("attach instanceprofile name=my-instance-profile instance=i-12345 replace=true").Mock(&awstest.Ec2Mock{
 				DescribeIamInstanceProfileAssociationsFunc: func(input *ec2.DescribeIamInstanceProfileAssociationsInput) (*ec2.DescribeIamInstanceProfileAssociationsOutput, error) {
 					return &ec2.DescribeIamInstanceProfileAssociationsOutput{IamInstanceProfileAssociations: []*ec2.IamInstanceProfileAssociation{}}, nil
 				},
@@ -50,7 +51,7 @@ func TestInstanceprofile(t *testing.T) {
 			}).ExpectCalls("DescribeIamInstanceProfileAssociations", "AssociateIamInstanceProfile").Run(t)
 		})
 		t.Run("with existing associations", func(t *testing.T) {
-			Template("attach instanceprofile name=my-instance-profile instance=i-12345 replace=true").Mock(&ec2Mock{
+			Template("attach instanceprofile name=my-instance-profile instance=i-12345 replace=true").Mock(&awstest.Ec2Mock{
 				DescribeIamInstanceProfileAssociationsFunc: func(input *ec2.DescribeIamInstanceProfileAssociationsInput) (*ec2.DescribeIamInstanceProfileAssociationsOutput, error) {
 					return &ec2.DescribeIamInstanceProfileAssociationsOutput{
 						IamInstanceProfileAssociations: []*ec2.IamInstanceProfileAssociation{
@@ -76,7 +77,7 @@ func TestInstanceprofile(t *testing.T) {
 	})
 
 	t.Run("detach", func(t *testing.T) {
-		Template("detach instanceprofile name=my-instance-profile instance=i-12345").Mock(&ec2Mock{
+		Template("detach instanceprofile name=my-instance-profile instance=i-12345").Mock(&awstest.Ec2Mock{
 			DescribeIamInstanceProfileAssociationsFunc: func(input *ec2.DescribeIamInstanceProfileAssociationsInput) (*ec2.DescribeIamInstanceProfileAssociationsOutput, error) {
 				return &ec2.DescribeIamInstanceProfileAssociationsOutput{
 					IamInstanceProfileAssociations: []*ec2.IamInstanceProfileAssociation{
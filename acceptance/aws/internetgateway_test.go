@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestInternetGateway(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create internetgateway").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				CreateInternetGatewayFunc: func(param0 *ec2.CreateInternetGatewayInput) (*ec2.CreateInternetGatewayOutput, error) {
 					return &ec2.CreateInternetGatewayOutput{InternetGateway: &ec2.InternetGateway{InternetGatewayId: String("new-internetgateway-id")}}, nil
 				},
@@ -19,7 +20,7 @@ func TestInternetGateway(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete internetgateway id=igw-1234").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				DeleteInternetGatewayFunc: func(param0 *ec2.DeleteInternetGatewayInput) (*ec2.DeleteInternetGatewayOutput, error) {
 					return nil, nil
 				},
@@ -29,7 +30,7 @@ func TestInternetGateway(t *testing.T) {
 
 	t.Run("attach", func(t *testing.T) {
 		Template("attach internetgateway id=igw-1234 vpc=vpc-2345").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				AttachInternetGatewayFunc: func(param0 *ec2.AttachInternetGatewayInput) (*ec2.AttachInternetGatewayOutput, error) {
 					return nil, nil
 				},
@@ -42,7 +43,7 @@ func TestInternetGateway(t *testing.T) {
 
 	t.Run("detach", func(t *testing.T) {
 		Template("detach internetgateway id=igw-1234 vpc=vpc-2345").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				DetachInternetGatewayFunc: func(param0 *ec2.DetachInternetGatewayInput) (*ec2.DetachInternetGatewayOutput, error) {
 					return nil, nil
 				},
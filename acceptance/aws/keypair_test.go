@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/aws/test"
 	"github.com/wallix/awless/console"
 )
 
@@ -28,7 +29,7 @@ func TestKeypair(t *testing.T) {
 		os.Setenv("__AWLESS_KEYS_DIR", tmpFolder)
 
 		Template("create keypair name=my-kp encrypted=true").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				ImportKeyPairFunc: func(param0 *ec2.ImportKeyPairInput) (*ec2.ImportKeyPairOutput, error) {
 					return &ec2.ImportKeyPairOutput{KeyName: String("my-kp")}, nil
 				},
@@ -41,7 +42,7 @@ func TestKeypair(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete keypair name=kp-to-delete").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				DeleteKeyPairFunc: func(param0 *ec2.DeleteKeyPairInput) (*ec2.DeleteKeyPairOutput, error) {
 					return nil, nil
 				},
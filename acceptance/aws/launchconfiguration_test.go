@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestLaunchConfiguration(t *testing.T) {
@@ -16,7 +17,7 @@ func TestLaunchConfiguration(t *testing.T) {
 			Template("oneRef=awesome\n"+
 				"create launchconfiguration name=new-launchconfiguration type=t2.nano image=ami-1234 public=true "+
 				"keypair=an-existing-kp userdata="+userdataFile+" securitygroups=sg-1234,sg-2345 role=my-role spotprice=12.5").
-				Mock(&autoscalingMock{
+				Mock(&awstest.AutoscalingMock{
 					CreateLaunchConfigurationFunc: func(param0 *autoscaling.CreateLaunchConfigurationInput) (*autoscaling.CreateLaunchConfigurationOutput, error) {
 						return &autoscaling.CreateLaunchConfigurationOutput{}, nil
 					},
@@ -36,7 +37,7 @@ func TestLaunchConfiguration(t *testing.T) {
 			Template("oneRef=awesome\n"+
 				"create launchconfiguration name=new-launchconfiguration type=t2.nano distro=debian public=true "+
 				"keypair=an-existing-kp userdata="+userdataFile+" securitygroups=sg-1234,sg-2345 role=my-role spotprice=12.5").
-				Mock(&autoscalingMock{
+				Mock(&awstest.AutoscalingMock{
 					CreateLaunchConfigurationFunc: func(param0 *autoscaling.CreateLaunchConfigurationInput) (*autoscaling.CreateLaunchConfigurationOutput, error) {
 						return &autoscaling.CreateLaunchConfigurationOutput{}, nil
 					},
@@ -56,7 +57,7 @@ func TestLaunchConfiguration(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete launchconfiguration name=my-launchconfig-to-delete").
-			Mock(&autoscalingMock{
+			Mock(&awstest.AutoscalingMock{
 				DeleteLaunchConfigurationFunc: func(param0 *autoscaling.DeleteLaunchConfigurationInput) (*autoscaling.DeleteLaunchConfigurationOutput, error) {
 					return nil, nil
 				},
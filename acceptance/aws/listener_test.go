@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestListener(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create listener actiontype=forward loadbalancer=arn:of:loadbalancer port=80 "+
-			"protocol=HTTP targetgroup=arn:of:targetgroup certificate=arn:of:certificate sslpolicy=ELBSecurityPolicy-2016-08").Mock(&elbv2Mock{
+			"protocol=HTTP targetgroup=arn:of:targetgroup certificate=arn:of:certificate sslpolicy=ELBSecurityPolicy-2016-08").Mock(&awstest.Elbv2Mock{
 			CreateListenerFunc: func(input *elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error) {
 				return &elbv2.CreateListenerOutput{Listeners: []*elbv2.Listener{
 					{ListenerArn: String("arn:of:new:listener")},
@@ -28,7 +29,7 @@ func TestListener(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete listener id=arn:of:listener:to:delete").Mock(&elbv2Mock{
+		Template("delete listener id=arn:of:listener:to:delete").Mock(&awstest.Elbv2Mock{
 			DeleteListenerFunc: func(input *elbv2.DeleteListenerInput) (*elbv2.DeleteListenerOutput, error) {
 				return nil, nil
 			}}).
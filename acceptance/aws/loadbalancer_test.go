@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestLoadbalancer(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create loadbalancer name=my-new-loadbalancer subnets=sub-1234,sub-2345 subnet-mappings=sub-1234:eipalloc-321, sub-2345:eipalloc-678 "+
-			"iptype=ipv4 scheme=Internet-facing securitygroups=sg-1234,sg-2345 type=network").Mock(&elbv2Mock{
+			"iptype=ipv4 scheme=Internet-facing securitygroups=sg-1234,sg-2345 type=network").Mock(&awstest.Elbv2Mock{
 			CreateLoadBalancerFunc: func(input *elbv2.CreateLoadBalancerInput) (*elbv2.CreateLoadBalancerOutput, error) {
 				return &elbv2.CreateLoadBalancerOutput{LoadBalancers: []*elbv2.LoadBalancer{
 					{LoadBalancerArn: String("arn:of:new:loadbalancer")},
@@ -27,7 +28,7 @@ func TestLoadbalancer(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete loadbalancer id=arn:of:loadbalancer:to:delete").Mock(&elbv2Mock{
+		Template("delete loadbalancer id=arn:of:loadbalancer:to:delete").Mock(&awstest.Elbv2Mock{
 			DeleteLoadBalancerFunc: func(input *elbv2.DeleteLoadBalancerInput) (*elbv2.DeleteLoadBalancerOutput, error) {
 				return nil, nil
 			}}).
@@ -37,7 +38,7 @@ func TestLoadbalancer(t *testing.T) {
 	})
 
 	t.Run("check", func(t *testing.T) {
-		Template("check loadbalancer id=arn:of:loadbalancer:to:check state=active timeout=1").Mock(&elbv2Mock{
+		Template("check loadbalancer id=arn:of:loadbalancer:to:check state=active timeout=1").Mock(&awstest.Elbv2Mock{
 			DescribeLoadBalancersFunc: func(input *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
 				return &elbv2.DescribeLoadBalancersOutput{LoadBalancers: []*elbv2.LoadBalancer{
 					{LoadBalancerArn: String("arn:of:loadbalancer:to:check"), State: &elbv2.LoadBalancerState{Code: String("active")}},
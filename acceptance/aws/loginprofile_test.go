@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestLoginProfile(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create loginprofile username=jdoe password=temporary-password password-reset=true").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
 				CreateLoginProfileFunc: func(*iam.CreateLoginProfileInput) (*iam.CreateLoginProfileOutput, error) {
 					return &iam.CreateLoginProfileOutput{LoginProfile: &iam.LoginProfile{UserName: String("jdoe")}}, nil
 				},
@@ -23,20 +24,24 @@ func TestLoginProfile(t *testing.T) {
 
 	t.Run("update", func(t *testing.T) {
 		Template("update loginprofile username=jdoe password=temporary-password password-reset=true").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
+				GetLoginProfileFunc: func(param0 *iam.GetLoginProfileInput) (*iam.GetLoginProfileOutput, error) {
+					return &iam.GetLoginProfileOutput{LoginProfile: &iam.LoginProfile{UserName: String("jdoe"), PasswordResetRequired: Bool(false)}}, nil
+				},
 				UpdateLoginProfileFunc: func(param0 *iam.UpdateLoginProfileInput) (*iam.UpdateLoginProfileOutput, error) {
 					return nil, nil
 				},
-			}).ExpectInput("UpdateLoginProfile", &iam.UpdateLoginProfileInput{
+			}).ExpectInput("GetLoginProfile", &iam.GetLoginProfileInput{UserName: String("jdoe")}).ExpectInput("UpdateLoginProfile", &iam.UpdateLoginProfileInput{
 			UserName:              String("jdoe"),
 			Password:              String("temporary-password"),
 			PasswordResetRequired: Bool(true),
-		}).ExpectCalls("UpdateLoginProfile").Run(t)
+		}).ExpectCalls("GetLoginProfile", "UpdateLoginProfile").
+			ExpectRevert("update loginprofile password-reset=false password={secret:loginprofile.password} username=jdoe").Run(t)
 	})
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete loginprofile username=jdoe").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
 				DeleteLoginProfileFunc: func(param0 *iam.DeleteLoginProfileInput) (*iam.DeleteLoginProfileOutput, error) {
 					return nil, nil
 				},
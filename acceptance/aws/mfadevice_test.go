@@ -4,13 +4,14 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestMFADevice(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		defer redirectStdErrToDevNull()()
 		Template("create mfadevice name=my-new-mfadevice").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
 				CreateVirtualMFADeviceFunc: func(param0 *iam.CreateVirtualMFADeviceInput) (*iam.CreateVirtualMFADeviceOutput, error) {
 					return &iam.CreateVirtualMFADeviceOutput{VirtualMFADevice: &iam.VirtualMFADevice{SerialNumber: String("arn:new:mfadevice")}}, nil
 				},
@@ -22,7 +23,7 @@ func TestMFADevice(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete mfadevice id=arn:mfadevice:to:delete").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
 				DeleteVirtualMFADeviceFunc: func(param0 *iam.DeleteVirtualMFADeviceInput) (*iam.DeleteVirtualMFADeviceOutput, error) {
 					return nil, nil
 				},
@@ -32,7 +33,7 @@ func TestMFADevice(t *testing.T) {
 
 	t.Run("attach", func(t *testing.T) {
 		Template("attach mfadevice id=arn:mfadevice:to:attach user=my-username mfa-code-1=012345 mfa-code-2=123456 no-prompt=true").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
 				EnableMFADeviceFunc: func(param0 *iam.EnableMFADeviceInput) (*iam.EnableMFADeviceOutput, error) { return nil, nil },
 			}).ExpectInput("EnableMFADevice", &iam.EnableMFADeviceInput{
 			SerialNumber:        String("arn:mfadevice:to:attach"),
@@ -45,7 +46,7 @@ func TestMFADevice(t *testing.T) {
 
 	t.Run("detach", func(t *testing.T) {
 		Template("detach mfadevice id=arn:mfadevice:to:detach user=my-username").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
 				DeactivateMFADeviceFunc: func(param0 *iam.DeactivateMFADeviceInput) (*iam.DeactivateMFADeviceOutput, error) {
 					return nil, nil
 				},
@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestNATGateway(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create natgateway elasticip-id=eip-12345 subnet=sub-23456").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				CreateNatGatewayFunc: func(param0 *ec2.CreateNatGatewayInput) (*ec2.CreateNatGatewayOutput, error) {
 					return &ec2.CreateNatGatewayOutput{NatGateway: &ec2.NatGateway{NatGatewayId: String("new-natgateway-id")}}, nil
 				},
@@ -22,7 +23,7 @@ func TestNATGateway(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete natgateway id=ngw-1234").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				DeleteNatGatewayFunc: func(param0 *ec2.DeleteNatGatewayInput) (*ec2.DeleteNatGatewayOutput, error) {
 					return nil, nil
 				},
@@ -32,7 +33,7 @@ func TestNATGateway(t *testing.T) {
 
 	t.Run("check", func(t *testing.T) {
 		Template("check natgateway id=ngw-1234 state=available timeout=1").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				DescribeNatGatewaysFunc: func(param0 *ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error) {
 					return &ec2.DescribeNatGatewaysOutput{NatGateways: []*ec2.NatGateway{
 						{NatGatewayId: String("ngw-1234"), State: String("available")},
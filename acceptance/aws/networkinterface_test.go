@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestNetworkInterface(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create networkinterface subnet=sub-1234 description='my ni desc' securitygroups=sg-1234,sg-2345 privateip=127.0.0.1").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				CreateNetworkInterfaceFunc: func(param0 *ec2.CreateNetworkInterfaceInput) (*ec2.CreateNetworkInterfaceOutput, error) {
 					return &ec2.CreateNetworkInterfaceOutput{NetworkInterface: &ec2.NetworkInterface{NetworkInterfaceId: String("new-networkinterface-id")}}, nil
 				},
@@ -24,7 +25,7 @@ func TestNetworkInterface(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete networkinterface id=ni-1234").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				DeleteNetworkInterfaceFunc: func(param0 *ec2.DeleteNetworkInterfaceInput) (*ec2.DeleteNetworkInterfaceOutput, error) {
 					return nil, nil
 				},
@@ -34,7 +35,7 @@ func TestNetworkInterface(t *testing.T) {
 
 	t.Run("attach", func(t *testing.T) {
 		Template("attach networkinterface id=ni-1234 instance=i-2345 device-index=2").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				AttachNetworkInterfaceFunc: func(param0 *ec2.AttachNetworkInterfaceInput) (*ec2.AttachNetworkInterfaceOutput, error) {
 					return &ec2.AttachNetworkInterfaceOutput{AttachmentId: String("attach-ni-id")}, nil
 				},
@@ -49,7 +50,7 @@ func TestNetworkInterface(t *testing.T) {
 	t.Run("detach", func(t *testing.T) {
 		t.Run("with attachment id", func(t *testing.T) {
 			Template("detach networkinterface attachment=id-of-attachment").
-				Mock(&ec2Mock{
+				Mock(&awstest.Ec2Mock{
 					DetachNetworkInterfaceFunc: func(param0 *ec2.DetachNetworkInterfaceInput) (*ec2.DetachNetworkInterfaceOutput, error) {
 						return nil, nil
 					},
@@ -60,7 +61,7 @@ func TestNetworkInterface(t *testing.T) {
 		})
 		t.Run("with instance and network interface", func(t *testing.T) {
 			Template("detach networkinterface id=ni-1234 instance=i-2345 force=true").
-				Mock(&ec2Mock{
+				Mock(&awstest.Ec2Mock{
 					DescribeInstancesFunc: func(param0 *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
 						return &ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{
 							Instances: []*ec2.Instance{
@@ -87,7 +88,7 @@ func TestNetworkInterface(t *testing.T) {
 	})
 	t.Run("check", func(t *testing.T) {
 		Template("check networkinterface id=ni-1234 state=available timeout=1").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				DescribeNetworkInterfacesFunc: func(param0 *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error) {
 					return &ec2.DescribeNetworkInterfacesOutput{
 						NetworkInterfaces: []*ec2.NetworkInterface{
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestPolicy(t *testing.T) {
@@ -13,7 +14,7 @@ func TestPolicy(t *testing.T) {
 			"create policy name=AwlessInfraReadonlyPolicy effect=Allow action=ec2:Describe*,autoscaling:Describe*,elasticloadbalancing:Describe* "+
 				"resource=\"arn:aws:iam::0123456789:mfa/${aws:username}\",\"arn:aws:iam::0123456789:user/${aws:username}\" "+
 				"conditions=\"aws:MultiFactorAuthPresent==true\", \"aws:TokenIssueTime!=Null\" description=\"Readonly access to infra resources\"").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
 				CreatePolicyFunc: func(input *iam.CreatePolicyInput) (*iam.CreatePolicyOutput, error) {
 					return &iam.CreatePolicyOutput{Policy: &iam.Policy{Arn: String("new-policy-arn")}}, nil
 				},
@@ -47,12 +48,45 @@ func TestPolicy(t *testing.T) {
 }`)}).ExpectCommandResult("new-policy-arn").ExpectCalls("CreatePolicy").Run(t)
 	})
 
+	t.Run("create from document", func(t *testing.T) {
+		Template("create policy name=AwlessReadonlyPolicy document=<<EOF\n"+
+			"{\n"+
+			"  \"Version\": \"2012-10-17\",\n"+
+			"  \"Statement\": [{\"Effect\": \"Allow\", \"Action\": \"ec2:Describe*\", \"Resource\": \"*\"}]\n"+
+			"}\n"+
+			"EOF").
+			Mock(&awstest.IamMock{
+				CreatePolicyFunc: func(input *iam.CreatePolicyInput) (*iam.CreatePolicyOutput, error) {
+					return &iam.CreatePolicyOutput{Policy: &iam.Policy{Arn: String("new-policy-arn")}}, nil
+				},
+			}).ExpectInput("CreatePolicy", &iam.CreatePolicyInput{
+			PolicyName: String("AwlessReadonlyPolicy"),
+			PolicyDocument: String(`{
+  "Version": "2012-10-17",
+  "Statement": [{"Effect": "Allow", "Action": "ec2:Describe*", "Resource": "*"}]
+}`)}).ExpectCommandResult("new-policy-arn").ExpectCalls("CreatePolicy").Run(t)
+	})
+
+	t.Run("create from one-line document", func(t *testing.T) {
+		Template("create policy name=AwlessReadonlyPolicy document=<<EOF\n"+
+			"{\"Version\": \"2012-10-17\", \"Statement\": []}\n"+
+			"EOF").
+			Mock(&awstest.IamMock{
+				CreatePolicyFunc: func(input *iam.CreatePolicyInput) (*iam.CreatePolicyOutput, error) {
+					return &iam.CreatePolicyOutput{Policy: &iam.Policy{Arn: String("new-policy-arn")}}, nil
+				},
+			}).ExpectInput("CreatePolicy", &iam.CreatePolicyInput{
+			PolicyName:     String("AwlessReadonlyPolicy"),
+			PolicyDocument: String(`{"Version": "2012-10-17", "Statement": []}`),
+		}).ExpectCommandResult("new-policy-arn").ExpectCalls("CreatePolicy").Run(t)
+	})
+
 	t.Run("update", func(t *testing.T) {
 		Template(
 			"update policy arn=arn:my:arn:of:policy:to:update effect=Deny action=ec2:AttachVolume,DescribeVolumeAttribute "+
 				"resource=\"arn:aws:ec2:eu-west-1:0123456789:volume/*\" "+
 				"conditions=\"aws:MultiFactorAuthPresent==true\"").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
 				CreatePolicyVersionFunc: func(input *iam.CreatePolicyVersionInput) (*iam.CreatePolicyVersionOutput, error) {
 					return nil, nil
 				},
@@ -160,7 +194,7 @@ func TestPolicy(t *testing.T) {
 	t.Run("delete", func(t *testing.T) {
 		Template(
 			"delete policy arn=arn:my:arn:of:policy:to:delete all-versions=true").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
 				DeletePolicyFunc: func(input *iam.DeletePolicyInput) (*iam.DeletePolicyOutput, error) {
 					return nil, nil
 				},
@@ -185,7 +219,7 @@ func TestPolicy(t *testing.T) {
 	t.Run("attach", func(t *testing.T) {
 		Template(
 			"attach policy group=administrators access=readonly service=ec2").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
 				AttachGroupPolicyFunc: func(input *iam.AttachGroupPolicyInput) (*iam.AttachGroupPolicyOutput, error) {
 					return nil, nil
 				},
@@ -196,7 +230,7 @@ func TestPolicy(t *testing.T) {
 
 		Template(
 			"attach policy user=toto arn=arn:for:my:policy").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
 				AttachUserPolicyFunc: func(input *iam.AttachUserPolicyInput) (*iam.AttachUserPolicyOutput, error) {
 					return nil, nil
 				},
@@ -207,7 +241,7 @@ func TestPolicy(t *testing.T) {
 
 		Template(
 			"attach policy role=my-role access=full service=ec2").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
 				AttachRolePolicyFunc: func(input *iam.AttachRolePolicyInput) (*iam.AttachRolePolicyOutput, error) {
 					return nil, nil
 				},
@@ -220,7 +254,7 @@ func TestPolicy(t *testing.T) {
 	t.Run("detach", func(t *testing.T) {
 		Template(
 			"detach policy group=administrators access=readonly service=ec2").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
 				DetachGroupPolicyFunc: func(input *iam.DetachGroupPolicyInput) (*iam.DetachGroupPolicyOutput, error) {
 					return nil, nil
 				},
@@ -231,7 +265,7 @@ func TestPolicy(t *testing.T) {
 
 		Template(
 			"detach policy user=toto arn=arn:for:my:policy").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
 				DetachUserPolicyFunc: func(input *iam.DetachUserPolicyInput) (*iam.DetachUserPolicyOutput, error) {
 					return nil, nil
 				},
@@ -242,7 +276,7 @@ func TestPolicy(t *testing.T) {
 
 		Template(
 			"detach policy role=my-role access=full service=ec2").
-			Mock(&iamMock{
+			Mock(&awstest.IamMock{
 				DetachRolePolicyFunc: func(input *iam.DetachRolePolicyInput) (*iam.DetachRolePolicyOutput, error) {
 					return nil, nil
 				},
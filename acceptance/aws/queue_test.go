@@ -4,13 +4,14 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestQueue(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create queue name=my-new-queue-name delay=12 max-msg-size=42 retention-period=10 policy=my-policy "+
 			"msg-wait=12 redrive-policy=my-redrive-policy visibility-timeout=180").
-			Mock(&sqsMock{
+			Mock(&awstest.SqsMock{
 				CreateQueueFunc: func(param0 *sqs.CreateQueueInput) (*sqs.CreateQueueOutput, error) {
 					return &sqs.CreateQueueOutput{QueueUrl: String("my-queue-url")}, nil
 				},
@@ -31,7 +32,7 @@ func TestQueue(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete queue url=queue-url-to-delete").
-			Mock(&sqsMock{
+			Mock(&awstest.SqsMock{
 				DeleteQueueFunc: func(param0 *sqs.DeleteQueueInput) (*sqs.DeleteQueueOutput, error) {
 					return nil, nil
 				},
@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestRecord(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create record zone=/hostedzone/1234ABCD name=my.domain.com type=A value=127.0.0.1 ttl=60 comment='this is my localhost record'").
-			Mock(&route53Mock{
+			Mock(&awstest.Route53Mock{
 				ChangeResourceRecordSetsFunc: func(param0 *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
 					return &route53.ChangeResourceRecordSetsOutput{ChangeInfo: &route53.ChangeInfo{Id: String("change-id")}}, nil
 				},
@@ -36,7 +37,12 @@ func TestRecord(t *testing.T) {
 
 	t.Run("update", func(t *testing.T) {
 		Template("update record zone=/hostedzone/1234ABCD name=myupdated.domain.com type=A value=127.0.0.1 ttl=60").
-			Mock(&route53Mock{
+			Mock(&awstest.Route53Mock{
+				ListResourceRecordSetsFunc: func(param0 *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
+					return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: []*route53.ResourceRecordSet{
+						{Name: String("myupdated.domain.com."), Type: String("A"), TTL: Int64(300), ResourceRecords: []*route53.ResourceRecord{{Value: String("10.0.0.1")}}},
+					}}, nil
+				},
 				ChangeResourceRecordSetsFunc: func(param0 *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
 					return &route53.ChangeResourceRecordSetsOutput{ChangeInfo: &route53.ChangeInfo{Id: String("updated-id")}}, nil
 				},
@@ -57,12 +63,18 @@ func TestRecord(t *testing.T) {
 					},
 				},
 			},
-		}).ExpectCommandResult("updated-id").ExpectCalls("ChangeResourceRecordSets").Run(t)
+		}).ExpectInput("ListResourceRecordSets", &route53.ListResourceRecordSetsInput{
+			HostedZoneId:    String("/hostedzone/1234ABCD"),
+			StartRecordName: String("myupdated.domain.com"),
+			StartRecordType: String("A"),
+			MaxItems:        String("1"),
+		}).ExpectCommandResult("updated-id").ExpectCalls("ListResourceRecordSets", "ChangeResourceRecordSets").
+			ExpectRevert("update record name=myupdated.domain.com ttl=300 type=A value=10.0.0.1 zone=/hostedzone/1234ABCD").Run(t)
 	})
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete record zone=/hostedzone/1234ABCD name=mydeleted.domain.com type=A value=127.0.0.1 ttl=60").
-			Mock(&route53Mock{
+			Mock(&awstest.Route53Mock{
 				ChangeResourceRecordSetsFunc: func(param0 *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
 					return &route53.ChangeResourceRecordSetsOutput{ChangeInfo: &route53.ChangeInfo{Id: String("deleted-id")}}, nil
 				},
@@ -5,11 +5,12 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestRegistry(t *testing.T) {
 	t.Run("authenticate", func(t *testing.T) {
-		Template("authenticate registry accounts=my-registry-id-1,my-registry-id-2 no-docker-login=true").Mock(&ecrMock{
+		Template("authenticate registry accounts=my-registry-id-1,my-registry-id-2 no-docker-login=true").Mock(&awstest.EcrMock{
 			GetAuthorizationTokenFunc: func(input *ecr.GetAuthorizationTokenInput) (*ecr.GetAuthorizationTokenOutput, error) {
 				return &ecr.GetAuthorizationTokenOutput{
 					AuthorizationData: []*ecr.AuthorizationData{{AuthorizationToken: String(base64.StdEncoding.EncodeToString([]byte("user:my-authorization-token")))}},
@@ -4,11 +4,12 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestRepository(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
-		Template("create repository name=new-repo").Mock(&ecrMock{
+		Template("create repository name=new-repo").Mock(&awstest.EcrMock{
 			CreateRepositoryFunc: func(input *ecr.CreateRepositoryInput) (*ecr.CreateRepositoryOutput, error) {
 				return &ecr.CreateRepositoryOutput{Repository: &ecr.Repository{RepositoryArn: String("new-repo-arn")}}, nil
 			}}).
@@ -18,7 +19,7 @@ func TestRepository(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete repository name=any-repo").Mock(&ecrMock{
+		Template("delete repository name=any-repo").Mock(&awstest.EcrMock{
 			DeleteRepositoryFunc: func(input *ecr.DeleteRepositoryInput) (*ecr.DeleteRepositoryOutput, error) {
 				return &ecr.DeleteRepositoryOutput{Repository: &ecr.Repository{RepositoryArn: String("any-repo-arn")}}, nil
 			}}).
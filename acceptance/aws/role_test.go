@@ -4,11 +4,12 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestRole(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
-		Template(`create role name=president principal-account=account principal-user=user principal-service=service conditions="aws:SecureTransport==true","s3:max-keys==10" sleep-after=0`).Mock(&iamMock{
+		Template(`create role name=president principal-account=account principal-user=user principal-service=service conditions="aws:SecureTransport==true","s3:max-keys==10" sleep-after=0`).Mock(&awstest.IamMock{
 			CreateRoleFunc: func(input *iam.CreateRoleInput) (*iam.CreateRoleOutput, error) {
 				return &iam.CreateRoleOutput{Role: &iam.Role{Arn: String("new-role-arn"), RoleId: String("new-role-id"), RoleName: String("president")}}, nil
 			},
@@ -50,7 +51,7 @@ func TestRole(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete role name=president").Mock(&iamMock{
+		Template("delete role name=president").Mock(&awstest.IamMock{
 			RemoveRoleFromInstanceProfileFunc: func(input *iam.RemoveRoleFromInstanceProfileInput) (*iam.RemoveRoleFromInstanceProfileOutput, error) {
 				return &iam.RemoveRoleFromInstanceProfileOutput{}, nil
 			},
@@ -70,7 +71,7 @@ func TestRole(t *testing.T) {
 	})
 
 	t.Run("attach", func(t *testing.T) {
-		Template("attach role name=president instanceprofile=any-inst-profile").Mock(&iamMock{
+		Template("attach role name=president instanceprofile=any-inst-profile").Mock(&awstest.IamMock{
 			AddRoleToInstanceProfileFunc: func(input *iam.AddRoleToInstanceProfileInput) (*iam.AddRoleToInstanceProfileOutput, error) {
 				return &iam.AddRoleToInstanceProfileOutput{}, nil
 			}}).ExpectInput("AddRoleToInstanceProfile", &iam.AddRoleToInstanceProfileInput{
@@ -80,7 +81,7 @@ func TestRole(t *testing.T) {
 	})
 
 	t.Run("detach", func(t *testing.T) {
-		Template("detach role name=president instanceprofile=any-inst-profile").Mock(&iamMock{
+		Template("detach role name=president instanceprofile=any-inst-profile").Mock(&awstest.IamMock{
 			RemoveRoleFromInstanceProfileFunc: func(input *iam.RemoveRoleFromInstanceProfileInput) (*iam.RemoveRoleFromInstanceProfileOutput, error) {
 				return &iam.RemoveRoleFromInstanceProfileOutput{}, nil
 			}}).ExpectInput("RemoveRoleFromInstanceProfile", &iam.RemoveRoleFromInstanceProfileInput{
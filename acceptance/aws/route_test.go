@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestRoute(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create route table=table-id cidr=10.0.0.0/16 gateway=igw-id").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				CreateRouteFunc: func(param0 *ec2.CreateRouteInput) (*ec2.CreateRouteOutput, error) {
 					return nil, nil
 				},
@@ -22,7 +23,7 @@ func TestRoute(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete route table=table-id cidr=10.0.0.0/16").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				DeleteRouteFunc: func(param0 *ec2.DeleteRouteInput) (*ec2.DeleteRouteOutput, error) {
 					return nil, nil
 				},
@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestRouteTable(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create routetable vpc=vpc-1234").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				CreateRouteTableFunc: func(param0 *ec2.CreateRouteTableInput) (*ec2.CreateRouteTableOutput, error) {
 					return &ec2.CreateRouteTableOutput{RouteTable: &ec2.RouteTable{RouteTableId: String("new-routetable-id")}}, nil
 				},
@@ -19,7 +20,7 @@ func TestRouteTable(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete routetable id=rt-1234").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				DeleteRouteTableFunc: func(param0 *ec2.DeleteRouteTableInput) (*ec2.DeleteRouteTableOutput, error) {
 					return nil, nil
 				},
@@ -29,7 +30,7 @@ func TestRouteTable(t *testing.T) {
 
 	t.Run("attach", func(t *testing.T) {
 		Template("attach routetable id=my-rt-id subnet=my-subnet-id").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				AssociateRouteTableFunc: func(param0 *ec2.AssociateRouteTableInput) (*ec2.AssociateRouteTableOutput, error) {
 					return &ec2.AssociateRouteTableOutput{AssociationId: String("new-assoc-id")}, nil
 				},
@@ -41,7 +42,7 @@ func TestRouteTable(t *testing.T) {
 
 	t.Run("detach", func(t *testing.T) {
 		Template("detach routetable association=assoc-2345").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				DisassociateRouteTableFunc: func(param0 *ec2.DisassociateRouteTableInput) (*ec2.DisassociateRouteTableOutput, error) {
 					return nil, nil
 				},
@@ -9,6 +9,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestS3object(t *testing.T) {
@@ -25,7 +26,7 @@ func TestS3object(t *testing.T) {
 		}
 
 		t.Run("with filename", func(t *testing.T) {
-			Template("create s3object name=my-s3object file="+filePath+" bucket=any-bucket acl=public-read").Mock(&s3Mock{
+			Template("create s3object name=my-s3object file="+filePath+" bucket=any-bucket acl=public-read").Mock(&awstest.S3Mock{
 				PutObjectFunc: func(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
 					return &s3.PutObjectOutput{}, nil
 				}}).
@@ -39,7 +40,7 @@ func TestS3object(t *testing.T) {
 
 		t.Run("no filename", func(t *testing.T) {
 			filename := filepath.Base(filePath)
-			Template("create s3object file="+filePath+" bucket=any-bucket acl=public-read").Mock(&s3Mock{
+			Template("create s3object file="+filePath+" bucket=any-bucket acl=public-read").Mock(&awstest.S3Mock{
 				PutObjectFunc: func(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
 					return &s3.PutObjectOutput{}, nil
 				}}).
@@ -53,7 +54,7 @@ func TestS3object(t *testing.T) {
 	})
 
 	t.Run("update", func(t *testing.T) {
-		Template("update s3object name=any-file bucket=other-bucket acl=public-read version=2").Mock(&s3Mock{
+		Template("update s3object name=any-file bucket=other-bucket acl=public-read version=2").Mock(&awstest.S3Mock{
 			PutObjectAclFunc: func(input *s3.PutObjectAclInput) (*s3.PutObjectAclOutput, error) {
 				return &s3.PutObjectAclOutput{}, nil
 			}}).
@@ -66,7 +67,7 @@ func TestS3object(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete s3object name=any-file bucket=any-bucket").Mock(&s3Mock{
+		Template("delete s3object name=any-file bucket=any-bucket").Mock(&awstest.S3Mock{
 			DeleteObjectFunc: func(input *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
 				return &s3.DeleteObjectOutput{}, nil
 			}}).
@@ -4,11 +4,12 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestScalingGroup(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
-		Template("create scalinggroup name=new-autoscaling launchconfiguration=config max-size=12 min-size=10 subnets=sub_1,sub_2 cooldown=3 desired-capacity=12 healthcheck-grace-period=4 healthcheck-type=healthy new-instances-protected=true targetgroups=tg_1,tg_2").Mock(&autoscalingMock{
+		Template("create scalinggroup name=new-autoscaling launchconfiguration=config max-size=12 min-size=10 subnets=sub_1,sub_2 cooldown=3 desired-capacity=12 healthcheck-grace-period=4 healthcheck-type=healthy new-instances-protected=true targetgroups=tg_1,tg_2").Mock(&awstest.AutoscalingMock{
 			CreateAutoScalingGroupFunc: func(input *autoscaling.CreateAutoScalingGroupInput) (*autoscaling.CreateAutoScalingGroupOutput, error) {
 				return &autoscaling.CreateAutoScalingGroupOutput{}, nil
 			}}).
@@ -28,10 +29,25 @@ func TestScalingGroup(t *testing.T) {
 	})
 
 	t.Run("update", func(t *testing.T) {
-		Template("update scalinggroup name=new-autoscaling launchconfiguration=config max-size=12 min-size=10 subnets=sub_1,sub_2 cooldown=3 desired-capacity=12 healthcheck-grace-period=4 healthcheck-type=healthy new-instances-protected=true").Mock(&autoscalingMock{
+		Template("update scalinggroup name=new-autoscaling launchconfiguration=config max-size=12 min-size=10 subnets=sub_1,sub_2 cooldown=3 desired-capacity=12 healthcheck-grace-period=4 healthcheck-type=healthy new-instances-protected=true").Mock(&awstest.AutoscalingMock{
+			DescribeAutoScalingGroupsFunc: func(input *autoscaling.DescribeAutoScalingGroupsInput) (*autoscaling.DescribeAutoScalingGroupsOutput, error) {
+				return &autoscaling.DescribeAutoScalingGroupsOutput{AutoScalingGroups: []*autoscaling.Group{{
+					AutoScalingGroupName:             String("new-autoscaling"),
+					LaunchConfigurationName:          String("old-config"),
+					MaxSize:                          Int64(2),
+					MinSize:                          Int64(1),
+					DefaultCooldown:                  Int64(300),
+					DesiredCapacity:                  Int64(1),
+					HealthCheckGracePeriod:           Int64(0),
+					HealthCheckType:                  String("EC2"),
+					NewInstancesProtectedFromScaleIn: Bool(false),
+					VPCZoneIdentifier:                String("sub_1"),
+				}}}, nil
+			},
 			UpdateAutoScalingGroupFunc: func(input *autoscaling.UpdateAutoScalingGroupInput) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
 				return nil, nil
 			}}).
+			ExpectInput("DescribeAutoScalingGroups", &autoscaling.DescribeAutoScalingGroupsInput{AutoScalingGroupNames: []*string{String("new-autoscaling")}}).
 			ExpectInput("UpdateAutoScalingGroup", &autoscaling.UpdateAutoScalingGroupInput{
 				AutoScalingGroupName:             String("new-autoscaling"),
 				LaunchConfigurationName:          String("config"),
@@ -43,11 +59,12 @@ func TestScalingGroup(t *testing.T) {
 				HealthCheckType:                  String("healthy"),
 				NewInstancesProtectedFromScaleIn: Bool(true),
 				VPCZoneIdentifier:                String("sub_1,sub_2"),
-			}).ExpectCalls("UpdateAutoScalingGroup").Run(t)
+			}).ExpectCalls("DescribeAutoScalingGroups", "UpdateAutoScalingGroup").
+			ExpectRevert("update scalinggroup cooldown=300 desired-capacity=1 healthcheck-grace-period=0 healthcheck-type=EC2 launchconfiguration=old-config max-size=2 min-size=1 name=new-autoscaling new-instances-protected=false subnets=[sub_1]").Run(t)
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete scalinggroup name=any-sg force=true").Mock(&autoscalingMock{
+		Template("delete scalinggroup name=any-sg force=true").Mock(&awstest.AutoscalingMock{
 			DeleteAutoScalingGroupFunc: func(input *autoscaling.DeleteAutoScalingGroupInput) (*autoscaling.DeleteAutoScalingGroupOutput, error) {
 				return nil, nil
 			}}).
@@ -58,7 +75,7 @@ func TestScalingGroup(t *testing.T) {
 	})
 
 	t.Run("check", func(t *testing.T) {
-		Template("check scalinggroup name=any-sg count=1 timeout=0").Mock(&autoscalingMock{
+		Template("check scalinggroup name=any-sg count=1 timeout=0").Mock(&awstest.AutoscalingMock{
 			DescribeAutoScalingGroupsFunc: func(input *autoscaling.DescribeAutoScalingGroupsInput) (*autoscaling.DescribeAutoScalingGroupsOutput, error) {
 				return &autoscaling.DescribeAutoScalingGroupsOutput{
 					AutoScalingGroups: []*autoscaling.Group{
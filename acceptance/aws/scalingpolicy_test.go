@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/wallix/awless/aws/test"
 )
 
 /*
@@ -18,7 +19,7 @@ import (
 
 func TestScalingPolicy(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
-		Template("create scalingpolicy adjustment-type=adj scalinggroup=scaler name=any-policy adjustment-scaling=3 cooldown=5 adjustment-magnitude=12").Mock(&autoscalingMock{
+		Template("create scalingpolicy adjustment-type=adj scalinggroup=scaler name=any-policy adjustment-scaling=3 cooldown=5 adjustment-magnitude=12").Mock(&awstest.AutoscalingMock{
 			PutScalingPolicyFunc: func(input *autoscaling.PutScalingPolicyInput) (*autoscaling.PutScalingPolicyOutput, error) {
 				return &autoscaling.PutScalingPolicyOutput{PolicyARN: String("new-policy-arn")}, nil
 			}}).
@@ -33,7 +34,7 @@ func TestScalingPolicy(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete scalingpolicy id=any-scalingpolicy").Mock(&autoscalingMock{
+		Template("delete scalingpolicy id=any-scalingpolicy").Mock(&awstest.AutoscalingMock{
 			DeletePolicyFunc: func(input *autoscaling.DeletePolicyInput) (*autoscaling.DeletePolicyOutput, error) {
 				return nil, nil
 			}}).ExpectInput("DeletePolicy", &autoscaling.DeletePolicyInput{
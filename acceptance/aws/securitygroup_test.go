@@ -4,11 +4,12 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestSecuritygroup(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
-		Template(`create securitygroup name=my-sg-name vpc=my-vpc-id description="security group description"`).Mock(&ec2Mock{
+		Template(`create securitygroup name=my-sg-name vpc=my-vpc-id description="security group description"`).Mock(&awstest.Ec2Mock{
 			CreateSecurityGroupFunc: func(input *ec2.CreateSecurityGroupInput) (*ec2.CreateSecurityGroupOutput, error) {
 				return &ec2.CreateSecurityGroupOutput{GroupId: String("new-secgroup-id")}, nil
 			}}).
@@ -21,7 +22,7 @@ func TestSecuritygroup(t *testing.T) {
 
 	t.Run("update", func(t *testing.T) {
 		t.Run("inbound authorize with another secgroup", func(t *testing.T) {
-			Template("update securitygroup id=my-secgroup-id inbound=authorize protocol=tcp securitygroup=any-secgroup-id portrange=8080").Mock(&ec2Mock{
+			Template("update securitygroup id=my-secgroup-id inbound=authorize protocol=tcp securitygroup=any-secgroup-id portrange=8080").Mock(&awstest.Ec2Mock{
 				AuthorizeSecurityGroupIngressFunc: func(input *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
 					return nil, nil
 				}}).
@@ -39,7 +40,7 @@ func TestSecuritygroup(t *testing.T) {
 		})
 
 		t.Run("inbound authorize", func(t *testing.T) {
-			Template("update securitygroup id=my-secgroup-id inbound=authorize protocol=tcp cidr=10.10.10.0/24 portrange=10-22").Mock(&ec2Mock{
+			Template("update securitygroup id=my-secgroup-id inbound=authorize protocol=tcp cidr=10.10.10.0/24 portrange=10-22").Mock(&awstest.Ec2Mock{
 				AuthorizeSecurityGroupIngressFunc: func(input *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
 					return nil, nil
 				}}).
@@ -56,7 +57,7 @@ func TestSecuritygroup(t *testing.T) {
 				}).ExpectCalls("AuthorizeSecurityGroupIngress").Run(t)
 		})
 		t.Run("inbound revoke", func(t *testing.T) {
-			Template("update securitygroup id=my-secgroup-id inbound=revoke protocol=tcp cidr=10.10.10.0/24 portrange=10-22").Mock(&ec2Mock{
+			Template("update securitygroup id=my-secgroup-id inbound=revoke protocol=tcp cidr=10.10.10.0/24 portrange=10-22").Mock(&awstest.Ec2Mock{
 				RevokeSecurityGroupIngressFunc: func(input *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error) {
 					return nil, nil
 				}}).
@@ -73,7 +74,7 @@ func TestSecuritygroup(t *testing.T) {
 				}).ExpectCalls("RevokeSecurityGroupIngress").Run(t)
 		})
 		t.Run("outbound authorize", func(t *testing.T) {
-			Template("update securitygroup id=my-secgroup-id outbound=authorize protocol=tcp cidr=10.10.10.0/24 portrange=10-22").Mock(&ec2Mock{
+			Template("update securitygroup id=my-secgroup-id outbound=authorize protocol=tcp cidr=10.10.10.0/24 portrange=10-22").Mock(&awstest.Ec2Mock{
 				AuthorizeSecurityGroupEgressFunc: func(input *ec2.AuthorizeSecurityGroupEgressInput) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
 					return nil, nil
 				}}).
@@ -90,7 +91,7 @@ func TestSecuritygroup(t *testing.T) {
 				}).ExpectCalls("AuthorizeSecurityGroupEgress").Run(t)
 		})
 		t.Run("outbound revoke", func(t *testing.T) {
-			Template("update securitygroup id=my-secgroup-id outbound=revoke protocol=tcp cidr=10.10.10.0/24 portrange=10-22").Mock(&ec2Mock{
+			Template("update securitygroup id=my-secgroup-id outbound=revoke protocol=tcp cidr=10.10.10.0/24 portrange=10-22").Mock(&awstest.Ec2Mock{
 				RevokeSecurityGroupEgressFunc: func(input *ec2.RevokeSecurityGroupEgressInput) (*ec2.RevokeSecurityGroupEgressOutput, error) {
 					return nil, nil
 				}}).
@@ -109,7 +110,7 @@ func TestSecuritygroup(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete securitygroup id=my-secgroup-id").Mock(&ec2Mock{
+		Template("delete securitygroup id=my-secgroup-id").Mock(&awstest.Ec2Mock{
 			DeleteSecurityGroupFunc: func(input *ec2.DeleteSecurityGroupInput) (*ec2.DeleteSecurityGroupOutput, error) {
 				return nil, nil
 			}}).
@@ -119,7 +120,7 @@ func TestSecuritygroup(t *testing.T) {
 	})
 
 	t.Run("attach", func(t *testing.T) {
-		Template("attach securitygroup id=my-secgroup-id instance=secgroup-instance-id").Mock(&ec2Mock{
+		Template("attach securitygroup id=my-secgroup-id instance=secgroup-instance-id").Mock(&awstest.Ec2Mock{
 			DescribeInstanceAttributeFunc: func(input *ec2.DescribeInstanceAttributeInput) (*ec2.DescribeInstanceAttributeOutput, error) {
 				return &ec2.DescribeInstanceAttributeOutput{Groups: []*ec2.GroupIdentifier{
 					{GroupId: String("secgroup-1")}, {GroupId: String("secgroup-2")},
@@ -139,7 +140,7 @@ func TestSecuritygroup(t *testing.T) {
 	})
 
 	t.Run("detach", func(t *testing.T) {
-		Template("detach securitygroup id=my-secgroup-id instance=secgroup-instance-id").Mock(&ec2Mock{
+		Template("detach securitygroup id=my-secgroup-id instance=secgroup-instance-id").Mock(&awstest.Ec2Mock{
 			DescribeInstanceAttributeFunc: func(input *ec2.DescribeInstanceAttributeInput) (*ec2.DescribeInstanceAttributeOutput, error) {
 				return &ec2.DescribeInstanceAttributeOutput{Groups: []*ec2.GroupIdentifier{
 					{GroupId: String("secgroup-1")}, {GroupId: String("my-secgroup-id")},
@@ -159,7 +160,7 @@ func TestSecuritygroup(t *testing.T) {
 	})
 
 	t.Run("check", func(t *testing.T) {
-		Template("check securitygroup id=my-secgroup-id state=unused timeout=2").Mock(&ec2Mock{
+		Template("check securitygroup id=my-secgroup-id state=unused timeout=2").Mock(&awstest.Ec2Mock{
 			DescribeNetworkInterfacesFunc: func(input *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error) {
 				return &ec2.DescribeNetworkInterfacesOutput{
 					NetworkInterfaces: []*ec2.NetworkInterface{},
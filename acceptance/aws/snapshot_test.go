@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestSnapshot(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create snapshot volume=my-volume-id description='this is the description of my snapshot'").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				CreateSnapshotFunc: func(param0 *ec2.CreateSnapshotInput) (*ec2.Snapshot, error) {
 					return &ec2.Snapshot{SnapshotId: String("new-snapshot-id")}, nil
 				},
@@ -22,7 +23,7 @@ func TestSnapshot(t *testing.T) {
 
 	t.Run("delete", func(t *testing.T) {
 		Template("delete snapshot id=snap-1234").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				DeleteSnapshotFunc: func(param0 *ec2.DeleteSnapshotInput) (*ec2.DeleteSnapshotOutput, error) {
 					return nil, nil
 				},
@@ -32,7 +33,7 @@ func TestSnapshot(t *testing.T) {
 
 	t.Run("copy", func(t *testing.T) {
 		Template("copy snapshot source-id=my-origin-id source-region=my-origin-region encrypted=true description='an encrypted snapshot'").
-			Mock(&ec2Mock{
+			Mock(&awstest.Ec2Mock{
 				CopySnapshotFunc: func(param0 *ec2.CopySnapshotInput) (*ec2.CopySnapshotOutput, error) {
 					return &ec2.CopySnapshotOutput{SnapshotId: String("my-snapshotcopy-id")}, nil
 				},
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestStack(t *testing.T) {
@@ -18,7 +19,7 @@ func TestStack(t *testing.T) {
 
 	t.Run("create", func(t *testing.T) {
 
-		Template("create stack name=new-stack template-file="+tplFilePath+" capabilities=one,two disable-rollback=true notifications=none,ntwo on-failure=done parameters=1:pone,2:ptwo resource-types=rone,rtwo role=donjuan policy-file="+polFilePath+" timeout=180").Mock(&cloudformationMock{
+		Template("create stack name=new-stack template-file="+tplFilePath+" capabilities=one,two disable-rollback=true notifications=none,ntwo on-failure=done parameters=1:pone,2:ptwo resource-types=rone,rtwo role=donjuan policy-file="+polFilePath+" timeout=180").Mock(&awstest.CloudformationMock{
 			CreateStackFunc: func(input *cloudformation.CreateStackInput) (*cloudformation.CreateStackOutput, error) {
 				return &cloudformation.CreateStackOutput{StackId: String("new-stack-id")}, nil
 			}}).ExpectInput("CreateStack", &cloudformation.CreateStackInput{
@@ -36,11 +37,44 @@ func TestStack(t *testing.T) {
 		}).ExpectCommandResult("new-stack-id").ExpectCalls("CreateStack").Run(t)
 	})
 
+	t.Run("create with map parameters", func(t *testing.T) {
+		Template("create stack name=new-stack template-file="+tplFilePath+" parameters={Env: prod, Team: 'core infra'} tags={Owner: bob}").Mock(&awstest.CloudformationMock{
+			CreateStackFunc: func(input *cloudformation.CreateStackInput) (*cloudformation.CreateStackOutput, error) {
+				return &cloudformation.CreateStackOutput{StackId: String("new-stack-id")}, nil
+			}}).ExpectInput("CreateStack", &cloudformation.CreateStackInput{
+			StackName:    String("new-stack"),
+			TemplateBody: String("tpl body content"),
+			Parameters:   []*cloudformation.Parameter{{ParameterKey: String("Env"), ParameterValue: String("prod")}, {ParameterKey: String("Team"), ParameterValue: String("core infra")}},
+			Tags:         []*cloudformation.Tag{{Key: String("Owner"), Value: String("bob")}},
+		}).ExpectCommandResult("new-stack-id").ExpectCalls("CreateStack").Run(t)
+	})
+
+	t.Run("update reverted to previous parameters", func(t *testing.T) {
+		Template("update stack name=some-stack use-previous-template=true parameters={Env: staging} tags={Owner: alice}").Mock(&awstest.CloudformationMock{
+			DescribeStacksFunc: func(input *cloudformation.DescribeStacksInput) (*cloudformation.DescribeStacksOutput, error) {
+				return &cloudformation.DescribeStacksOutput{Stacks: []*cloudformation.Stack{{
+					StackName:  String("some-stack"),
+					Parameters: []*cloudformation.Parameter{{ParameterKey: String("Env"), ParameterValue: String("prod")}, {ParameterKey: String("Team"), ParameterValue: String("core infra")}},
+					Tags:       []*cloudformation.Tag{{Key: String("Owner"), Value: String("bob")}},
+				}}}, nil
+			},
+			UpdateStackFunc: func(input *cloudformation.UpdateStackInput) (*cloudformation.UpdateStackOutput, error) {
+				return &cloudformation.UpdateStackOutput{StackId: String("any-stack-id")}, nil
+			}}).ExpectInput("DescribeStacks", &cloudformation.DescribeStacksInput{StackName: String("some-stack")}).
+			ExpectInput("UpdateStack", &cloudformation.UpdateStackInput{
+				StackName:           String("some-stack"),
+				UsePreviousTemplate: Bool(true),
+				Parameters:          []*cloudformation.Parameter{{ParameterKey: String("Env"), ParameterValue: String("staging")}},
+				Tags:                []*cloudformation.Tag{{Key: String("Owner"), Value: String("alice")}},
+			}).ExpectCalls("DescribeStacks", "UpdateStack").
+			ExpectRevert("update stack name=some-stack parameters={Env: prod, Team: 'core infra'} tags={Owner: bob} use-previous-template=true").Run(t)
+	})
+
 	t.Run("update", func(t *testing.T) {
 		_, polUpdateFilePath, clean := generateTmpFile("update policy content")
 		defer clean()
 
-		Template("update stack name=other-name template-file="+tplFilePath+" use-previous-template=true capabilities=one,two notifications=none,ntwo parameters=1:pone,2:ptwo resource-types=rone,rtwo role=donjuan policy-file="+polFilePath+" policy-update-file="+polUpdateFilePath).Mock(&cloudformationMock{
+		Template("update stack name=other-name template-file="+tplFilePath+" use-previous-template=true capabilities=one,two notifications=none,ntwo parameters=1:pone,2:ptwo resource-types=rone,rtwo role=donjuan policy-file="+polFilePath+" policy-update-file="+polUpdateFilePath).Mock(&awstest.CloudformationMock{
 			UpdateStackFunc: func(input *cloudformation.UpdateStackInput) (*cloudformation.UpdateStackOutput, error) {
 				return &cloudformation.UpdateStackOutput{StackId: String("any-stack-id")}, nil
 			}}).ExpectInput("UpdateStack", &cloudformation.UpdateStackInput{
@@ -58,7 +92,7 @@ func TestStack(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete stack name=any-stack-name retain-resources=1,2").Mock(&cloudformationMock{
+		Template("delete stack name=any-stack-name retain-resources=1,2").Mock(&awstest.CloudformationMock{
 			DeleteStackFunc: func(input *cloudformation.DeleteStackInput) (*cloudformation.DeleteStackOutput, error) {
 				return nil, nil
 			}}).ExpectInput("DeleteStack", &cloudformation.DeleteStackInput{
@@ -85,7 +119,7 @@ StackPolicy:
 	defer stackFileYMLClean()
 
 	t.Run("update", func(t *testing.T) {
-		Template("update stack name=some-stack template-file="+tplFilePath+" stack-file="+stackFileYMLPath+" parameters=Test1:a,Test2:b tags=Tag1:a,Tag2:b policy-file="+polFilePath).Mock(&cloudformationMock{
+		Template("update stack name=some-stack template-file="+tplFilePath+" stack-file="+stackFileYMLPath+" parameters=Test1:a,Test2:b tags=Tag1:a,Tag2:b policy-file="+polFilePath).Mock(&awstest.CloudformationMock{
 			UpdateStackFunc: func(input *cloudformation.UpdateStackInput) (*cloudformation.UpdateStackOutput, error) {
 				return &cloudformation.UpdateStackOutput{StackId: String("any-stack-id")}, nil
 			}}).ExpectInput("UpdateStack", &cloudformation.UpdateStackInput{
@@ -102,7 +136,7 @@ StackPolicy:
 	defer stackFileJSONClean()
 
 	t.Run("update", func(t *testing.T) {
-		Template("update stack name=some-stack template-file="+tplFilePath+" stack-file="+stackFileJSONPath+" parameters=Test1:a,Test2:b tags=Tag1:a,Tag2:b").Mock(&cloudformationMock{
+		Template("update stack name=some-stack template-file="+tplFilePath+" stack-file="+stackFileJSONPath+" parameters=Test1:a,Test2:b tags=Tag1:a,Tag2:b").Mock(&awstest.CloudformationMock{
 			UpdateStackFunc: func(input *cloudformation.UpdateStackInput) (*cloudformation.UpdateStackOutput, error) {
 				return &cloudformation.UpdateStackOutput{StackId: String("any-stack-id")}, nil
 			}}).ExpectInput("UpdateStack", &cloudformation.UpdateStackInput{
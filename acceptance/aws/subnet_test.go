@@ -7,11 +7,12 @@ import (
 	"github.com/aws/aws-sdk-go/aws/client/metadata"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestSubnet(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
-		Template("create subnet name=my-subnet cidr=10.10.10.0/24 vpc=any-vpc-id availabilityzone=eu-west-1a").Mock(&ec2Mock{
+		Template("create subnet name=my-subnet cidr=10.10.10.0/24 vpc=any-vpc-id availabilityzone=eu-west-1a").Mock(&awstest.Ec2Mock{
 			CreateSubnetFunc: func(input *ec2.CreateSubnetInput) (*ec2.CreateSubnetOutput, error) {
 				return &ec2.CreateSubnetOutput{Subnet: &ec2.Subnet{SubnetId: String("new-subnet-id")}}, nil
 			},
@@ -32,7 +33,7 @@ func TestSubnet(t *testing.T) {
 	})
 
 	t.Run("create public", func(t *testing.T) {
-		Template("create subnet public=true name=my-subnet cidr=10.10.10.0/24 vpc=any-vpc-id availabilityzone=eu-west-1a").Mock(&ec2Mock{
+		Template("create subnet public=true name=my-subnet cidr=10.10.10.0/24 vpc=any-vpc-id availabilityzone=eu-west-1a").Mock(&awstest.Ec2Mock{
 			CreateSubnetFunc: func(input *ec2.CreateSubnetInput) (*ec2.CreateSubnetOutput, error) {
 				return &ec2.CreateSubnetOutput{Subnet: &ec2.Subnet{SubnetId: String("new-subnet-id")}}, nil
 			},
@@ -59,18 +60,23 @@ func TestSubnet(t *testing.T) {
 	})
 
 	t.Run("update", func(t *testing.T) {
-		Template("update subnet id=any-subnet-id public=true").Mock(&ec2Mock{
+		Template("update subnet id=any-subnet-id public=true").Mock(&awstest.Ec2Mock{
+			DescribeSubnetsFunc: func(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+				return &ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{{SubnetId: String("any-subnet-id"), MapPublicIpOnLaunch: Bool(false)}}}, nil
+			},
 			ModifySubnetAttributeFunc: func(input *ec2.ModifySubnetAttributeInput) (*ec2.ModifySubnetAttributeOutput, error) {
 				return nil, nil
 			}}).
+			ExpectInput("DescribeSubnets", &ec2.DescribeSubnetsInput{SubnetIds: []*string{String("any-subnet-id")}}).
 			ExpectInput("ModifySubnetAttribute", &ec2.ModifySubnetAttributeInput{
 				MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{Value: Bool(true)},
 				SubnetId:            String("any-subnet-id"),
-			}).ExpectCalls("ModifySubnetAttribute").Run(t)
+			}).ExpectCalls("DescribeSubnets", "ModifySubnetAttribute").
+			ExpectRevert("update subnet id=any-subnet-id public=false").Run(t)
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete subnet id=any-subnet-id").Mock(&ec2Mock{
+		Template("delete subnet id=any-subnet-id").Mock(&awstest.Ec2Mock{
 			DeleteSubnetFunc: func(input *ec2.DeleteSubnetInput) (*ec2.DeleteSubnetOutput, error) {
 				return nil, nil
 			}}).
@@ -4,11 +4,12 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestSubscription(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
-		Template("create subscription topic=any-topic endpoint=any-endpoint protocol=HTTP").Mock(&snsMock{
+		Template("create subscription topic=any-topic endpoint=any-endpoint protocol=HTTP").Mock(&awstest.SnsMock{
 			SubscribeFunc: func(input *sns.SubscribeInput) (*sns.SubscribeOutput, error) {
 				return &sns.SubscribeOutput{SubscriptionArn: String("subscription-arn")}, nil
 			}}).
@@ -20,7 +21,7 @@ func TestSubscription(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete subscription id=any-subscription-arn").Mock(&snsMock{
+		Template("delete subscription id=any-subscription-arn").Mock(&awstest.SnsMock{
 			UnsubscribeFunc: func(input *sns.UnsubscribeInput) (*sns.UnsubscribeOutput, error) {
 				return nil, nil
 			}}).
@@ -7,11 +7,12 @@ import (
 	"github.com/aws/aws-sdk-go/aws/client/metadata"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestTag(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
-		Template("create tag key=MyKey resource=any-resource-id value=Value").Mock(&ec2Mock{
+		Template("create tag key=MyKey resource=any-resource-id value=Value").Mock(&awstest.Ec2Mock{
 			CreateTagsRequestFunc: func(input *ec2.CreateTagsInput) (req *request.Request, output *ec2.CreateTagsOutput) {
 				output = &ec2.CreateTagsOutput{}
 				req = request.New(aws.Config{}, metadata.ClientInfo{}, request.Handlers{}, nil, &request.Operation{}, input, output)
@@ -24,7 +25,7 @@ func TestTag(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete tag key=MyKey resource=any-resource-id").Mock(&ec2Mock{
+		Template("delete tag key=MyKey resource=any-resource-id").Mock(&awstest.Ec2Mock{
 			DeleteTagsFunc: func(input *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
 				return nil, nil
 			}}).
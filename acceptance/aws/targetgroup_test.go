@@ -4,20 +4,21 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestTargetgroup(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
-		Template("create targetgroup name=new-tg port=80 protocol=HTTP vpc=any-vpc-id healthcheckinterval=2 healthcheckpath=/health healthcheckport=80 healthcheckprotocol=HTTP healthchecktimeout=180 healthythreshold=30 unhealthythreshold=10 matcher=OK").Mock(&elbv2Mock{
+		Template("create targetgroup name=new-tg port=80 protocol=HTTP vpc=any-vpc-id healthcheckinterval=2 healthcheckpath=/health healthcheckport=80 healthcheckprotocol=HTTP healthchecktimeout=180 healthythreshold=30 unhealthythreshold=10 matcher=OK").Mock(&awstest.Elbv2Mock{
 			CreateTargetGroupFunc: func(input *elbv2.CreateTargetGroupInput) (*elbv2.CreateTargetGroupOutput, error) {
 				return &elbv2.CreateTargetGroupOutput{
 					TargetGroups: []*elbv2.TargetGroup{{TargetGroupArn: String("new-tg-arn")}},
 				}, nil
 			}}).ExpectInput("CreateTargetGroup", &elbv2.CreateTargetGroupInput{
-			Name:     String("new-tg"),
-			Port:     Int64(80),
-			Protocol: String("HTTP"),
-			VpcId:    String("any-vpc-id"),
+			Name:                       String("new-tg"),
+			Port:                       Int64(80),
+			Protocol:                   String("HTTP"),
+			VpcId:                      String("any-vpc-id"),
 			HealthCheckIntervalSeconds: Int64(2),
 			HealthCheckPath:            String("/health"),
 			HealthCheckPort:            String("80"),
@@ -33,19 +34,40 @@ func TestTargetgroup(t *testing.T) {
 	})
 
 	t.Run("update", func(t *testing.T) {
-		Template("update targetgroup id=any-tg stickiness=ouech stickinessduration=ouechdur deregistrationdelay=yeap healthcheckinterval=2 healthcheckpath=/health healthcheckport=80 healthcheckprotocol=HTTP healthchecktimeout=180 healthythreshold=30 unhealthythreshold=10 matcher=OK").Mock(&elbv2Mock{
+		Template("update targetgroup id=any-tg stickiness=ouech stickinessduration=ouechdur deregistrationdelay=yeap healthcheckinterval=2 healthcheckpath=/health healthcheckport=80 healthcheckprotocol=HTTP healthchecktimeout=180 healthythreshold=30 unhealthythreshold=10 matcher=OK").Mock(&awstest.Elbv2Mock{
 			ModifyTargetGroupAttributesFunc: func(input *elbv2.ModifyTargetGroupAttributesInput) (*elbv2.ModifyTargetGroupAttributesOutput, error) {
 				return &elbv2.ModifyTargetGroupAttributesOutput{
 					Attributes: []*elbv2.TargetGroupAttribute{},
 				}, nil
 			},
-			ModifyTargetGroupFunc: func(input *elbv2.ModifyTargetGroupInput) (*elbv2.ModifyTargetGroupOutput, error) { return nil, nil }}).ExpectInput("ModifyTargetGroupAttributes", &elbv2.ModifyTargetGroupAttributesInput{
-			TargetGroupArn: String("any-tg"),
-			Attributes: []*elbv2.TargetGroupAttribute{
-				{Key: String("stickiness.enabled"), Value: String("ouech")},
-				{Key: String("stickiness.lb_cookie.duration_seconds"), Value: String("ouechdur")},
-				{Key: String("deregistration_delay.timeout_seconds"), Value: String("yeap")},
-			}}).ExpectInput("ModifyTargetGroup", &elbv2.ModifyTargetGroupInput{
+			ModifyTargetGroupFunc: func(input *elbv2.ModifyTargetGroupInput) (*elbv2.ModifyTargetGroupOutput, error) { return nil, nil },
+			DescribeTargetGroupAttributesFunc: func(input *elbv2.DescribeTargetGroupAttributesInput) (*elbv2.DescribeTargetGroupAttributesOutput, error) {
+				return &elbv2.DescribeTargetGroupAttributesOutput{Attributes: []*elbv2.TargetGroupAttribute{
+					{Key: String("stickiness.enabled"), Value: String("false")},
+					{Key: String("stickiness.lb_cookie.duration_seconds"), Value: String("86400")},
+					{Key: String("deregistration_delay.timeout_seconds"), Value: String("300")},
+				}}, nil
+			},
+			DescribeTargetGroupsFunc: func(input *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
+				return &elbv2.DescribeTargetGroupsOutput{TargetGroups: []*elbv2.TargetGroup{{
+					HealthCheckIntervalSeconds: Int64(30),
+					HealthCheckPath:            String("/"),
+					HealthCheckPort:            String("traffic-port"),
+					HealthCheckProtocol:        String("HTTP"),
+					HealthCheckTimeoutSeconds:  Int64(5),
+					HealthyThresholdCount:      Int64(5),
+					UnhealthyThresholdCount:    Int64(2),
+					Matcher:                    &elbv2.Matcher{HttpCode: String("200")},
+				}}}, nil
+			}}).ExpectInput("DescribeTargetGroupAttributes", &elbv2.DescribeTargetGroupAttributesInput{TargetGroupArn: String("any-tg")}).
+			ExpectInput("DescribeTargetGroups", &elbv2.DescribeTargetGroupsInput{TargetGroupArns: []*string{String("any-tg")}}).
+			ExpectInput("ModifyTargetGroupAttributes", &elbv2.ModifyTargetGroupAttributesInput{
+				TargetGroupArn: String("any-tg"),
+				Attributes: []*elbv2.TargetGroupAttribute{
+					{Key: String("stickiness.enabled"), Value: String("ouech")},
+					{Key: String("stickiness.lb_cookie.duration_seconds"), Value: String("ouechdur")},
+					{Key: String("deregistration_delay.timeout_seconds"), Value: String("yeap")},
+				}}).ExpectInput("ModifyTargetGroup", &elbv2.ModifyTargetGroupInput{
 			TargetGroupArn:             String("any-tg"),
 			HealthCheckIntervalSeconds: Int64(2),
 			HealthCheckPath:            String("/health"),
@@ -57,11 +79,12 @@ func TestTargetgroup(t *testing.T) {
 			Matcher: &elbv2.Matcher{
 				HttpCode: String("OK"),
 			},
-		}).ExpectCalls("ModifyTargetGroupAttributes", "ModifyTargetGroup").Run(t)
+		}).ExpectCalls("DescribeTargetGroupAttributes", "DescribeTargetGroups", "ModifyTargetGroupAttributes", "ModifyTargetGroup").
+			ExpectRevert("update targetgroup deregistrationdelay=300 healthcheckinterval=30 healthcheckpath=/ healthcheckport=traffic-port healthcheckprotocol=HTTP healthchecktimeout=5 healthythreshold=5 id=any-tg matcher=200 stickiness=false stickinessduration=86400 unhealthythreshold=2").Run(t)
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete targetgroup id=any-tg-arn").Mock(&elbv2Mock{
+		Template("delete targetgroup id=any-tg-arn").Mock(&awstest.Elbv2Mock{
 			DeleteTargetGroupFunc: func(input *elbv2.DeleteTargetGroupInput) (*elbv2.DeleteTargetGroupOutput, error) {
 				return &elbv2.DeleteTargetGroupOutput{}, nil
 			}}).ExpectInput("DeleteTargetGroup", &elbv2.DeleteTargetGroupInput{
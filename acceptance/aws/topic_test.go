@@ -4,11 +4,12 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestTopic(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
-		Template("create topic name=donald").Mock(&snsMock{
+		Template("create topic name=donald").Mock(&awstest.SnsMock{
 			CreateTopicFunc: func(input *sns.CreateTopicInput) (*sns.CreateTopicOutput, error) {
 				return &sns.CreateTopicOutput{TopicArn: String("new-topic-arn")}, nil
 			}}).ExpectInput("CreateTopic", &sns.CreateTopicInput{Name: String("donald")}).
@@ -16,7 +17,7 @@ func TestTopic(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete topic id=any-topic-id").Mock(&snsMock{
+		Template("delete topic id=any-topic-id").Mock(&awstest.SnsMock{
 			DeleteTopicFunc: func(input *sns.DeleteTopicInput) (*sns.DeleteTopicOutput, error) {
 				return nil, nil
 			}}).ExpectInput("DeleteTopic", &sns.DeleteTopicInput{
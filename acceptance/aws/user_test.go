@@ -4,11 +4,12 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestUser(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
-		Template("create user name=donald").Mock(&iamMock{
+		Template("create user name=donald").Mock(&awstest.IamMock{
 			CreateUserFunc: func(input *iam.CreateUserInput) (*iam.CreateUserOutput, error) {
 				return &iam.CreateUserOutput{User: &iam.User{UserId: String("new-user-id")}}, nil
 			}}).ExpectInput("CreateUser", &iam.CreateUserInput{
@@ -17,7 +18,7 @@ func TestUser(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete user name=donald").Mock(&iamMock{
+		Template("delete user name=donald").Mock(&awstest.IamMock{
 			DeleteUserFunc: func(input *iam.DeleteUserInput) (*iam.DeleteUserOutput, error) {
 				return nil, nil
 			}}).ExpectInput("DeleteUser", &iam.DeleteUserInput{
@@ -26,7 +27,7 @@ func TestUser(t *testing.T) {
 	})
 
 	t.Run("attach", func(t *testing.T) {
-		Template("attach user name=donald group=trolls").Mock(&iamMock{
+		Template("attach user name=donald group=trolls").Mock(&awstest.IamMock{
 			AddUserToGroupFunc: func(input *iam.AddUserToGroupInput) (*iam.AddUserToGroupOutput, error) {
 				return nil, nil
 			}}).ExpectInput("AddUserToGroup", &iam.AddUserToGroupInput{
@@ -36,7 +37,7 @@ func TestUser(t *testing.T) {
 	})
 
 	t.Run("detach", func(t *testing.T) {
-		Template("detach user name=donald group=trolls").Mock(&iamMock{
+		Template("detach user name=donald group=trolls").Mock(&awstest.IamMock{
 			RemoveUserFromGroupFunc: func(input *iam.RemoveUserFromGroupInput) (*iam.RemoveUserFromGroupOutput, error) {
 				return nil, nil
 			}}).ExpectInput("RemoveUserFromGroup", &iam.RemoveUserFromGroupInput{
@@ -4,11 +4,12 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestVolume(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
-		Template("create volume availabilityzone=eu-west-1 size=1").Mock(&ec2Mock{
+		Template("create volume availabilityzone=eu-west-1 size=1").Mock(&awstest.Ec2Mock{
 			CreateVolumeFunc: func(input *ec2.CreateVolumeInput) (*ec2.Volume, error) {
 				return &ec2.Volume{VolumeId: String("new-volume-id")}, nil
 			}}).
@@ -19,7 +20,7 @@ func TestVolume(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete volume id=any-volume-id").Mock(&ec2Mock{
+		Template("delete volume id=any-volume-id").Mock(&awstest.Ec2Mock{
 			DeleteVolumeFunc: func(*ec2.DeleteVolumeInput) (*ec2.DeleteVolumeOutput, error) {
 				return nil, nil
 			}}).
@@ -29,7 +30,7 @@ func TestVolume(t *testing.T) {
 	})
 
 	t.Run("check", func(t *testing.T) {
-		Template("check volume id=my-volume-id state=available timeout=0").Mock(&ec2Mock{
+		Template("check volume id=my-volume-id state=available timeout=0").Mock(&awstest.Ec2Mock{
 			DescribeVolumesFunc: func(input *ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error) {
 				return &ec2.DescribeVolumesOutput{Volumes: []*ec2.Volume{
 					{VolumeId: String("my-volume-id"), State: String("available")},
@@ -40,7 +41,7 @@ func TestVolume(t *testing.T) {
 	})
 
 	t.Run("attach", func(t *testing.T) {
-		Template("attach volume id=my-volume-id device=dev instance=my-instance-id").Mock(&ec2Mock{
+		Template("attach volume id=my-volume-id device=dev instance=my-instance-id").Mock(&awstest.Ec2Mock{
 			AttachVolumeFunc: func(param0 *ec2.AttachVolumeInput) (*ec2.VolumeAttachment, error) {
 				return &ec2.VolumeAttachment{VolumeId: String("my-volume-id")}, nil
 			}}).ExpectInput("AttachVolume", &ec2.AttachVolumeInput{
@@ -51,7 +52,7 @@ func TestVolume(t *testing.T) {
 	})
 
 	t.Run("detach", func(t *testing.T) {
-		Template("detach volume id=my-volume-id device=dev instance=my-instance-id force=true").Mock(&ec2Mock{
+		Template("detach volume id=my-volume-id device=dev instance=my-instance-id force=true").Mock(&awstest.Ec2Mock{
 			DetachVolumeFunc: func(input *ec2.DetachVolumeInput) (*ec2.VolumeAttachment, error) {
 				return &ec2.VolumeAttachment{VolumeId: String("my-volume-id")}, nil
 			}}).ExpectInput("DetachVolume", &ec2.DetachVolumeInput{
@@ -7,11 +7,12 @@ import (
 	"github.com/aws/aws-sdk-go/aws/client/metadata"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/aws/test"
 )
 
 func TestVPC(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
-		Template("create vpc name=myvpc cidr=10.0.0.0/16").Mock(&ec2Mock{
+		Template("create vpc name=myvpc cidr=10.0.0.0/16").Mock(&awstest.Ec2Mock{
 			CreateVpcFunc: func(input *ec2.CreateVpcInput) (*ec2.CreateVpcOutput, error) {
 				return &ec2.CreateVpcOutput{Vpc: &ec2.Vpc{VpcId: String("new-vpc-id")}}, nil
 			},
@@ -30,7 +31,7 @@ func TestVPC(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete vpc id=any-vpc-id").Mock(&ec2Mock{
+		Template("delete vpc id=any-vpc-id").Mock(&awstest.Ec2Mock{
 			DeleteVpcFunc: func(input *ec2.DeleteVpcInput) (*ec2.DeleteVpcOutput, error) {
 				return &ec2.DeleteVpcOutput{}, nil
 			}},
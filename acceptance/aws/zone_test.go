@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/wallix/awless/aws/test"
 )
 
 /*
@@ -19,7 +20,7 @@ import (
 
 func TestZone(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
-		Template(`create zone callerreference=caller name=new-zone delegationsetid=1234 comment="new zone" isprivate=true vpcid=any-vpc vpcregion=us-west-2`).Mock(&route53Mock{
+		Template(`create zone callerreference=caller name=new-zone delegationsetid=1234 comment="new zone" isprivate=true vpcid=any-vpc vpcregion=us-west-2`).Mock(&awstest.Route53Mock{
 			CreateHostedZoneFunc: func(input *route53.CreateHostedZoneInput) (*route53.CreateHostedZoneOutput, error) {
 				return &route53.CreateHostedZoneOutput{
 					HostedZone: &route53.HostedZone{Id: String("new-zone-id")},
@@ -38,7 +39,7 @@ func TestZone(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		Template("delete zone id=any-zone-id").Mock(&route53Mock{
+		Template("delete zone id=any-zone-id").Mock(&awstest.Route53Mock{
 			DeleteHostedZoneFunc: func(input *route53.DeleteHostedZoneInput) (*route53.DeleteHostedZoneOutput, error) {
 				return nil, nil
 			},
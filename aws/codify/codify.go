@@ -0,0 +1,581 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package codify generates awless templates recreating existing resources of a local graph
+package codify
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/template"
+)
+
+// creationOrder ranks the supported resource types, ordering the statements
+// which do not reference each other
+var creationOrder = []string{
+	cloud.Vpc,
+	cloud.InternetGateway,
+	cloud.Subnet,
+	cloud.RouteTable,
+	cloud.SecurityGroup,
+	cloud.NatGateway,
+	cloud.Instance,
+	cloud.Group,
+	cloud.User,
+	cloud.Queue,
+}
+
+// Template returns a template recreating the given resource and the resources of its subtree:
+// its children through parentOf relations and the resources depending on them through applyOn relations.
+// Statements reference the resources they depend on with $variables and are topologically ordered.
+// The resources or properties which cannot be recreated are returned as skipped.
+func Template(g *graph.Graph, root *graph.Resource) (tpl *template.Template, skipped []string, err error) {
+	c := &codifier{
+		g:         g,
+		resources: make(map[string]*graph.Resource),
+		vars:      make(map[string]string),
+		usedVars:  make(map[string]bool),
+	}
+	if err = c.collect(root); err != nil {
+		return nil, nil, err
+	}
+
+	var ordered []*graph.Resource
+	for _, res := range c.resources {
+		ordered = append(ordered, res)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		ri, rj := typeRank(ordered[i].Type()), typeRank(ordered[j].Type())
+		if ri != rj {
+			return ri < rj
+		}
+		return ordered[i].Id() < ordered[j].Id()
+	})
+	for _, res := range ordered {
+		c.vars[res.Id()] = c.variable(res)
+	}
+	for _, res := range ordered {
+		c.codify(res)
+	}
+
+	var buff bytes.Buffer
+	fmt.Fprintf(&buff, "# Generated from %s %s\n", root.Type(), root.Id())
+	for _, st := range c.sortedStatements() {
+		buff.WriteString(st.text)
+		buff.WriteByte('\n')
+	}
+	if tpl, err = template.Parse(buff.String()); err != nil {
+		return nil, c.skipped, fmt.Errorf("generated template: %s", err)
+	}
+	return tpl, c.skipped, nil
+}
+
+// Resource returns the statements recreating a single resource with its rules, memberships or attributes,
+// referencing the other resources by their ids. Its variable is named after it, as with Template.
+// The resource or properties which cannot be recreated are returned as skipped,
+// the statements being empty when the resource itself cannot be recreated.
+func Resource(g *graph.Graph, res *graph.Resource) (text string, skipped []string, err error) {
+	c := &codifier{
+		g:         g,
+		resources: make(map[string]*graph.Resource),
+		vars:      make(map[string]string),
+		usedVars:  make(map[string]bool),
+	}
+	c.add(res)
+	if _, ok := c.resources[res.Id()]; !ok {
+		return "", c.skipped, nil
+	}
+	c.vars[res.Id()] = c.variable(res)
+	c.codify(res)
+	if res.Type() == cloud.Instance {
+		c.skip(res, "data of its volumes, the instance being launched anew from its image")
+	}
+
+	var lines []string
+	for _, st := range c.sortedStatements() {
+		lines = append(lines, st.text)
+	}
+	text = strings.Join(lines, "\n")
+	if _, err = template.Parse(text); err != nil {
+		return "", c.skipped, fmt.Errorf("generated statements: %s", err)
+	}
+	return text, c.skipped, nil
+}
+
+type statement struct {
+	text string
+	// declares is the id of the resource created by the statement, if any
+	declares string
+	// refs are the ids of the resources referenced by the statement
+	refs []string
+}
+
+type codifier struct {
+	g          *graph.Graph
+	resources  map[string]*graph.Resource
+	vars       map[string]string
+	usedVars   map[string]bool
+	statements []*statement
+	skipped    []string
+	// members are the user/group memberships already codified
+	members map[string]bool
+}
+
+func (c *codifier) collect(root *graph.Resource) error {
+	var subtree []*graph.Resource
+	if err := c.g.Accept(&graph.ChildrenVisitor{From: root, Each: graph.VisitorCollectFunc(&subtree), IncludeFrom: true}); err != nil {
+		return err
+	}
+	for _, res := range subtree {
+		c.add(res)
+	}
+	for _, res := range subtree {
+		dependents, err := c.g.ListResourcesDependingOn(res)
+		if err != nil {
+			return err
+		}
+		for _, dep := range dependents {
+			// security groups and keypairs apply on instances, referenced by them
+			if res.Type() == cloud.Instance && (dep.Type() == cloud.SecurityGroup || dep.Type() == cloud.Keypair) {
+				continue
+			}
+			// groups and policies apply on users and groups, attached to them
+			if (res.Type() == cloud.User || res.Type() == cloud.Group) && (dep.Type() == cloud.Group || dep.Type() == cloud.Policy) {
+				continue
+			}
+			c.add(dep)
+		}
+	}
+	return nil
+}
+
+func (c *codifier) add(res *graph.Resource) {
+	if _, done := c.resources[res.Id()]; done {
+		return
+	}
+	if !Recreatable(res.Type()) {
+		c.skip(res, "resource type not supported")
+		return
+	}
+	switch {
+	case res.Type() == cloud.Instance && res.Properties()[properties.State] == "terminated":
+		return
+	case res.Type() == cloud.RouteTable && res.Properties()[properties.Default] == true:
+		c.skip(res, "main route table created with its VPC")
+		return
+	case res.Type() == cloud.SecurityGroup && res.Properties()[properties.Name] == "default":
+		c.skip(res, "default security group created with its VPC")
+		return
+	}
+	c.resources[res.Id()] = res
+}
+
+func (c *codifier) codify(res *graph.Resource) {
+	props := res.Properties()
+	id := res.Id()
+	switch res.Type() {
+	case cloud.Vpc:
+		c.create(res, "vpc", param("cidr", props[properties.CIDR]), param("name", props[properties.Name]))
+	case cloud.Subnet:
+		c.create(res, "subnet", param("cidr", props[properties.CIDR]), c.refParam("vpc", props[properties.Vpc]),
+			param("availabilityzone", props[properties.AvailabilityZone]), param("name", props[properties.Name]))
+		if props[properties.Public] == true {
+			c.follow(id, "update subnet id=$%s public=true", c.vars[id])
+		}
+	case cloud.InternetGateway:
+		c.create(res, "internetgateway")
+		c.nameTag(res)
+		for _, vpc := range stringSlice(props[properties.Vpcs]) {
+			c.follow(id, "attach internetgateway id=$%s %s", c.vars[id], c.refParam("vpc", vpc))
+		}
+	case cloud.RouteTable:
+		c.create(res, "routetable", c.refParam("vpc", props[properties.Vpc]))
+		c.nameTag(res)
+		if assocs, ok := props[properties.Associations].([]*graph.KeyValue); ok {
+			assocs = append([]*graph.KeyValue{}, assocs...)
+			sort.Slice(assocs, func(i, j int) bool { return assocs[i].Value < assocs[j].Value })
+			for _, assoc := range assocs {
+				c.follow(id, "attach routetable id=$%s %s", c.vars[id], c.refParam("subnet", assoc.Value))
+			}
+		}
+		if routes, ok := props[properties.Routes].([]*graph.Route); ok {
+			routes = append([]*graph.Route{}, routes...)
+			sort.Slice(routes, func(i, j int) bool { return routes[i].String() < routes[j].String() })
+			for _, route := range routes {
+				c.route(res, route)
+			}
+		}
+	case cloud.SecurityGroup:
+		c.create(res, "securitygroup", param("name", props[properties.Name]), c.refParam("vpc", props[properties.Vpc]),
+			param("description", props[properties.Description]))
+		if rules, ok := props[properties.InboundRules].([]*graph.FirewallRule); ok {
+			for _, rule := range sortedRules(rules) {
+				c.firewallRule(res, "inbound", rule)
+			}
+		}
+		if rules, ok := props[properties.OutboundRules].([]*graph.FirewallRule); ok {
+			for _, rule := range sortedRules(rules) {
+				if isDefaultEgress(rule) {
+					continue
+				}
+				c.firewallRule(res, "outbound", rule)
+			}
+		}
+	case cloud.NatGateway:
+		ipVar := c.uniqueVar(c.vars[id] + "_ip")
+		c.statements = append(c.statements, &statement{text: fmt.Sprintf("%s = create elasticip domain=vpc", ipVar)})
+		c.create(res, "natgateway", fmt.Sprintf("elasticip-id=$%s", ipVar), c.refParam("subnet", props[properties.Subnet]))
+	case cloud.Instance:
+		var groups []string
+		for _, sg := range stringSlice(props[properties.SecurityGroups]) {
+			groups = append(groups, c.ref(sg))
+		}
+		var groupsParam string
+		if len(groups) > 0 {
+			groupsParam = fmt.Sprintf("securitygroup=[%s]", strings.Join(groups, ","))
+		}
+		c.create(res, "instance", param("image", props[properties.Image]), param("type", props[properties.Type]),
+			c.refParam("subnet", props[properties.Subnet]), param("name", props[properties.Name]), "count=1",
+			param("keypair", props[properties.KeyPair]), groupsParam)
+	case cloud.Group:
+		c.create(res, "group", param("name", props[properties.Name]))
+		c.iamPath(res)
+		users, err := c.g.ListResourcesAppliedOn(res)
+		if err == nil {
+			for _, user := range sortedOfType(users, cloud.User) {
+				c.membership(user, res)
+			}
+		}
+		c.policies(res, "group")
+	case cloud.User:
+		c.create(res, "user", param("name", props[properties.Name]))
+		c.iamPath(res)
+		groups, err := c.g.ListResourcesDependingOn(res)
+		if err == nil {
+			for _, group := range sortedOfType(groups, cloud.Group) {
+				c.membership(res, group)
+			}
+		}
+		c.policies(res, "user")
+	case cloud.Queue:
+		if count, ok := props[properties.ApproximateMessageCount].(int); ok && count > 0 {
+			c.skip(res, fmt.Sprintf("its %d messages", count))
+		}
+		c.create(res, "queue", param("name", queueName(id)), param("delay", props[properties.Delay]),
+			param("max-msg-size", props[properties.MaxMessageSize]), param("retention-period", props[properties.MessageRetentionPeriod]),
+			param("msg-wait", props[properties.MessageWait]), param("visibility-timeout", props[properties.VisibilityTimeout]),
+			param("policy", props[properties.Policy]), param("redrive-policy", props[properties.RedrivePolicy]))
+	}
+}
+
+// membership attaches a user to a group, once for both of them
+func (c *codifier) membership(user, group *graph.Resource) {
+	userName, _ := user.Properties()[properties.Name].(string)
+	groupName, _ := group.Properties()[properties.Name].(string)
+	if c.members == nil {
+		c.members = make(map[string]bool)
+	}
+	key := userName + "/" + groupName
+	if c.members[key] || userName == "" || groupName == "" {
+		return
+	}
+	c.members[key] = true
+	text := fmt.Sprintf("attach user name=%s group=%s", quote(userName), quote(groupName))
+	c.statements = append(c.statements, &statement{text: text, refs: []string{user.Id(), group.Id()}})
+}
+
+// policies attaches to a user or group the managed policies applying on it
+func (c *codifier) policies(res *graph.Resource, key string) {
+	name, _ := res.Properties()[properties.Name].(string)
+	policies, err := c.g.ListResourcesDependingOn(res)
+	if err != nil {
+		return
+	}
+	for _, policy := range sortedOfType(policies, cloud.Policy) {
+		if arn, ok := policy.Properties()[properties.Arn].(string); ok {
+			c.follow(res.Id(), "attach policy arn=%s %s=%s", quote(arn), key, quote(name))
+		}
+	}
+	for _, inline := range stringSlice(res.Properties()[properties.InlinePolicies]) {
+		c.skip(res, fmt.Sprintf("inline policy %s", inline))
+	}
+}
+
+func (c *codifier) iamPath(res *graph.Resource) {
+	if path, ok := res.Properties()[properties.Path].(string); ok && path != "" && path != "/" {
+		c.skip(res, fmt.Sprintf("path %s", path))
+	}
+}
+
+func (c *codifier) create(res *graph.Resource, entity string, params ...string) {
+	var nonEmpty []string
+	for _, p := range params {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	text := fmt.Sprintf("%s = create %s", c.vars[res.Id()], entity)
+	if len(nonEmpty) > 0 {
+		text += " " + strings.Join(nonEmpty, " ")
+	}
+	c.statements = append(c.statements, &statement{text: text, declares: res.Id(), refs: c.refsIn(text)})
+}
+
+// follow appends a statement following the creation of the given resource
+func (c *codifier) follow(id, format string, a ...interface{}) {
+	text := fmt.Sprintf(format, a...)
+	c.statements = append(c.statements, &statement{text: text, refs: append(c.refsIn(text), id)})
+}
+
+func (c *codifier) nameTag(res *graph.Resource) {
+	if name, ok := res.Properties()[properties.Name].(string); ok && name != "" {
+		c.follow(res.Id(), "create tag resource=$%s key=Name value=%s", c.vars[res.Id()], quote(name))
+	}
+}
+
+func (c *codifier) route(table *graph.Resource, route *graph.Route) {
+	if route.Destination == nil {
+		c.skip(table, "route without IPv4 destination")
+		return
+	}
+	for _, target := range route.Targets {
+		switch {
+		case target.Type == graph.GatewayTarget && target.Ref == "local":
+		case target.Type == graph.GatewayTarget:
+			c.follow(table.Id(), "create route table=$%s cidr=%s %s", c.vars[table.Id()], route.Destination, c.refParam("gateway", target.Ref))
+		default:
+			c.skip(table, fmt.Sprintf("route to %s: only routes to gateways are supported", route.Destination))
+		}
+	}
+}
+
+func (c *codifier) firewallRule(sg *graph.Resource, direction string, rule *graph.FirewallRule) {
+	params := fmt.Sprintf("%s=authorize protocol=%s", direction, rule.Protocol)
+	if rule.Protocol != "any" {
+		params += " portrange=" + portRange(rule.PortRange)
+	}
+	for _, cidr := range rule.IPRanges {
+		if cidr.IP.To4() == nil {
+			c.skip(sg, fmt.Sprintf("%s rule for IPv6 range %s", direction, cidr))
+			continue
+		}
+		c.follow(sg.Id(), "update securitygroup id=$%s %s cidr=%s", c.vars[sg.Id()], params, cidr)
+	}
+	for _, source := range rule.Sources {
+		c.follow(sg.Id(), "update securitygroup id=$%s %s securitygroup=%s", c.vars[sg.Id()], params, c.ref(source))
+	}
+}
+
+func (c *codifier) skip(res *graph.Resource, reason string) {
+	desc := res.Id()
+	if name, ok := res.Properties()[properties.Name].(string); ok && name != "" && name != desc {
+		desc = fmt.Sprintf("%s (%s)", desc, name)
+	}
+	c.skipped = append(c.skipped, fmt.Sprintf("%s %s: %s", res.Type(), desc, reason))
+}
+
+// ref returns the $variable of a codified resource, or the id of a resource outside of the template
+func (c *codifier) ref(id string) string {
+	if v, ok := c.vars[id]; ok {
+		return "$" + v
+	}
+	return quote(id)
+}
+
+func (c *codifier) refParam(key string, id interface{}) string {
+	str, ok := id.(string)
+	if !ok || str == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s=%s", key, c.ref(str))
+}
+
+var refRegex = regexp.MustCompile(`\$([a-zA-Z0-9-_.]+)`)
+
+func (c *codifier) refsIn(text string) (refs []string) {
+	declared := make(map[string]string)
+	for id, v := range c.vars {
+		declared[v] = id
+	}
+	for _, match := range refRegex.FindAllStringSubmatch(text, -1) {
+		if id, ok := declared[match[1]]; ok {
+			refs = append(refs, id)
+		}
+	}
+	return
+}
+
+// sortedStatements orders topologically the statements from the resources they reference,
+// keeping their generation order otherwise
+func (c *codifier) sortedStatements() (sorted []*statement) {
+	created := make(map[string]bool)
+	declared := make(map[string]bool)
+	for _, st := range c.statements {
+		if st.declares != "" {
+			declared[st.declares] = true
+		}
+	}
+	done := make([]bool, len(c.statements))
+	for len(sorted) < len(c.statements) {
+		progress := false
+		for i, st := range c.statements {
+			if done[i] || !refsCreated(st, created, declared) {
+				continue
+			}
+			done[i], progress = true, true
+			sorted = append(sorted, st)
+			if st.declares != "" {
+				created[st.declares] = true
+			}
+			break
+		}
+		if !progress { // cyclic references: keep the remaining statements in order
+			for i, st := range c.statements {
+				if !done[i] {
+					sorted = append(sorted, st)
+				}
+			}
+			return
+		}
+	}
+	return
+}
+
+func refsCreated(st *statement, created, declared map[string]bool) bool {
+	for _, ref := range st.refs {
+		if ref != st.declares && declared[ref] && !created[ref] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *codifier) variable(res *graph.Resource) string {
+	base := res.Type()
+	if name, ok := res.Properties()[properties.Name].(string); ok && name != "" {
+		base = strings.Trim(nonIdentRegex.ReplaceAllString(strings.ToLower(name), "_"), "_")
+		if base == "" || base[0] >= '0' && base[0] <= '9' {
+			base = res.Type() + "_" + base
+		}
+	}
+	return c.uniqueVar(base)
+}
+
+func (c *codifier) uniqueVar(base string) string {
+	v := base
+	for i := 2; c.usedVars[v]; i++ {
+		v = fmt.Sprintf("%s_%d", base, i)
+	}
+	c.usedVars[v] = true
+	return v
+}
+
+var (
+	nonIdentRegex    = regexp.MustCompile(`[^a-z0-9_]+`)
+	simpleValueRegex = regexp.MustCompile(`^[a-zA-Z0-9-._:/]+$`)
+)
+
+func param(key string, value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		if v == "" {
+			return ""
+		}
+		return fmt.Sprintf("%s=%s", key, quote(v))
+	default:
+		return fmt.Sprintf("%s=%v", key, v)
+	}
+}
+
+func quote(s string) string {
+	if simpleValueRegex.MatchString(s) {
+		return s
+	}
+	if strings.ContainsRune(s, '\'') {
+		return "\"" + s + "\""
+	}
+	return "'" + s + "'"
+}
+
+func portRange(p graph.PortRange) string {
+	switch {
+	case p.Any:
+		return "any"
+	case p.FromPort == p.ToPort:
+		return fmt.Sprint(p.FromPort)
+	default:
+		return fmt.Sprintf("%d-%d", p.FromPort, p.ToPort)
+	}
+}
+
+// isDefaultEgress returns true for the outbound rule allowing all traffic, created with every security group
+func isDefaultEgress(rule *graph.FirewallRule) bool {
+	return rule.Protocol == "any" && len(rule.Sources) == 0 && len(rule.IPRanges) == 1 && rule.IPRanges[0].String() == "0.0.0.0/0"
+}
+
+// sortedRules sorts a copy of firewall rules, the graph not keeping their order
+func sortedRules(rules []*graph.FirewallRule) graph.FirewallRules {
+	sorted := append(graph.FirewallRules{}, rules...)
+	sorted.Sort()
+	return sorted
+}
+
+// stringSlice returns the sorted values of a property, the graph not keeping their order
+func stringSlice(i interface{}) []string {
+	strs, _ := i.([]string)
+	sorted := append([]string{}, strs...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// sortedOfType returns the resources of the given type sorted by id
+func sortedOfType(resources []*graph.Resource, typ string) (sorted []*graph.Resource) {
+	for _, res := range resources {
+		if res.Type() == typ {
+			sorted = append(sorted, res)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Id() < sorted[j].Id() })
+	return
+}
+
+// queueName returns the name of a queue from its URL, which is its id
+func queueName(url string) string {
+	return url[strings.LastIndex(url, "/")+1:]
+}
+
+// Recreatable returns true for the resource types whose statements can be generated
+func Recreatable(typ string) bool {
+	return typeRank(typ) < len(creationOrder)
+}
+
+func typeRank(typ string) int {
+	for i, t := range creationOrder {
+		if t == typ {
+			return i
+		}
+	}
+	return len(creationOrder)
+}
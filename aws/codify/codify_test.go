@@ -0,0 +1,174 @@
+package codify
+
+import (
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/template"
+)
+
+func TestTemplate(t *testing.T) {
+	g := graph.NewGraph()
+	newRes := func(typ, id string, props map[string]interface{}) *graph.Resource {
+		res := graph.InitResource(typ, id)
+		for k, v := range props {
+			res.Properties()[k] = v
+		}
+		if err := g.AddResource(res); err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+	cidr := func(s string) *net.IPNet {
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	vpc := newRes(cloud.Vpc, "vpc-1", map[string]interface{}{properties.Name: "prod", properties.CIDR: "10.0.0.0/16"})
+	subnet := newRes(cloud.Subnet, "sub-1", map[string]interface{}{properties.Name: "prod-public", properties.CIDR: "10.0.1.0/24",
+		properties.Vpc: "vpc-1", properties.AvailabilityZone: "eu-west-1a", properties.Public: true})
+	igw := newRes(cloud.InternetGateway, "igw-1", map[string]interface{}{properties.Vpcs: []string{"vpc-1"}})
+	mainTable := newRes(cloud.RouteTable, "rtb-main", map[string]interface{}{properties.Vpc: "vpc-1", properties.Default: true})
+	table := newRes(cloud.RouteTable, "rtb-1", map[string]interface{}{properties.Vpc: "vpc-1", properties.Default: false,
+		properties.Associations: []*graph.KeyValue{{KeyName: "rtbassoc-1", Value: "sub-1"}},
+		properties.Routes: []*graph.Route{
+			{Destination: cidr("10.0.0.0/16"), Targets: []*graph.RouteTarget{{Type: graph.GatewayTarget, Ref: "local"}}},
+			{Destination: cidr("0.0.0.0/0"), Targets: []*graph.RouteTarget{{Type: graph.GatewayTarget, Ref: "igw-1"}}},
+			{Destination: cidr("10.1.0.0/16"), Targets: []*graph.RouteTarget{{Type: graph.VpcPeeringConnectionTarget, Ref: "pcx-1"}}},
+		}})
+	sg := newRes(cloud.SecurityGroup, "sg-1", map[string]interface{}{properties.Name: "web", properties.Vpc: "vpc-1", properties.Description: "web servers",
+		properties.InboundRules: []*graph.FirewallRule{
+			{Protocol: "tcp", PortRange: graph.PortRange{FromPort: 443, ToPort: 443}, IPRanges: []*net.IPNet{cidr("0.0.0.0/0")}},
+			{Protocol: "tcp", PortRange: graph.PortRange{FromPort: 22, ToPort: 22}, Sources: []string{"sg-bastion"}},
+		},
+		properties.OutboundRules: []*graph.FirewallRule{
+			{Protocol: "any", PortRange: graph.PortRange{Any: true}, IPRanges: []*net.IPNet{cidr("0.0.0.0/0")}},
+		}})
+	inst := newRes(cloud.Instance, "i-1", map[string]interface{}{properties.Name: "web 1", properties.Image: "ami-1", properties.Type: "t2.micro",
+		properties.Subnet: "sub-1", properties.KeyPair: "ops", properties.SecurityGroups: []string{"sg-1", "sg-bastion"}, properties.State: "running"})
+	keypair := newRes(cloud.Keypair, "ops", nil)
+	volume := newRes(cloud.Volume, "vol-1", nil)
+
+	for _, child := range []*graph.Resource{subnet, mainTable, table, sg} {
+		g.AddParentRelation(vpc, child)
+	}
+	g.AddParentRelation(subnet, inst)
+	g.AddAppliesOnRelation(igw, vpc)
+	g.AddAppliesOnRelation(table, subnet)
+	g.AddAppliesOnRelation(sg, inst)
+	g.AddAppliesOnRelation(keypair, inst)
+	g.AddAppliesOnRelation(volume, inst)
+
+	tpl, skipped, err := Template(g, vpc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `# Generated from vpc vpc-1
+prod            = create vpc cidr=10.0.0.0/16 name=prod
+internetgateway = create internetgateway
+attach internetgateway id=$internetgateway vpc=$prod
+prod_public = create subnet availabilityzone=eu-west-1a cidr=10.0.1.0/24 name=prod-public vpc=$prod
+update subnet id=$prod_public public=true
+routetable = create routetable vpc=$prod
+attach routetable id=$routetable subnet=$prod_public
+create route cidr=0.0.0.0/0 gateway=$internetgateway table=$routetable
+web = create securitygroup description='web servers' name=web vpc=$prod
+update securitygroup id=$web inbound=authorize portrange=22 protocol=tcp securitygroup=sg-bastion
+update securitygroup cidr=0.0.0.0/0 id=$web inbound=authorize portrange=443 protocol=tcp
+web_1 = create instance count=1 image=ami-1 keypair=ops name='web 1' securitygroup=[$web,sg-bastion] subnet=$prod_public type=t2.micro
+`
+	if got := tpl.Format(); got != exp {
+		t.Fatalf("got\n%s\nwant\n%s", got, exp)
+	}
+
+	expSkipped := []string{
+		"routetable rtb-main: main route table created with its VPC",
+		"routetable rtb-1: route to 10.1.0.0/16: only routes to gateways are supported",
+		"volume vol-1: resource type not supported",
+	}
+	sort.Strings(skipped)
+	sort.Strings(expSkipped)
+	if !reflect.DeepEqual(skipped, expSkipped) {
+		t.Fatalf("got skipped\n%s\nwant\n%s", strings.Join(skipped, "\n"), strings.Join(expSkipped, "\n"))
+	}
+}
+
+func TestResource(t *testing.T) {
+	g := graph.NewGraph()
+	newRes := func(typ, id string, props map[string]interface{}) *graph.Resource {
+		res := graph.InitResource(typ, id)
+		for k, v := range props {
+			res.Properties()[k] = v
+		}
+		if err := g.AddResource(res); err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+	_, anywhere, _ := net.ParseCIDR("0.0.0.0/0")
+
+	vpc := newRes(cloud.Vpc, "vpc-1", map[string]interface{}{properties.Name: "prod", properties.CIDR: "10.0.0.0/16"})
+	sg := newRes(cloud.SecurityGroup, "sg-1", map[string]interface{}{properties.Name: "web", properties.Vpc: "vpc-1", properties.Description: "web servers",
+		properties.InboundRules: []*graph.FirewallRule{
+			{Protocol: "tcp", PortRange: graph.PortRange{FromPort: 443, ToPort: 443}, IPRanges: []*net.IPNet{anywhere}},
+		}})
+	g.AddParentRelation(vpc, sg)
+	user := newRes(cloud.User, "AIDA1", map[string]interface{}{properties.Name: "alice", properties.Path: "/",
+		properties.InlinePolicies: []string{"s3-access"}})
+	admins := newRes(cloud.Group, "AGPA1", map[string]interface{}{properties.Name: "admins"})
+	devs := newRes(cloud.Group, "AGPA2", map[string]interface{}{properties.Name: "devs"})
+	policy := newRes(cloud.Policy, "ANPA1", map[string]interface{}{properties.Name: "ReadOnly", properties.Arn: "arn:aws:iam::aws:policy/ReadOnlyAccess"})
+	g.AddAppliesOnRelation(admins, user)
+	g.AddAppliesOnRelation(devs, user)
+	g.AddAppliesOnRelation(policy, user)
+	queue := newRes(cloud.Queue, "https://sqs.eu-west-1.amazonaws.com/123456789012/jobs", map[string]interface{}{properties.Delay: 5,
+		properties.VisibilityTimeout: 60, properties.RedrivePolicy: `{"maxReceiveCount":"5"}`, properties.ApproximateMessageCount: 3})
+	keypair := newRes(cloud.Keypair, "ops", nil)
+
+	tcases := []struct {
+		res        *graph.Resource
+		exp        string
+		expSkipped []string
+	}{
+		{res: sg, exp: "web = create securitygroup description='web servers' name=web vpc=vpc-1\n" +
+			"update securitygroup cidr=0.0.0.0/0 id=$web inbound=authorize portrange=443 protocol=tcp"},
+		{res: user, exp: "alice = create user name=alice\n" +
+			"attach user group=admins name=alice\n" +
+			"attach user group=devs name=alice\n" +
+			"attach policy arn=arn:aws:iam::aws:policy/ReadOnlyAccess user=alice",
+			expSkipped: []string{"user AIDA1 (alice): inline policy s3-access"}},
+		{res: admins, exp: "admins = create group name=admins\n" +
+			"attach user group=admins name=alice"},
+		{res: queue, exp: `queue = create queue delay=5 name=jobs redrive-policy='{"maxReceiveCount":"5"}' visibility-timeout=60`,
+			expSkipped: []string{"queue https://sqs.eu-west-1.amazonaws.com/123456789012/jobs: its 3 messages"}},
+		{res: keypair, expSkipped: []string{"keypair ops: resource type not supported"}},
+	}
+	for i, tcase := range tcases {
+		text, skipped, err := Resource(g, tcase.res)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if text != "" {
+			tpl, err := template.Parse(text)
+			if err != nil {
+				t.Fatalf("%d: %s", i+1, err)
+			}
+			text = tpl.String()
+		}
+		if got, want := text, tcase.exp; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+		if got, want := skipped, tcase.expSkipped; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got skipped %q, want %q", i+1, got, want)
+		}
+	}
+}
@@ -675,6 +675,15 @@ func addManualStorageFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 		return resources, objects, err
 	}
 }
+
+// queueIntAttributes maps the SQS integer attributes to the properties holding them
+var queueIntAttributes = map[string]string{
+	"MaximumMessageSize":            properties.MaxMessageSize,
+	"MessageRetentionPeriod":        properties.MessageRetentionPeriod,
+	"ReceiveMessageWaitTimeSeconds": properties.MessageWait,
+	"VisibilityTimeout":             properties.VisibilityTimeout,
+}
+
 func addManualMessagingFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 	funcs["queue"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
 		var objects []*string
@@ -742,6 +751,16 @@ func addManualMessagingFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 							errC <- err
 						}
 						res.Properties()[properties.Delay] = delay
+					case "MaximumMessageSize", "MessageRetentionPeriod", "ReceiveMessageWaitTimeSeconds", "VisibilityTimeout":
+						val, err := strconv.Atoi(awssdk.StringValue(v))
+						if err != nil {
+							errC <- err
+						}
+						res.Properties()[queueIntAttributes[k]] = val
+					case "Policy":
+						res.Properties()[properties.Policy] = awssdk.StringValue(v)
+					case "RedrivePolicy":
+						res.Properties()[properties.RedrivePolicy] = awssdk.StringValue(v)
 					}
 
 				}
@@ -888,6 +888,9 @@ func TestBuildNotificationGraph(t *testing.T) {
 			"LastModifiedTimestamp":       awssdk.String("1494332859"),
 			"QueueArn":                    awssdk.String("queue_2_arn"),
 			"DelaySeconds":                awssdk.String("15"),
+			"MaximumMessageSize":          awssdk.String("1024"),
+			"VisibilityTimeout":           awssdk.String("60"),
+			"RedrivePolicy":               awssdk.String(`{"maxReceiveCount":"5"}`),
 		},
 		"queue_3": {
 			"ApproximateNumberOfMessages": awssdk.String("12"),
@@ -935,7 +938,8 @@ func TestBuildNotificationGraph(t *testing.T) {
 
 	expected = map[string]*graph.Resource{
 		"queue_1": resourcetest.Queue("queue_1").Build(),
-		"queue_2": resourcetest.Queue("queue_2").Prop(p.ApproximateMessageCount, 4).Prop(p.Created, time.Unix(1494419259, 0).UTC()).Prop(p.Modified, time.Unix(1494332859, 0).UTC()).Prop(p.Arn, "queue_2_arn").Prop(p.Delay, 15).Build(),
+		"queue_2": resourcetest.Queue("queue_2").Prop(p.ApproximateMessageCount, 4).Prop(p.Created, time.Unix(1494419259, 0).UTC()).Prop(p.Modified, time.Unix(1494332859, 0).UTC()).Prop(p.Arn, "queue_2_arn").Prop(p.Delay, 15).
+			Prop(p.MaxMessageSize, 1024).Prop(p.VisibilityTimeout, 60).Prop(p.RedrivePolicy, `{"maxReceiveCount":"5"}`).Build(),
 		"queue_3": resourcetest.Queue("queue_3").Prop(p.ApproximateMessageCount, 12).Build(),
 	}
 	expectedChildren = map[string][]string{}
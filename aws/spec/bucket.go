@@ -16,22 +16,27 @@ limitations under the License.
 package awsspec
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/wallix/awless/cloud/graph"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/wallix/awless/logger"
 )
 
 type CreateBucket struct {
-	_      string `action:"create" entity:"bucket" awsAPI:"s3" awsCall:"CreateBucket" awsInput:"s3.CreateBucketInput" awsOutput:"s3.CreateBucketOutput"`
+	_      string `action:"create" entity:"bucket" awsAPI:"s3" awsCall:"CreateBucket" awsInput:"s3.CreateBucketInput" awsOutput:"s3.CreateBucketOutput" cfnType:"AWS::S3::Bucket"`
 	logger *logger.Logger
 	graph  cloudgraph.GraphAPI
 	api    s3iface.S3API
-	Name   *string `awsName:"Bucket" awsType:"awsstr" templateName:"name" required:""`
+	Name   *string `awsName:"Bucket" awsType:"awsstr" templateName:"name" cfnName:"BucketName" required:""`
 	Acl    *string `awsName:"ACL" awsType:"awsstr" templateName:"acl"`
 }
 
@@ -63,6 +68,79 @@ func (cmd *UpdateBucket) ValidateParams(params []string) ([]string, error) {
 	}.verify(params)
 }
 
+func (cmd *UpdateBucket) PriorValues(params map[string]interface{}) (map[string]interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, err
+	}
+	prior := make(map[string]interface{})
+	if cmd.Acl != nil {
+		out, err := cmd.api.GetBucketAcl(&s3.GetBucketAclInput{Bucket: cmd.Name})
+		if err != nil {
+			return nil, err
+		}
+		acl, err := cannedACL(out)
+		if err != nil {
+			return nil, err
+		}
+		prior["acl"] = acl
+	}
+	if cmd.PublicWebsite != nil {
+		prior["redirect-hostname"], prior["index-suffix"], prior["enforce-https"] = nil, nil, nil
+		out, err := cmd.api.GetBucketWebsite(&s3.GetBucketWebsiteInput{Bucket: cmd.Name})
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchWebsiteConfiguration" {
+			prior["public-website"] = false
+			return prior, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		prior["public-website"] = true
+		if redirect := out.RedirectAllRequestsTo; redirect != nil {
+			prior["redirect-hostname"] = StringValue(redirect.HostName)
+			prior["enforce-https"] = StringValue(redirect.Protocol) == "https"
+		} else if out.IndexDocument != nil {
+			prior["index-suffix"] = StringValue(out.IndexDocument.Suffix)
+		}
+	}
+	return prior, nil
+}
+
+// cannedACL returns the canned ACL matching the grants of a bucket
+func cannedACL(out *s3.GetBucketAclOutput) (string, error) {
+	const allUsers, authenticatedUsers = "http://acs.amazonaws.com/groups/global/AllUsers", "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+	var ownerFullControl bool
+	var others []string
+	for _, g := range out.Grants {
+		if g.Grantee == nil {
+			continue
+		}
+		switch {
+		case out.Owner != nil && StringValue(g.Grantee.ID) == StringValue(out.Owner.ID) && StringValue(g.Permission) == s3.PermissionFullControl:
+			ownerFullControl = true
+		case StringValue(g.Grantee.URI) == allUsers:
+			others = append(others, "all:"+StringValue(g.Permission))
+		case StringValue(g.Grantee.URI) == authenticatedUsers:
+			others = append(others, "authenticated:"+StringValue(g.Permission))
+		default:
+			return "", fmt.Errorf("grant of %s to %s has no canned ACL", StringValue(g.Permission), StringValue(g.Grantee.ID))
+		}
+	}
+	if ownerFullControl {
+		sort.Strings(others)
+		switch strings.Join(others, ",") {
+		case "":
+			return s3.BucketCannedACLPrivate, nil
+		case "all:READ":
+			return s3.BucketCannedACLPublicRead, nil
+		case "all:READ,all:WRITE":
+			return s3.BucketCannedACLPublicReadWrite, nil
+		case "authenticated:READ":
+			return s3.BucketCannedACLAuthenticatedRead, nil
+		}
+	}
+	return "", errors.New("bucket grants have no canned ACL")
+}
+
 func (cmd *UpdateBucket) ManualRun(ctx map[string]interface{}) (interface{}, error) {
 	start := time.Now()
 
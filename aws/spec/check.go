@@ -0,0 +1,202 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/graph"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/cloud/rdf"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+)
+
+// CheckResource polls any resource type fetched by a cloud service, until the graph
+// properties given as params (ex: State=CREATE_COMPLETE) have the expected values.
+// It is built for the entities without a dedicated check command.
+type CheckResource struct {
+	entity       string
+	logger       *logger.Logger
+	graph        cloudgraph.GraphAPI
+	properties   map[string]string
+	frequency    time.Duration
+	maxFrequency time.Duration
+	Id           *string `templateName:"id" required:""`
+	Timeout      *int64  `templateName:"timeout" required:""`
+}
+
+func NewCheckResource(entity string, g cloudgraph.GraphAPI, l ...*logger.Logger) *CheckResource {
+	cmd := &CheckResource{entity: entity, properties: make(map[string]string), frequency: 2 * time.Second, maxFrequency: 30 * time.Second}
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (f *AWSFactory) buildCheckResource(key string) func() interface{} {
+	if !strings.HasPrefix(key, "check") {
+		return nil
+	}
+	entity := strings.TrimPrefix(key, "check")
+	if _, err := cloud.GetServiceForType(entity); err != nil {
+		return nil
+	}
+	return func() interface{} { return NewCheckResource(entity, f.Graph, f.Log) }
+}
+
+func isCheckedProperty(key string) bool {
+	_, ok := rdf.Labels[key]
+	return ok
+}
+
+func (cmd *CheckResource) ValidateParams(params []string) ([]string, error) {
+	var others []string
+	for _, p := range params {
+		if !isCheckedProperty(p) {
+			others = append(others, p)
+		}
+	}
+	return validateParams(cmd, others)
+}
+
+func (cmd *CheckResource) ValidateCommand(params map[string]interface{}, refs []string) (errs []error) {
+	if err := cmd.inject(params); err != nil {
+		return []error{err}
+	}
+	if err := validateStruct(cmd, refs); err != nil {
+		errs = append(errs, err)
+	}
+	var refProperty bool
+	for _, ref := range refs {
+		if isCheckedProperty(ref) {
+			refProperty = true
+		}
+	}
+	if len(cmd.properties) == 0 && !refProperty {
+		errs = append(errs, errors.New("expecting at least one property to check (ex: State=available)"))
+	}
+	return
+}
+
+func (cmd *CheckResource) ParamsHelp() string {
+	return generateParamsHelp("check"+cmd.entity, structListParamsKeys(cmd)) + "\n\tand the properties to check (ex: State=available)"
+}
+
+func (cmd *CheckResource) inject(params map[string]interface{}) error {
+	if err := structSetter(cmd, params); err != nil {
+		return err
+	}
+	for k, v := range params {
+		if isCheckedProperty(k) {
+			cmd.properties[k] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
+
+func (cmd *CheckResource) Run(ctx, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	var keys []string
+	for k := range cmd.properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	formatProperties := func(valueFn func(string) string) string {
+		var out []string
+		for _, k := range keys {
+			out = append(out, fmt.Sprintf("%s=%s", k, valueFn(k)))
+		}
+		return strings.Join(out, ", ")
+	}
+
+	c := &checker{
+		description:  fmt.Sprintf("%s %s", cmd.entity, StringValue(cmd.Id)),
+		timeout:      time.Duration(Int64AsIntValue(cmd.Timeout)) * time.Second,
+		frequency:    cmd.frequency,
+		maxFrequency: cmd.maxFrequency,
+		fetchFunc: func() (string, error) {
+			res, err := cmd.fetch()
+			if err != nil {
+				return "", err
+			}
+			if res == nil {
+				return formatProperties(func(string) string { return notFoundState }), nil
+			}
+			return formatProperties(func(k string) string {
+				if v, ok := res.Property(k); ok {
+					return fmt.Sprint(v)
+				}
+				return ""
+			}), nil
+		},
+		expect:    formatProperties(func(k string) string { return cmd.properties[k] }),
+		logger:    cmd.logger,
+		checkName: "properties",
+	}
+	if err := c.check(); err != nil {
+		return nil, err
+	}
+
+	cmd.logger.Verbosef("check %s done", cmd.entity)
+	return nil, nil
+}
+
+func (cmd *CheckResource) DryRun(ctx, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId(cmd.entity), nil
+}
+
+func (cmd *CheckResource) fetch() (*graph.Resource, error) {
+	srv, err := cloud.GetServiceForType(cmd.entity)
+	if err != nil {
+		return nil, err
+	}
+	g, err := srv.FetchByType(context.Background(), cmd.entity)
+	if err != nil {
+		return nil, err
+	}
+	id := StringValue(cmd.Id)
+	res, err := g.FindResource(id)
+	if err != nil {
+		return nil, err
+	}
+	if res != nil && res.Type() == cmd.entity {
+		return res, nil
+	}
+	named, err := g.FindResourcesByProperty(properties.Name, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range named {
+		if r.Type() == cmd.entity {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
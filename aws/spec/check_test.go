@@ -0,0 +1,120 @@
+package awsspec
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+)
+
+type stackService struct {
+	cloud.Service
+	stacks  []*graph.Resource
+	fetched int
+	onFetch func(fetched int)
+}
+
+func (s *stackService) Name() string            { return "cloudformation" }
+func (s *stackService) ResourceTypes() []string { return []string{"stack"} }
+func (s *stackService) FetchByType(ctx context.Context, t string) (*graph.Graph, error) {
+	s.fetched++
+	if s.onFetch != nil {
+		s.onFetch(s.fetched)
+	}
+	g := graph.NewGraph()
+	return g, g.AddResource(s.stacks...)
+}
+
+func TestCheckResource(t *testing.T) {
+	stack := graph.InitResource("stack", "arn:stack/my-stack")
+	stack.SetProperty("Name", "my-stack")
+	stack.SetProperty("State", "CREATE_COMPLETE")
+	cloud.ServiceRegistry["cloudformation"] = &stackService{stacks: []*graph.Resource{stack}}
+	defer delete(cloud.ServiceRegistry, "cloudformation")
+
+	factory := &AWSFactory{Log: logger.DiscardLogger}
+
+	t.Run("build", func(t *testing.T) {
+		if _, ok := factory.Build("checkstack")().(*CheckResource); !ok {
+			t.Fatal("expected generic check for stack")
+		}
+		if _, ok := factory.Build("checkinstance")().(*CheckInstance); !ok {
+			t.Fatal("expected dedicated check for instance")
+		}
+		if build := factory.Build("checkunknown"); build != nil {
+			t.Fatal("expected no check for unfetched type")
+		}
+		if build := factory.Build("deletestack"); build == nil {
+			t.Fatal("expected dedicated delete for stack")
+		}
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		cmd := factory.Build("checkstack")().(*CheckResource)
+		missing, err := cmd.ValidateParams([]string{"id", "State"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(missing) != 1 || missing[0] != "timeout" {
+			t.Fatalf("got %v, want [timeout]", missing)
+		}
+		if _, err = cmd.ValidateParams([]string{"id", "Unknown", "timeout"}); err == nil || !strings.Contains(err.Error(), "unexpected 'Unknown' param") {
+			t.Fatalf("got %v", err)
+		}
+		checkErrs(t, cmd.ValidateCommand(map[string]interface{}{"id": "my-stack", "timeout": 10}, nil), 1, "at least one property")
+		cmd = factory.Build("checkstack")().(*CheckResource)
+		checkErrs(t, cmd.ValidateCommand(map[string]interface{}{"id": "my-stack", "timeout": 10}, []string{"State"}), 0)
+	})
+
+	t.Run("run", func(t *testing.T) {
+		tcases := []map[string]interface{}{
+			{"id": "arn:stack/my-stack", "State": "CREATE_COMPLETE"},
+			{"id": "my-stack", "State": "create_complete", "Name": "my-stack"},
+			{"id": "other-stack", "State": "not-found"},
+		}
+		for i, params := range tcases {
+			cmd := factory.Build("checkstack")().(*CheckResource)
+			params["timeout"] = 10
+			if _, err := cmd.Run(nil, params); err != nil {
+				t.Fatalf("%d: %s", i+1, err)
+			}
+		}
+	})
+	t.Run("poll until state changes", func(t *testing.T) {
+		stack := graph.InitResource("stack", "arn:stack/new-stack")
+		stack.SetProperty("State", "CREATE_IN_PROGRESS")
+		cloud.ServiceRegistry["cloudformation"] = &stackService{stacks: []*graph.Resource{stack}, onFetch: func(fetched int) {
+			if fetched == 4 {
+				stack.SetProperty("State", "CREATE_COMPLETE")
+			}
+		}}
+
+		var out bytes.Buffer
+		cmd := NewCheckResource("stack", nil, logger.New("", 0, &out))
+		cmd.frequency, cmd.maxFrequency = time.Millisecond, 3*time.Millisecond
+		if _, err := cmd.Run(nil, map[string]interface{}{"id": "arn:stack/new-stack", "State": "CREATE_COMPLETE", "timeout": 10}); err != nil {
+			t.Fatal(err)
+		}
+
+		var progress []string
+		for _, line := range strings.Split(out.String(), "\r") {
+			if i := strings.Index(line, "(retry in "); i >= 0 {
+				progress = append(progress, line[i:])
+			}
+		}
+		if got, want := strings.Join(progress, ", "), "(retry in 1ms), (retry in 2ms), (retry in 3ms)"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := strings.Count(out.String(), "stack arn:stack/new-stack properties 'State=CREATE_IN_PROGRESS', expect 'State=CREATE_COMPLETE'"), 3; got != want {
+			t.Fatalf("got %d progress lines, want %d in\n%s", got, want, out.String())
+		}
+		if !strings.Contains(out.String(), "check stack arn:stack/new-stack properties 'State=CREATE_COMPLETE' done") {
+			t.Fatalf("expected check done in\n%s", out.String())
+		}
+	})
+}
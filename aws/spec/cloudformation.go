@@ -0,0 +1,45 @@
+package awsspec
+
+import (
+	"reflect"
+)
+
+// CloudFormationResource describes how a command creating a resource maps to a CloudFormation resource,
+// as declared with the `cfnType` and `cfnResult` tags of the command and the `cfnName` tags of its params
+type CloudFormationResource struct {
+	Type string
+	// Result is the attribute of the resource returned by the command, its Ref when empty
+	Result string
+	// Properties are the CloudFormation properties of the template params, as in "Tags.Name" for a Name tag
+	Properties map[string]string
+}
+
+// CloudFormationResourceOf returns the CloudFormation mapping of a command, false when it has none
+func CloudFormationResourceOf(cmd interface{}) (*CloudFormationResource, bool) {
+	val := reflect.ValueOf(cmd)
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+	stru := val.Elem().Type()
+
+	res := &CloudFormationResource{Properties: make(map[string]string)}
+	for i := 0; i < stru.NumField(); i++ {
+		field := stru.Field(i)
+		if field.Name == "_" {
+			res.Type = field.Tag.Get("cfnType")
+			res.Result = field.Tag.Get("cfnResult")
+			continue
+		}
+		tplName, ok := field.Tag.Lookup("templateName")
+		if !ok {
+			continue
+		}
+		if cfnName, ok := field.Tag.Lookup("cfnName"); ok {
+			res.Properties[tplName] = cfnName
+		}
+	}
+	if res.Type == "" {
+		return nil, false
+	}
+	return res, true
+}
@@ -39,7 +39,7 @@ type CreateDatabase struct {
 	Type     *string `awsName:"DBInstanceClass" awsType:"awsstr" templateName:"type"`
 	Id       *string `awsName:"DBInstanceIdentifier" awsType:"awsstr" templateName:"id"`
 	Engine   *string `awsName:"Engine" awsType:"awsstr" templateName:"engine"`
-	Password *string `awsName:"MasterUserPassword" awsType:"awsstr" templateName:"password"`
+	Password *string `awsName:"MasterUserPassword" awsType:"awsstr" templateName:"password" secret:""`
 	Username *string `awsName:"MasterUsername" awsType:"awsstr" templateName:"username"`
 	Size     *int64  `awsName:"AllocatedStorage" awsType:"awsint64" templateName:"size"`
 
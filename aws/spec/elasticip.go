@@ -24,11 +24,11 @@ import (
 )
 
 type CreateElasticip struct {
-	_      string `action:"create" entity:"elasticip" awsAPI:"ec2" awsCall:"AllocateAddress" awsInput:"ec2.AllocateAddressInput" awsOutput:"ec2.AllocateAddressOutput" awsDryRun:""`
+	_      string `action:"create" entity:"elasticip" awsAPI:"ec2" awsCall:"AllocateAddress" awsInput:"ec2.AllocateAddressInput" awsOutput:"ec2.AllocateAddressOutput" awsDryRun:"" cfnType:"AWS::EC2::EIP" cfnResult:"AllocationId"`
 	logger *logger.Logger
 	graph  cloudgraph.GraphAPI
 	api    ec2iface.EC2API
-	Domain *string `awsName:"Domain" awsType:"awsstr" templateName:"domain" required:""`
+	Domain *string `awsName:"Domain" awsType:"awsstr" templateName:"domain" cfnName:"Domain" required:""`
 }
 
 func (cmd *CreateElasticip) ValidateParams(params []string) ([]string, error) {
@@ -24,10 +24,11 @@ import (
 )
 
 type CreateFunction struct {
-	_             string `action:"create" entity:"function" awsAPI:"lambda" awsCall:"CreateFunction" awsInput:"lambda.CreateFunctionInput" awsOutput:"lambda.FunctionConfiguration"`
-	logger        *logger.Logger
-	graph         cloudgraph.GraphAPI
-	api           lambdaiface.LambdaAPI
+	_      string `action:"create" entity:"function" awsAPI:"lambda" awsCall:"CreateFunction" awsInput:"lambda.CreateFunctionInput" awsOutput:"lambda.FunctionConfiguration"`
+	logger *logger.Logger
+	graph  cloudgraph.GraphAPI
+	api    lambdaiface.LambdaAPI
+	inlineFiles
 	Name          *string `awsName:"FunctionName" awsType:"awsstr" templateName:"name" required:""`
 	Handler       *string `awsName:"Handler" awsType:"awsstr" templateName:"handler" required:""`
 	Role          *string `awsName:"Role" awsType:"awsstr" templateName:"role" required:""`
@@ -548,8 +548,8 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Action:         "create",
 		Entity:         "policy",
 		Api:            "iam",
-		RequiredParams: []string{"action", "effect", "name", "resource"},
-		ExtraParams:    []string{"conditions", "description"},
+		RequiredParams: []string{"name"},
+		ExtraParams:    []string{"action", "conditions", "description", "document", "effect", "resource"},
 	},
 	"createqueue": {
 		Action:         "create",
@@ -362,7 +362,7 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 	case "updatetargetgroup":
 		return func() interface{} { return NewUpdateTargetgroup(f.Sess, f.Graph, f.Log) }
 	}
-	return nil
+	return f.buildCheckResource(key)
 }
 
 var (
@@ -3142,6 +3142,10 @@ func (cmd *CreateDatabase) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateDatabase) SecretParams() []string {
+	return []string{"password"}
+}
+
 func NewCreateDbsubnetgroup(sess *session.Session, g cloudgraph.GraphAPI, l ...*logger.Logger) *CreateDbsubnetgroup {
 	cmd := new(CreateDbsubnetgroup)
 	if len(l) > 0 {
@@ -4438,6 +4442,10 @@ func (cmd *CreateLoginprofile) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateLoginprofile) SecretParams() []string {
+	return []string{"password"}
+}
+
 func NewCreateMfadevice(sess *session.Session, g cloudgraph.GraphAPI, l ...*logger.Logger) *CreateMfadevice {
 	cmd := new(CreateMfadevice)
 	if len(l) > 0 {
@@ -13744,6 +13752,10 @@ func (cmd *UpdateLoginprofile) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *UpdateLoginprofile) SecretParams() []string {
+	return []string{"password"}
+}
+
 func NewUpdatePolicy(sess *session.Session, g cloudgraph.GraphAPI, l ...*logger.Logger) *UpdatePolicy {
 	cmd := new(UpdatePolicy)
 	if len(l) > 0 {
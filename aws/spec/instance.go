@@ -30,21 +30,22 @@ import (
 )
 
 type CreateInstance struct {
-	_              string `action:"create" entity:"instance" awsAPI:"ec2" awsCall:"RunInstances" awsInput:"ec2.RunInstancesInput" awsOutput:"ec2.Reservation" awsDryRun:""`
-	logger         *logger.Logger
-	graph          cloudgraph.GraphAPI
-	api            ec2iface.EC2API
-	Image          *string   `awsName:"ImageId" awsType:"awsstr" templateName:"image" required:""`
+	_      string `action:"create" entity:"instance" awsAPI:"ec2" awsCall:"RunInstances" awsInput:"ec2.RunInstancesInput" awsOutput:"ec2.Reservation" awsDryRun:"" cfnType:"AWS::EC2::Instance"`
+	logger *logger.Logger
+	graph  cloudgraph.GraphAPI
+	api    ec2iface.EC2API
+	inlineFiles
+	Image          *string   `awsName:"ImageId" awsType:"awsstr" templateName:"image" cfnName:"ImageId" required:""`
 	Count          *int64    `awsName:"MaxCount,MinCount" awsType:"awsin64" templateName:"count" required:""`
-	Type           *string   `awsName:"InstanceType" awsType:"awsstr" templateName:"type" required:""`
-	Name           *string   `templateName:"name" required:""`
-	Subnet         *string   `awsName:"SubnetId" awsType:"awsstr" templateName:"subnet" required:""`
-	Keypair        *string   `awsName:"KeyName" awsType:"awsstr" templateName:"keypair"`
-	PrivateIP      *string   `awsName:"PrivateIpAddress" awsType:"awsstr" templateName:"ip"`
+	Type           *string   `awsName:"InstanceType" awsType:"awsstr" templateName:"type" cfnName:"InstanceType" required:""`
+	Name           *string   `templateName:"name" cfnName:"Tags.Name" required:""`
+	Subnet         *string   `awsName:"SubnetId" awsType:"awsstr" templateName:"subnet" cfnName:"SubnetId" required:""`
+	Keypair        *string   `awsName:"KeyName" awsType:"awsstr" templateName:"keypair" cfnName:"KeyName"`
+	PrivateIP      *string   `awsName:"PrivateIpAddress" awsType:"awsstr" templateName:"ip" cfnName:"PrivateIpAddress"`
 	UserData       *string   `awsName:"UserData" awsType:"awsfiletobase64" templateName:"userdata"`
-	SecurityGroups []*string `awsName:"SecurityGroupIds" awsType:"awsstringslice" templateName:"securitygroup"`
-	Lock           *bool     `awsName:"DisableApiTermination" awsType:"awsbool" templateName:"lock"`
-	Role           *string   `awsName:"IamInstanceProfile.Name" awsType:"awsstr" templateName:"role"`
+	SecurityGroups []*string `awsName:"SecurityGroupIds" awsType:"awsstringslice" templateName:"securitygroup" cfnName:"SecurityGroupIds"`
+	Lock           *bool     `awsName:"DisableApiTermination" awsType:"awsbool" templateName:"lock" cfnName:"DisableApiTermination"`
+	Role           *string   `awsName:"IamInstanceProfile.Name" awsType:"awsstr" templateName:"role" cfnName:"IamInstanceProfile"`
 	DistroQuery    *string   `awsType:"awsstr" templateName:"distro"`
 }
 
@@ -107,6 +108,34 @@ func (cmd *UpdateInstance) ValidateParams(params []string) ([]string, error) {
 	return validateParams(cmd, params)
 }
 
+func (cmd *UpdateInstance) PriorValues(params map[string]interface{}) (map[string]interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, err
+	}
+	prior := make(map[string]interface{})
+	if cmd.Type != nil {
+		out, err := cmd.api.DescribeInstances(&ec2.DescribeInstancesInput{InstanceIds: []*string{cmd.Id}})
+		if err != nil {
+			return nil, err
+		}
+		if len(out.Reservations) == 0 || len(out.Reservations[0].Instances) == 0 {
+			return nil, fmt.Errorf("instance %s not found", StringValue(cmd.Id))
+		}
+		prior["type"] = StringValue(out.Reservations[0].Instances[0].InstanceType)
+	}
+	if cmd.Lock != nil {
+		out, err := cmd.api.DescribeInstanceAttribute(&ec2.DescribeInstanceAttributeInput{InstanceId: cmd.Id, Attribute: awssdk.String("disableApiTermination")})
+		if err != nil {
+			return nil, err
+		}
+		if out.DisableApiTermination == nil {
+			return nil, fmt.Errorf("no lock for instance %s", StringValue(cmd.Id))
+		}
+		prior["lock"] = BoolValue(out.DisableApiTermination.Value)
+	}
+	return prior, nil
+}
+
 type DeleteInstance struct {
 	_      string `action:"delete" entity:"instance" awsAPI:"ec2" awsCall:"TerminateInstances" awsInput:"ec2.TerminateInstancesInput" awsOutput:"ec2.TerminateInstancesOutput" awsDryRun:""`
 	logger *logger.Logger
@@ -22,10 +22,11 @@ import (
 )
 
 type CreateLaunchconfiguration struct {
-	_              string `action:"create" entity:"launchconfiguration" awsAPI:"autoscaling" awsCall:"CreateLaunchConfiguration" awsInput:"autoscaling.CreateLaunchConfigurationInput" awsOutput:"autoscaling.CreateLaunchConfigurationOutput"`
-	logger         *logger.Logger
-	graph          cloudgraph.GraphAPI
-	api            autoscalingiface.AutoScalingAPI
+	_      string `action:"create" entity:"launchconfiguration" awsAPI:"autoscaling" awsCall:"CreateLaunchConfiguration" awsInput:"autoscaling.CreateLaunchConfigurationInput" awsOutput:"autoscaling.CreateLaunchConfigurationOutput"`
+	logger *logger.Logger
+	graph  cloudgraph.GraphAPI
+	api    autoscalingiface.AutoScalingAPI
+	inlineFiles
 	Image          *string   `awsName:"ImageId" awsType:"awsstr" templateName:"image" required:""`
 	Type           *string   `awsName:"InstanceType" awsType:"awsstr" templateName:"type" required:""`
 	Name           *string   `awsName:"LaunchConfigurationName" awsType:"awsstr" templateName:"name" required:""`
@@ -29,17 +29,17 @@ import (
 )
 
 type CreateLoadbalancer struct {
-	_              string `action:"create" entity:"loadbalancer" awsAPI:"elbv2" awsCall:"CreateLoadBalancer" awsInput:"elbv2.CreateLoadBalancerInput" awsOutput:"elbv2.CreateLoadBalancerOutput"`
+	_              string `action:"create" entity:"loadbalancer" awsAPI:"elbv2" awsCall:"CreateLoadBalancer" awsInput:"elbv2.CreateLoadBalancerInput" awsOutput:"elbv2.CreateLoadBalancerOutput" cfnType:"AWS::ElasticLoadBalancingV2::LoadBalancer"`
 	logger         *logger.Logger
 	graph          cloudgraph.GraphAPI
 	api            elbv2iface.ELBV2API
-	Name           *string   `awsName:"Name" awsType:"awsstr" templateName:"name" required:""`
-	Subnets        []*string `awsName:"Subnets" awsType:"awsstringslice" templateName:"subnets" required:""`
+	Name           *string   `awsName:"Name" awsType:"awsstr" templateName:"name" cfnName:"Name" required:""`
+	Subnets        []*string `awsName:"Subnets" awsType:"awsstringslice" templateName:"subnets" cfnName:"Subnets" required:""`
 	SubnetMappings []*string `awsName:"SubnetMappings" awsType:"awssubnetmappings" templateName:"subnet-mappings"`
-	Iptype         *string   `awsName:"IpAddressType" awsType:"awsstr" templateName:"iptype"`
-	Scheme         *string   `awsName:"Scheme" awsType:"awsstr" templateName:"scheme"`
-	Securitygroups []*string `awsName:"SecurityGroups" awsType:"awsstringslice" templateName:"securitygroups"`
-	Type           *string   `awsName:"Type" awsType:"awsstr" templateName:"type"`
+	Iptype         *string   `awsName:"IpAddressType" awsType:"awsstr" templateName:"iptype" cfnName:"IpAddressType"`
+	Scheme         *string   `awsName:"Scheme" awsType:"awsstr" templateName:"scheme" cfnName:"Scheme"`
+	Securitygroups []*string `awsName:"SecurityGroups" awsType:"awsstringslice" templateName:"securitygroups" cfnName:"SecurityGroups"`
+	Type           *string   `awsName:"Type" awsType:"awsstr" templateName:"type" cfnName:"Type"`
 }
 
 func (cmd *CreateLoadbalancer) ValidateParams(params []string) ([]string, error) {
@@ -16,6 +16,9 @@ limitations under the License.
 package awsspec
 
 import (
+	"errors"
+	"fmt"
+
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
@@ -29,7 +32,7 @@ type CreateLoginprofile struct {
 	graph         cloudgraph.GraphAPI
 	api           iamiface.IAMAPI
 	Username      *string `awsName:"UserName" awsType:"awsstr" templateName:"username" required:""`
-	Password      *string `awsName:"Password" awsType:"awsstr" templateName:"password" required:""`
+	Password      *string `awsName:"Password" awsType:"awsstr" templateName:"password" required:"" secret:""`
 	PasswordReset *bool   `awsName:"PasswordResetRequired" awsType:"awsbool" templateName:"password-reset"`
 }
 
@@ -47,7 +50,7 @@ type UpdateLoginprofile struct {
 	graph         cloudgraph.GraphAPI
 	api           iamiface.IAMAPI
 	Username      *string `awsName:"UserName" awsType:"awsstr" templateName:"username" required:""`
-	Password      *string `awsName:"Password" awsType:"awsstr" templateName:"password" required:""`
+	Password      *string `awsName:"Password" awsType:"awsstr" templateName:"password" required:"" secret:""`
 	PasswordReset *bool   `awsName:"PasswordResetRequired" awsType:"awsbool" templateName:"password-reset"`
 }
 
@@ -55,6 +58,25 @@ func (cmd *UpdateLoginprofile) ValidateParams(params []string) ([]string, error)
 	return validateParams(cmd, params)
 }
 
+// PriorValues fetches the previous password reset flag. The previous password cannot be
+// fetched: its secret param is kept in the reverting update, to be prompted again.
+func (cmd *UpdateLoginprofile) PriorValues(params map[string]interface{}) (map[string]interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, err
+	}
+	if cmd.PasswordReset == nil {
+		return nil, errors.New("previous password cannot be fetched")
+	}
+	out, err := cmd.api.GetLoginProfile(&iam.GetLoginProfileInput{UserName: cmd.Username})
+	if err != nil {
+		return nil, err
+	}
+	if out.LoginProfile == nil {
+		return nil, fmt.Errorf("no login profile for user %s", StringValue(cmd.Username))
+	}
+	return map[string]interface{}{"password-reset": BoolValue(out.LoginProfile.PasswordResetRequired)}, nil
+}
+
 type DeleteLoginprofile struct {
 	_        string `action:"delete" entity:"loginprofile" awsAPI:"iam" awsCall:"DeleteLoginProfile" awsInput:"iam.DeleteLoginProfileInput" awsOutput:"iam.DeleteLoginProfileOutput"`
 	logger   *logger.Logger
@@ -29,12 +29,12 @@ import (
 )
 
 type CreateNatgateway struct {
-	_           string `action:"create" entity:"natgateway" awsAPI:"ec2" awsCall:"CreateNatGateway" awsInput:"ec2.CreateNatGatewayInput" awsOutput:"ec2.CreateNatGatewayOutput"`
+	_           string `action:"create" entity:"natgateway" awsAPI:"ec2" awsCall:"CreateNatGateway" awsInput:"ec2.CreateNatGatewayInput" awsOutput:"ec2.CreateNatGatewayOutput" cfnType:"AWS::EC2::NatGateway"`
 	logger      *logger.Logger
 	graph       cloudgraph.GraphAPI
 	api         ec2iface.EC2API
-	ElasticipId *string `awsName:"AllocationId" awsType:"awsstr" templateName:"elasticip-id" required:""`
-	Subnet      *string `awsName:"SubnetId" awsType:"awsstr" templateName:"subnet" required:""`
+	ElasticipId *string `awsName:"AllocationId" awsType:"awsstr" templateName:"elasticip-id" cfnName:"AllocationId" required:""`
+	Subnet      *string `awsName:"SubnetId" awsType:"awsstr" templateName:"subnet" cfnName:"SubnetId" required:""`
 }
 
 func (cmd *CreateNatgateway) ValidateParams(params []string) ([]string, error) {
@@ -37,24 +37,31 @@ import (
 )
 
 type CreatePolicy struct {
-	_           string `action:"create" entity:"policy" awsAPI:"iam" awsCall:"CreatePolicy" awsInput:"iam.CreatePolicyInput" awsOutput:"iam.CreatePolicyOutput"`
-	logger      *logger.Logger
-	graph       cloudgraph.GraphAPI
-	api         iamiface.IAMAPI
+	_      string `action:"create" entity:"policy" awsAPI:"iam" awsCall:"CreatePolicy" awsInput:"iam.CreatePolicyInput" awsOutput:"iam.CreatePolicyOutput"`
+	logger *logger.Logger
+	graph  cloudgraph.GraphAPI
+	api    iamiface.IAMAPI
+	inlineFiles
 	Name        *string   `awsName:"PolicyName" awsType:"awsstr" templateName:"name" required:""`
-	Effect      *string   `templateName:"effect" required:""`
-	Action      []*string `templateName:"action" required:""`
-	Resource    []*string `templateName:"resource" required:""`
+	Effect      *string   `templateName:"effect"`
+	Action      []*string `templateName:"action"`
+	Resource    []*string `templateName:"resource"`
 	Description *string   `awsName:"Description" awsType:"awsstr" templateName:"description"`
-	Document    *string   `awsName:"PolicyDocument" awsType:"awsstr"`
+	Document    *string   `awsName:"PolicyDocument" awsType:"awsfiletostring" templateName:"document"`
 	Conditions  []*string `templateName:"conditions"`
 }
 
 func (cmd *CreatePolicy) ValidateParams(params []string) ([]string, error) {
-	return validateParams(cmd, params)
+	return paramRule{
+		tree:   allOf(node("name"), oneOf(node("document"), allOf(node("effect"), node("action"), node("resource")))),
+		extras: []string{"description", "conditions"},
+	}.verify(params)
 }
 
 func (cmd *CreatePolicy) BeforeRun(ctx map[string]interface{}) error {
+	if cmd.Document != nil {
+		return nil
+	}
 	stat, err := buildStatementFromParams(cmd.Effect, cmd.Resource, cmd.Action, cmd.Conditions)
 	if err != nil {
 		return err
@@ -69,6 +76,7 @@ func (cmd *CreatePolicy) BeforeRun(ctx map[string]interface{}) error {
 		return fmt.Errorf("cannot marshal policy document: %s", err)
 	}
 	cmd.Document = String(string(b))
+	cmd.setInline("Document", true)
 	cmd.logger.ExtraVerbosef("policy document json:\n%s\n", string(b))
 	return nil
 }
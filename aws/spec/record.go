@@ -16,6 +16,8 @@ limitations under the License.
 package awsspec
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/wallix/awless/cloud/graph"
@@ -69,6 +71,35 @@ func (cmd *UpdateRecord) ValidateParams(params []string) ([]string, error) {
 	return validateParams(cmd, params)
 }
 
+func (cmd *UpdateRecord) PriorValues(params map[string]interface{}) (map[string]interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, err
+	}
+	out, err := cmd.api.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    cmd.Zone,
+		StartRecordName: cmd.Name,
+		StartRecordType: cmd.Type,
+		MaxItems:        String("1"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(out.ResourceRecordSets) == 0 {
+		return nil, fmt.Errorf("no %s record %s", StringValue(cmd.Type), StringValue(cmd.Name))
+	}
+	set := out.ResourceRecordSets[0]
+	if strings.TrimSuffix(StringValue(set.Name), ".") != strings.TrimSuffix(StringValue(cmd.Name), ".") || StringValue(set.Type) != StringValue(cmd.Type) {
+		return nil, fmt.Errorf("no %s record %s", StringValue(cmd.Type), StringValue(cmd.Name))
+	}
+	if len(set.ResourceRecords) != 1 {
+		return nil, fmt.Errorf("%s record %s has %d values", StringValue(cmd.Type), StringValue(cmd.Name), len(set.ResourceRecords))
+	}
+	return map[string]interface{}{
+		"value": StringValue(set.ResourceRecords[0].Value),
+		"ttl":   Int64AsIntValue(set.TTL),
+	}, nil
+}
+
 func (cmd *UpdateRecord) ManualRun(ctx map[string]interface{}) (interface{}, error) {
 	start := time.Now()
 	output, err := changeResourceRecordSets(cmd.api, String("UPSERT"), cmd.Zone, cmd.Name, cmd.Type, cmd.Value, nil, cmd.Ttl)
@@ -25,13 +25,13 @@ import (
 )
 
 type CreateRoute struct {
-	_       string `action:"create" entity:"route" awsAPI:"ec2" awsCall:"CreateRoute" awsInput:"ec2.CreateRouteInput" awsOutput:"ec2.CreateRouteOutput" awsDryRun:""`
+	_       string `action:"create" entity:"route" awsAPI:"ec2" awsCall:"CreateRoute" awsInput:"ec2.CreateRouteInput" awsOutput:"ec2.CreateRouteOutput" awsDryRun:"" cfnType:"AWS::EC2::Route"`
 	logger  *logger.Logger
 	graph   cloudgraph.GraphAPI
 	api     ec2iface.EC2API
-	Table   *string `awsName:"RouteTableId" awsType:"awsstr" templateName:"table" required:""`
-	CIDR    *string `awsName:"DestinationCidrBlock" awsType:"awsstr" templateName:"cidr" required:""`
-	Gateway *string `awsName:"GatewayId" awsType:"awsstr" templateName:"gateway" required:""`
+	Table   *string `awsName:"RouteTableId" awsType:"awsstr" templateName:"table" cfnName:"RouteTableId" required:""`
+	CIDR    *string `awsName:"DestinationCidrBlock" awsType:"awsstr" templateName:"cidr" cfnName:"DestinationCidrBlock" required:""`
+	Gateway *string `awsName:"GatewayId" awsType:"awsstr" templateName:"gateway" cfnName:"GatewayId" required:""`
 }
 
 func (cmd *CreateRoute) ValidateParams(params []string) ([]string, error) {
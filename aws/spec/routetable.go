@@ -24,11 +24,11 @@ import (
 )
 
 type CreateRoutetable struct {
-	_      string `action:"create" entity:"routetable" awsAPI:"ec2" awsCall:"CreateRouteTable" awsInput:"ec2.CreateRouteTableInput" awsOutput:"ec2.CreateRouteTableOutput" awsDryRun:""`
+	_      string `action:"create" entity:"routetable" awsAPI:"ec2" awsCall:"CreateRouteTable" awsInput:"ec2.CreateRouteTableInput" awsOutput:"ec2.CreateRouteTableOutput" awsDryRun:"" cfnType:"AWS::EC2::RouteTable"`
 	logger *logger.Logger
 	graph  cloudgraph.GraphAPI
 	api    ec2iface.EC2API
-	Vpc    *string `awsName:"VpcId" awsType:"awsstr" templateName:"vpc" required:""`
+	Vpc    *string `awsName:"VpcId" awsType:"awsstr" templateName:"vpc" cfnName:"VpcId" required:""`
 }
 
 func (cmd *CreateRoutetable) ValidateParams(params []string) ([]string, error) {
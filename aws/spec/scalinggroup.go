@@ -17,6 +17,7 @@ package awsspec
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/wallix/awless/cloud/graph"
@@ -73,6 +74,41 @@ func (cmd *UpdateScalinggroup) ValidateParams(params []string) ([]string, error)
 	return validateParams(cmd, params)
 }
 
+func (cmd *UpdateScalinggroup) PriorValues(params map[string]interface{}) (map[string]interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, err
+	}
+	out, err := cmd.api.DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{AutoScalingGroupNames: []*string{cmd.Name}})
+	if err != nil {
+		return nil, err
+	}
+	if len(out.AutoScalingGroups) == 0 {
+		return nil, fmt.Errorf("scalinggroup %s not found", StringValue(cmd.Name))
+	}
+	current := out.AutoScalingGroups[0]
+	var subnets []string
+	if zoneIdentifier := StringValue(current.VPCZoneIdentifier); zoneIdentifier != "" {
+		subnets = strings.Split(zoneIdentifier, ",")
+	}
+	prior := make(map[string]interface{})
+	for param, val := range map[string]interface{}{
+		"cooldown":                 Int64AsIntValue(current.DefaultCooldown),
+		"desired-capacity":         Int64AsIntValue(current.DesiredCapacity),
+		"healthcheck-grace-period": Int64AsIntValue(current.HealthCheckGracePeriod),
+		"healthcheck-type":         StringValue(current.HealthCheckType),
+		"launchconfiguration":      StringValue(current.LaunchConfigurationName),
+		"max-size":                 Int64AsIntValue(current.MaxSize),
+		"min-size":                 Int64AsIntValue(current.MinSize),
+		"new-instances-protected":  BoolValue(current.NewInstancesProtectedFromScaleIn),
+		"subnets":                  subnets,
+	} {
+		if _, ok := params[param]; ok {
+			prior[param] = val
+		}
+	}
+	return prior, nil
+}
+
 type DeleteScalinggroup struct {
 	_      string `action:"delete" entity:"scalinggroup" awsAPI:"autoscaling" awsCall:"DeleteAutoScalingGroup" awsInput:"autoscaling.DeleteAutoScalingGroupInput" awsOutput:"autoscaling.DeleteAutoScalingGroupOutput"`
 	logger *logger.Logger
@@ -33,13 +33,13 @@ import (
 )
 
 type CreateSecuritygroup struct {
-	_           string `action:"create" entity:"securitygroup" awsAPI:"ec2" awsCall:"CreateSecurityGroup" awsInput:"ec2.CreateSecurityGroupInput" awsOutput:"ec2.CreateSecurityGroupOutput" awsDryRun:""`
+	_           string `action:"create" entity:"securitygroup" awsAPI:"ec2" awsCall:"CreateSecurityGroup" awsInput:"ec2.CreateSecurityGroupInput" awsOutput:"ec2.CreateSecurityGroupOutput" awsDryRun:"" cfnType:"AWS::EC2::SecurityGroup"`
 	logger      *logger.Logger
 	graph       cloudgraph.GraphAPI
 	api         ec2iface.EC2API
-	Name        *string `awsName:"GroupName" awsType:"awsstr" templateName:"name" required:""`
-	Vpc         *string `awsName:"VpcId" awsType:"awsstr" templateName:"vpc" required:""`
-	Description *string `awsName:"Description" awsType:"awsstr" templateName:"description" required:""`
+	Name        *string `awsName:"GroupName" awsType:"awsstr" templateName:"name" cfnName:"GroupName" required:""`
+	Vpc         *string `awsName:"VpcId" awsType:"awsstr" templateName:"vpc" cfnName:"VpcId" required:""`
+	Description *string `awsName:"Description" awsType:"awsstr" templateName:"description" cfnName:"GroupDescription" required:""`
 }
 
 func (cmd *CreateSecuritygroup) ValidateParams(params []string) ([]string, error) {
@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	gotemplate "text/template"
@@ -134,36 +135,33 @@ func setFieldWithType(v, i interface{}, fieldPath string, destType string, inter
 			v = dimensions
 		} else {
 			dimensions = []*cloudwatch.Dimension{}
-			sl := castStringSlice(v)
-			for _, s := range sl {
-				splits := strings.SplitN(s, ":", 2)
-				if len(splits) != 2 {
-					return fmt.Errorf("invalid dimension '%s', expected 'key:value'", s)
-				}
-				dimensions = append(dimensions, &cloudwatch.Dimension{Name: aws.String(splits[0]), Value: aws.String(splits[1])})
+			kvs, kvErr := castKeyValues(v, "dimension")
+			if kvErr != nil {
+				return kvErr
+			}
+			for _, kv := range kvs {
+				dimensions = append(dimensions, &cloudwatch.Dimension{Name: aws.String(kv.key), Value: aws.String(kv.value)})
 				v = dimensions
 			}
 		}
 	case awsecskeyvalue:
-		sl := castStringSlice(v)
+		kvs, kvErr := castKeyValues(v, "keyvalue")
+		if kvErr != nil {
+			return kvErr
+		}
 		var keyvalues []*ecs.KeyValuePair
-		for _, s := range sl {
-			splits := strings.SplitN(s, ":", 2)
-			if len(splits) != 2 {
-				return fmt.Errorf("invalid keyvalue '%s', expected 'key:value'", s)
-			}
-			keyvalues = append(keyvalues, &ecs.KeyValuePair{Name: aws.String(splits[0]), Value: aws.String(splits[1])})
+		for _, kv := range kvs {
+			keyvalues = append(keyvalues, &ecs.KeyValuePair{Name: aws.String(kv.key), Value: aws.String(kv.value)})
 		}
 		v = keyvalues
 	case awsparameterslice:
-		sl := castStringSlice(v)
+		kvs, kvErr := castKeyValues(v, "parameter")
+		if kvErr != nil {
+			return kvErr
+		}
 		var parameters []*cloudformation.Parameter
-		for _, s := range sl {
-			splits := strings.SplitN(s, ":", 2)
-			if len(splits) != 2 {
-				return fmt.Errorf("invalid parameter '%s', expected 'key:value'", s)
-			}
-			parameters = append(parameters, &cloudformation.Parameter{ParameterKey: aws.String(splits[0]), ParameterValue: aws.String(splits[1])})
+		for _, kv := range kvs {
+			parameters = append(parameters, &cloudformation.Parameter{ParameterKey: aws.String(kv.key), ParameterValue: aws.String(kv.value)})
 		}
 		v = parameters
 	case awssubnetmappings:
@@ -262,13 +260,13 @@ func setFieldWithType(v, i interface{}, fieldPath string, destType string, inter
 			return err
 		}
 	case awsfiletobyteslice:
-		v, err = ioutil.ReadFile(castString(v))
+		v, err = fileOrInlineContent(v)
 		if err != nil {
 			return err
 		}
 	case awsfiletostring:
 		var b []byte
-		b, err = ioutil.ReadFile(castString(v))
+		b, err = fileOrInlineContent(v)
 		if err != nil {
 			return err
 		}
@@ -349,14 +347,13 @@ func setFieldWithType(v, i interface{}, fieldPath string, destType string, inter
 
 		return nil
 	case awstagslice:
-		sl := castStringSlice(v)
+		kvs, kvErr := castKeyValues(v, "tag")
+		if kvErr != nil {
+			return kvErr
+		}
 		var tags []*cloudformation.Tag
-		for _, s := range sl {
-			splits := strings.SplitN(s, ":", 2)
-			if len(splits) != 2 {
-				return fmt.Errorf("invalid tag '%s', expected 'key:value'", s)
-			}
-			tags = append(tags, &cloudformation.Tag{Key: aws.String(splits[0]), Value: aws.String(splits[1])})
+		for _, kv := range kvs {
+			tags = append(tags, &cloudformation.Tag{Key: aws.String(kv.key), Value: aws.String(kv.value)})
 		}
 
 		v = tags
@@ -464,8 +461,46 @@ func castInt64(v interface{}) (int64, error) {
 	}
 }
 
+type keyValue struct {
+	key, value string
+}
+
+// castKeyValues returns the pairs of a map, as given with the {key: value} template syntax,
+// sorted by key, or of a list of 'key:value' strings
+func castKeyValues(v interface{}, kind string) ([]keyValue, error) {
+	var kvs []keyValue
+	if m, isMap := v.(map[string]interface{}); isMap {
+		for _, k := range sortedKeys(m) {
+			kvs = append(kvs, keyValue{key: k, value: castString(m[k])})
+		}
+		return kvs, nil
+	}
+	for _, s := range castStringSlice(v) {
+		splits := strings.SplitN(s, ":", 2)
+		if len(splits) != 2 {
+			return nil, fmt.Errorf("invalid %s '%s', expected 'key:value'", kind, s)
+		}
+		kvs = append(kvs, keyValue{key: splits[0], value: splits[1]})
+	}
+	return kvs, nil
+}
+
+func sortedKeys(m map[string]interface{}) (keys []string) {
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
+
 func castStringSlice(v interface{}) []string {
 	switch vv := v.(type) {
+	case map[string]interface{}:
+		var slice []string
+		for _, k := range sortedKeys(vv) {
+			slice = append(slice, k+":"+castString(vv[k]))
+		}
+		return slice
 	case string:
 		return []string{vv}
 	case *string:
@@ -494,6 +529,8 @@ func castStringSlice(v interface{}) []string {
 
 func castStringPointerSlice(v interface{}) []*string {
 	switch vv := v.(type) {
+	case map[string]interface{}:
+		return aws.StringSlice(castStringSlice(vv))
 	case string:
 		return []*string{&vv}
 	case *string:
@@ -522,13 +559,53 @@ func castStringPointerSlice(v interface{}) []*string {
 	}
 }
 
+// inlineContent is implemented by the values of file params given as content
+// rather than as paths, as template heredocs
+type inlineContent interface {
+	InlineContent() string
+}
+
+type inlineString string
+
+func (s inlineString) InlineContent() string {
+	return string(s)
+}
+
+// inlineFiles records the file params of a command given as content, for the struct
+// setter and injector to keep it when the params are set as strings on the command struct.
+// It is embedded in the commands with file params.
+type inlineFiles struct {
+	inline map[string]bool
+}
+
+func (f *inlineFiles) setInline(field string, inline bool) {
+	if f.inline == nil {
+		f.inline = make(map[string]bool)
+	}
+	f.inline[field] = inline
+}
+
+func (f *inlineFiles) isInline(field string) bool {
+	return f.inline[field]
+}
+
+func fileOrInlineContent(v interface{}) ([]byte, error) {
+	if inline, ok := v.(inlineContent); ok {
+		return []byte(inline.InlineContent()), nil
+	}
+	return ioutil.ReadFile(castString(v))
+}
+
 func fileOrRemoteFileAsBase64(v interface{}, tplData interface{}) (string, error) {
 	path := castString(v)
 
 	var readErr error
 	var content []byte
 
-	if strings.HasPrefix(path, "http") {
+	if inline, ok := v.(inlineContent); ok {
+		content = []byte(inline.InlineContent())
+		path = "inline userdata"
+	} else if strings.HasPrefix(path, "http") {
 		client := &http.Client{Timeout: 5 * time.Second}
 
 		logger.ExtraVerbosef("fetching remote userdata at '%s'", path)
@@ -567,6 +644,9 @@ func structSetter(s interface{}, params map[string]interface{}) error {
 	if params == nil {
 		return nil
 	}
+	type I interface {
+		setInline(string, bool)
+	}
 	val := reflect.ValueOf(s).Elem()
 	stru := val.Type()
 
@@ -602,6 +682,10 @@ func structSetter(s interface{}, params map[string]interface{}) error {
 			if err := setFieldWithType(v, s, field.Name, fieldType); err != nil {
 				return fmt.Errorf("%s: %s", tplName, err)
 			}
+			if f, ok := s.(I); ok {
+				_, inline := v.(inlineContent)
+				f.setInline(field.Name, inline)
+			}
 		}
 	}
 	return nil
@@ -623,6 +707,9 @@ func structListParamsKeys(src interface{}) map[string]bool {
 }
 
 func structInjector(src, dest interface{}, ctx map[string]interface{}) error {
+	type I interface {
+		isInline(string) bool
+	}
 	val := reflect.ValueOf(src).Elem()
 	stru := val.Type()
 
@@ -635,7 +722,11 @@ func structInjector(src, dest interface{}, ctx map[string]interface{}) error {
 				if dstType, tok := field.Tag.Lookup("awsType"); tok {
 					fieldValue := val.Field(i)
 					if fieldValue.IsValid() && fieldValue.Interface() != nil && !fieldValue.IsNil() {
-						if err := setFieldWithType(fieldValue.Interface(), dest, destName, dstType, ctx); err != nil {
+						v := fieldValue.Interface()
+						if f, ok := src.(I); ok && f.isInline(field.Name) {
+							v = inlineString(castString(v))
+						}
+						if err := setFieldWithType(v, dest, destName, dstType, ctx); err != nil {
 							fieldName := field.Name
 							if tplName, ok := field.Tag.Lookup("templateName"); ok {
 								fieldName = tplName
@@ -108,6 +108,26 @@ func TestSetFieldWithTypeAWSFile(t *testing.T) {
 	if got, want := awssdk.StringValue(stackInput.TemplateBody), string(text); got != want {
 		t.Fatalf("got %s, want %s", got, want)
 	}
+
+	inline := "AWSTemplateFormatVersion: 2010-09-09\nResources: {}\n"
+	err = setFieldWithType(inlineString(inline), stackInput, "TemplateBody", awsfiletostring)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := awssdk.StringValue(stackInput.TemplateBody), inline; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	oneline := `{"Resources": {}}`
+	err = setFieldWithType(inlineString(oneline), stackInput, "TemplateBody", awsfiletostring)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := awssdk.StringValue(stackInput.TemplateBody), oneline; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
 }
 
 func TestSetFieldsOnAwsStruct(t *testing.T) {
@@ -517,6 +537,29 @@ func TestSetFieldWithMultiType(t *testing.T) {
 		t.Fatalf("got %s, want %s", got, want)
 	}
 
+	err = setFieldWithType(map[string]interface{}{"Team": "core infra", "Env": "prod", "Port": 8080}, &any, "ParameterList", awsparameterslice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expParams := []*cloudformation.Parameter{
+		{ParameterKey: awssdk.String("Env"), ParameterValue: awssdk.String("prod")},
+		{ParameterKey: awssdk.String("Port"), ParameterValue: awssdk.String("8080")},
+		{ParameterKey: awssdk.String("Team"), ParameterValue: awssdk.String("core infra")},
+	}
+	if got, want := any.ParameterList, expParams; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	err = setFieldWithType(map[string]interface{}{"User": "jdoe"}, &any, "KeyValueSliceField", awsecskeyvalue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := any.KeyValueSliceField, []*ecs.KeyValuePair{{Name: awssdk.String("User"), Value: awssdk.String("jdoe")}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := castStringSlice(map[string]interface{}{"b": "2", "a": "x:y"}), []string{"a:x:y", "b:2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
 	err = setFieldWithType([]string{"80:8080", "8082", "1234:8083/udp"}, &any, "PortMappings", awsportmappings)
 	if err != nil {
 		t.Fatal(err)
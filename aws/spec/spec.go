@@ -30,6 +30,13 @@ type AfterRunner interface {
 	AfterRun(ctx map[string]interface{}, output interface{}) error
 }
 
+// PriorValuesFetcher is implemented by the update commands able to fetch, before running,
+// the current values of the params they change, for their template execution to be reverted.
+// A nil value removes a param from the reverting update.
+type PriorValuesFetcher interface {
+	PriorValues(params map[string]interface{}) (map[string]interface{}, error)
+}
+
 type ResultExtractor interface {
 	ExtractResult(interface{}) string
 }
@@ -371,6 +378,8 @@ type checker struct {
 	logger      *logger.Logger
 	graph       cloudgraph.GraphAPI
 	checkName   string
+	// when set, frequency doubles after each retry up to maxFrequency
+	maxFrequency time.Duration
 }
 
 func (c *checker) check() error {
@@ -398,6 +407,12 @@ func (c *checker) check() error {
 		elapsed := time.Since(now)
 		c.logger.InteractiveInfof("%s %s '%s', expect '%s', timeout in %s (retry in %s)", c.description, c.checkName, got, c.expect, color.New(color.FgGreen).Sprint(c.timeout-elapsed.Round(time.Second)), c.frequency)
 		time.Sleep(c.frequency)
+		if c.maxFrequency > c.frequency {
+			c.frequency *= 2
+			if c.frequency > c.maxFrequency {
+				c.frequency = c.maxFrequency
+			}
+		}
 	}
 }
 
@@ -2,6 +2,7 @@ package awsspec
 
 import (
 	"reflect"
+	"sort"
 	"strings"
 	"testing"
 )
@@ -183,3 +184,50 @@ func convertParamsIfAvailable(cmd interface{}, params map[string]interface{}) (m
 	}
 	return params, nil
 }
+
+func TestCloudFormationResourceOf(t *testing.T) {
+	res, ok := CloudFormationResourceOf(&CreateSubnet{})
+	if !ok {
+		t.Fatal("expected cloudformation mapping")
+	}
+	if got, want := res.Type, "AWS::EC2::Subnet"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	exp := map[string]string{"cidr": "CidrBlock", "vpc": "VpcId", "availabilityzone": "AvailabilityZone", "public": "MapPublicIpOnLaunch", "name": "Tags.Name"}
+	if got, want := res.Properties, exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if res, _ = CloudFormationResourceOf(&CreateElasticip{}); res.Result != "AllocationId" {
+		t.Fatalf("got result %q, want AllocationId", res.Result)
+	}
+	if _, ok = CloudFormationResourceOf(&DeleteSubnet{}); ok {
+		t.Fatal("expected no cloudformation mapping")
+	}
+}
+
+func TestSecretParamsMatchTags(t *testing.T) {
+	type S interface {
+		SecretParams() []string
+	}
+	for key := range AWSTemplatesDefinitions {
+		cmd := MockAWSSessionFactory.Build(key)()
+		var tagged, got []string
+		typ := reflect.TypeOf(cmd).Elem()
+		for i := 0; i < typ.NumField(); i++ {
+			if _, ok := typ.Field(i).Tag.Lookup("secret"); ok {
+				tagged = append(tagged, typ.Field(i).Tag.Get("templateName"))
+			}
+		}
+		if s, ok := cmd.(S); ok {
+			got = s.SecretParams()
+		}
+		sort.Strings(tagged)
+		if !reflect.DeepEqual(got, tagged) {
+			t.Fatalf("%s: got secret params %v, want %v (regenerate with go generate)", key, got, tagged)
+		}
+	}
+	if _, ok := interface{}(&CreateDatabase{}).(S); !ok {
+		t.Fatal("expected database password to be secret")
+	}
+}
@@ -34,10 +34,11 @@ import (
 )
 
 type CreateStack struct {
-	_               string `action:"create" entity:"stack" awsAPI:"cloudformation" awsCall:"CreateStack" awsInput:"cloudformation.CreateStackInput" awsOutput:"cloudformation.CreateStackOutput"`
-	logger          *logger.Logger
-	graph           cloudgraph.GraphAPI
-	api             cloudformationiface.CloudFormationAPI
+	_      string `action:"create" entity:"stack" awsAPI:"cloudformation" awsCall:"CreateStack" awsInput:"cloudformation.CreateStackInput" awsOutput:"cloudformation.CreateStackOutput"`
+	logger *logger.Logger
+	graph  cloudgraph.GraphAPI
+	api    cloudformationiface.CloudFormationAPI
+	inlineFiles
 	Name            *string   `awsName:"StackName" awsType:"awsstr" templateName:"name" required:""`
 	TemplateFile    *string   `awsName:"TemplateBody" awsType:"awsfiletostring" templateName:"template-file" required:""`
 	Capabilities    []*string `awsName:"Capabilities" awsType:"awsstringslice" templateName:"capabilities"`
@@ -59,6 +60,9 @@ func (cmd *CreateStack) ValidateParams(params []string) ([]string, error) {
 }
 
 func (cmd *CreateStack) Validate_TemplateFile() error {
+	if cmd.isInline("TemplateFile") {
+		return nil
+	}
 	if _, err := os.Stat(StringValue(cmd.TemplateFile)); err != nil {
 		return errors.New(strings.TrimLeft(err.Error(), "stat "))
 	}
@@ -79,10 +83,11 @@ func (cmd *CreateStack) BeforeRun(ctx map[string]interface{}) error {
 }
 
 type UpdateStack struct {
-	_                   string `action:"update" entity:"stack" awsAPI:"cloudformation" awsCall:"UpdateStack" awsInput:"cloudformation.UpdateStackInput" awsOutput:"cloudformation.UpdateStackOutput"`
-	logger              *logger.Logger
-	graph               cloudgraph.GraphAPI
-	api                 cloudformationiface.CloudFormationAPI
+	_      string `action:"update" entity:"stack" awsAPI:"cloudformation" awsCall:"UpdateStack" awsInput:"cloudformation.UpdateStackInput" awsOutput:"cloudformation.UpdateStackOutput"`
+	logger *logger.Logger
+	graph  cloudgraph.GraphAPI
+	api    cloudformationiface.CloudFormationAPI
+	inlineFiles
 	Name                *string   `awsName:"StackName" awsType:"awsstr" templateName:"name" required:""`
 	Capabilities        []*string `awsName:"Capabilities" awsType:"awsstringslice" templateName:"capabilities"`
 	Notifications       []*string `awsName:"NotificationARNs" awsType:"awsstringslice" templateName:"notifications"`
@@ -102,6 +107,61 @@ func (cmd *UpdateStack) ValidateParams(params []string) ([]string, error) {
 	return validateParams(cmd, params)
 }
 
+// PriorValues fetches the previous parameters, tags, notifications, role and capabilities of a stack.
+// The previous template and stack policy cannot be restored from files: updating them is not revertible.
+func (cmd *UpdateStack) PriorValues(params map[string]interface{}) (map[string]interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, err
+	}
+	for _, param := range []string{"template-file", "policy-file"} {
+		if _, ok := params[param]; ok {
+			return nil, fmt.Errorf("previous %s cannot be restored", param)
+		}
+	}
+	out, err := cmd.api.DescribeStacks(&cloudformation.DescribeStacksInput{StackName: cmd.Name})
+	if err != nil {
+		return nil, err
+	}
+	if len(out.Stacks) == 0 {
+		return nil, fmt.Errorf("stack %s not found", StringValue(cmd.Name))
+	}
+	current := out.Stacks[0]
+	parameters := make(map[string]interface{})
+	for _, p := range current.Parameters {
+		parameters[StringValue(p.ParameterKey)] = StringValue(p.ParameterValue)
+	}
+	tags := make(map[string]interface{})
+	for _, t := range current.Tags {
+		tags[StringValue(t.Key)] = StringValue(t.Value)
+	}
+	var notifications, capabilities []string
+	for _, n := range current.NotificationARNs {
+		notifications = append(notifications, StringValue(n))
+	}
+	for _, c := range current.Capabilities {
+		capabilities = append(capabilities, StringValue(c))
+	}
+
+	prior := make(map[string]interface{})
+	for param, val := range map[string]interface{}{
+		"parameters":    parameters,
+		"tags":          tags,
+		"notifications": notifications,
+		"capabilities":  capabilities,
+		"role":          StringValue(current.RoleARN),
+	} {
+		if _, ok := params[param]; ok {
+			prior[param] = val
+		}
+	}
+	if _, ok := params["stack-file"]; ok { // parameters and tags of the stack file are restored as params
+		prior["stack-file"] = nil
+		prior["parameters"] = parameters
+		prior["tags"] = tags
+	}
+	return prior, nil
+}
+
 func (cmd *UpdateStack) ExtractResult(i interface{}) string {
 	return StringValue(i.(*cloudformation.UpdateStackOutput).StackId)
 }
@@ -188,15 +248,20 @@ func readStackFile(p string) (sf *stackFile, err error) {
 // supplied with CLI and StackFile with higher priority for values passed via CLI
 // example:
 // via cli passed next parameters:
-//   Test1=a
-//   Test2=b
+//
+//	Test1=a
+//	Test2=b
+//
 // via StackFile passed next parameters:
-//   Test2=x
-//   Test3=y
+//
+//	Test2=x
+//	Test3=y
+//
 // after merge result will be:
-//   Test1=a
-//   Test2=b
-//   Test3=y
+//
+//	Test1=a
+//	Test2=b
+//	Test3=y
 func mergeCliAndFileValues(valMap map[string]string, valSlice []*string) (resSlice []*string) {
 	// if values map are absent in StackFile
 	// just return slice of CLI values
@@ -16,6 +16,7 @@ limitations under the License.
 package awsspec
 
 import (
+	"fmt"
 	"net"
 
 	"github.com/wallix/awless/cloud/graph"
@@ -27,15 +28,15 @@ import (
 )
 
 type CreateSubnet struct {
-	_                string `action:"create" entity:"subnet" awsAPI:"ec2" awsCall:"CreateSubnet" awsInput:"ec2.CreateSubnetInput" awsOutput:"ec2.CreateSubnetOutput" awsDryRun:""`
+	_                string `action:"create" entity:"subnet" awsAPI:"ec2" awsCall:"CreateSubnet" awsInput:"ec2.CreateSubnetInput" awsOutput:"ec2.CreateSubnetOutput" awsDryRun:"" cfnType:"AWS::EC2::Subnet"`
 	logger           *logger.Logger
 	graph            cloudgraph.GraphAPI
 	api              ec2iface.EC2API
-	CIDR             *string `awsName:"CidrBlock" awsType:"awsstr" templateName:"cidr" required:""`
-	VPC              *string `awsName:"VpcId" awsType:"awsstr" templateName:"vpc" required:""`
-	AvailabilityZone *string `awsName:"AvailabilityZone" awsType:"awsstr" templateName:"availabilityzone"`
-	Public           *bool   `awsType:"awsboolattribute" templateName:"public"`
-	Name             *string `templateName:"name"`
+	CIDR             *string `awsName:"CidrBlock" awsType:"awsstr" templateName:"cidr" cfnName:"CidrBlock" required:""`
+	VPC              *string `awsName:"VpcId" awsType:"awsstr" templateName:"vpc" cfnName:"VpcId" required:""`
+	AvailabilityZone *string `awsName:"AvailabilityZone" awsType:"awsstr" templateName:"availabilityzone" cfnName:"AvailabilityZone"`
+	Public           *bool   `awsType:"awsboolattribute" templateName:"public" cfnName:"MapPublicIpOnLaunch"`
+	Name             *string `templateName:"name" cfnName:"Tags.Name"`
 }
 
 func (cmd *CreateSubnet) ValidateParams(params []string) ([]string, error) {
@@ -82,6 +83,24 @@ func (cmd *UpdateSubnet) ValidateParams(params []string) ([]string, error) {
 	return validateParams(cmd, params)
 }
 
+func (cmd *UpdateSubnet) PriorValues(params map[string]interface{}) (map[string]interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, err
+	}
+	prior := make(map[string]interface{})
+	if cmd.Public != nil {
+		out, err := cmd.api.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: []*string{cmd.Id}})
+		if err != nil {
+			return nil, err
+		}
+		if len(out.Subnets) == 0 {
+			return nil, fmt.Errorf("subnet %s not found", StringValue(cmd.Id))
+		}
+		prior["public"] = BoolValue(out.Subnets[0].MapPublicIpOnLaunch)
+	}
+	return prior, nil
+}
+
 type DeleteSubnet struct {
 	_      string `action:"delete" entity:"subnet" awsAPI:"ec2" awsCall:"DeleteSubnet" awsInput:"ec2.DeleteSubnetInput" awsOutput:"ec2.DeleteSubnetOutput" awsDryRun:""`
 	logger *logger.Logger
@@ -15,6 +15,7 @@ limitations under the License.
 package awsspec
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/wallix/awless/cloud/graph"
@@ -26,22 +27,22 @@ import (
 )
 
 type CreateTargetgroup struct {
-	_                   string `action:"create" entity:"targetgroup" awsAPI:"elbv2" awsCall:"CreateTargetGroup" awsInput:"elbv2.CreateTargetGroupInput" awsOutput:"elbv2.CreateTargetGroupOutput"`
+	_                   string `action:"create" entity:"targetgroup" awsAPI:"elbv2" awsCall:"CreateTargetGroup" awsInput:"elbv2.CreateTargetGroupInput" awsOutput:"elbv2.CreateTargetGroupOutput" cfnType:"AWS::ElasticLoadBalancingV2::TargetGroup"`
 	logger              *logger.Logger
 	graph               cloudgraph.GraphAPI
 	api                 elbv2iface.ELBV2API
-	Name                *string `awsName:"Name" awsType:"awsstr" templateName:"name" required:""`
-	Port                *int64  `awsName:"Port" awsType:"awsint64" templateName:"port" required:""`
-	Protocol            *string `awsName:"Protocol" awsType:"awsstr" templateName:"protocol" required:""`
-	Vpc                 *string `awsName:"VpcId" awsType:"awsstr" templateName:"vpc" required:""`
-	Healthcheckinterval *int64  `awsName:"HealthCheckIntervalSeconds" awsType:"awsint64" templateName:"healthcheckinterval"`
-	Healthcheckpath     *string `awsName:"HealthCheckPath" awsType:"awsstr" templateName:"healthcheckpath"`
-	Healthcheckport     *string `awsName:"HealthCheckPort" awsType:"awsstr" templateName:"healthcheckport"`
-	Healthcheckprotocol *string `awsName:"HealthCheckProtocol" awsType:"awsstr" templateName:"healthcheckprotocol"`
-	Healthchecktimeout  *int64  `awsName:"HealthCheckTimeoutSeconds" awsType:"awsint64" templateName:"healthchecktimeout"`
-	Healthythreshold    *int64  `awsName:"HealthyThresholdCount" awsType:"awsint64" templateName:"healthythreshold"`
-	Unhealthythreshold  *int64  `awsName:"UnhealthyThresholdCount" awsType:"awsint64" templateName:"unhealthythreshold"`
-	Matcher             *string `awsName:"Matcher.HttpCode" awsType:"awsstr" templateName:"matcher"`
+	Name                *string `awsName:"Name" awsType:"awsstr" templateName:"name" cfnName:"Name" required:""`
+	Port                *int64  `awsName:"Port" awsType:"awsint64" templateName:"port" cfnName:"Port" required:""`
+	Protocol            *string `awsName:"Protocol" awsType:"awsstr" templateName:"protocol" cfnName:"Protocol" required:""`
+	Vpc                 *string `awsName:"VpcId" awsType:"awsstr" templateName:"vpc" cfnName:"VpcId" required:""`
+	Healthcheckinterval *int64  `awsName:"HealthCheckIntervalSeconds" awsType:"awsint64" templateName:"healthcheckinterval" cfnName:"HealthCheckIntervalSeconds"`
+	Healthcheckpath     *string `awsName:"HealthCheckPath" awsType:"awsstr" templateName:"healthcheckpath" cfnName:"HealthCheckPath"`
+	Healthcheckport     *string `awsName:"HealthCheckPort" awsType:"awsstr" templateName:"healthcheckport" cfnName:"HealthCheckPort"`
+	Healthcheckprotocol *string `awsName:"HealthCheckProtocol" awsType:"awsstr" templateName:"healthcheckprotocol" cfnName:"HealthCheckProtocol"`
+	Healthchecktimeout  *int64  `awsName:"HealthCheckTimeoutSeconds" awsType:"awsint64" templateName:"healthchecktimeout" cfnName:"HealthCheckTimeoutSeconds"`
+	Healthythreshold    *int64  `awsName:"HealthyThresholdCount" awsType:"awsint64" templateName:"healthythreshold" cfnName:"HealthyThresholdCount"`
+	Unhealthythreshold  *int64  `awsName:"UnhealthyThresholdCount" awsType:"awsint64" templateName:"unhealthythreshold" cfnName:"UnhealthyThresholdCount"`
+	Matcher             *string `awsName:"Matcher.HttpCode" awsType:"awsstr" templateName:"matcher" cfnName:"Matcher.HttpCode"`
 }
 
 func (cmd *CreateTargetgroup) ValidateParams(params []string) ([]string, error) {
@@ -75,6 +76,71 @@ func (cmd *UpdateTargetgroup) ValidateParams(params []string) ([]string, error)
 	return validateParams(cmd, params)
 }
 
+var targetgroupAttributeParams = map[string]string{
+	"stickiness":          "stickiness.enabled",
+	"stickinessduration":  "stickiness.lb_cookie.duration_seconds",
+	"deregistrationdelay": "deregistration_delay.timeout_seconds",
+}
+
+func (tg *UpdateTargetgroup) PriorValues(params map[string]interface{}) (map[string]interface{}, error) {
+	if err := tg.inject(params); err != nil {
+		return nil, err
+	}
+	prior := make(map[string]interface{})
+	var attributesUpdated, targetgroupUpdated bool
+	for param := range params {
+		if _, isAttr := targetgroupAttributeParams[param]; isAttr {
+			attributesUpdated = true
+		} else if param != "id" {
+			targetgroupUpdated = true
+		}
+	}
+	if attributesUpdated {
+		out, err := tg.api.DescribeTargetGroupAttributes(&elbv2.DescribeTargetGroupAttributesInput{TargetGroupArn: tg.Id})
+		if err != nil {
+			return nil, err
+		}
+		attrs := make(map[string]string)
+		for _, attr := range out.Attributes {
+			attrs[StringValue(attr.Key)] = StringValue(attr.Value)
+		}
+		for param, key := range targetgroupAttributeParams {
+			if _, ok := params[param]; ok {
+				prior[param] = attrs[key]
+			}
+		}
+	}
+	if targetgroupUpdated {
+		out, err := tg.api.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{TargetGroupArns: []*string{tg.Id}})
+		if err != nil {
+			return nil, err
+		}
+		if len(out.TargetGroups) == 0 {
+			return nil, fmt.Errorf("targetgroup %s not found", StringValue(tg.Id))
+		}
+		current := out.TargetGroups[0]
+		var matcher *string
+		if current.Matcher != nil {
+			matcher = current.Matcher.HttpCode
+		}
+		for param, val := range map[string]interface{}{
+			"healthcheckinterval": awssdk.Int64Value(current.HealthCheckIntervalSeconds),
+			"healthcheckpath":     StringValue(current.HealthCheckPath),
+			"healthcheckport":     StringValue(current.HealthCheckPort),
+			"healthcheckprotocol": StringValue(current.HealthCheckProtocol),
+			"healthchecktimeout":  awssdk.Int64Value(current.HealthCheckTimeoutSeconds),
+			"healthythreshold":    awssdk.Int64Value(current.HealthyThresholdCount),
+			"unhealthythreshold":  awssdk.Int64Value(current.UnhealthyThresholdCount),
+			"matcher":             StringValue(matcher),
+		} {
+			if _, ok := params[param]; ok {
+				prior[param] = val
+			}
+		}
+	}
+	return prior, nil
+}
+
 func (tg *UpdateTargetgroup) ManualRun(ctx map[string]interface{}) (interface{}, error) {
 	tgArn := StringValue(tg.Id)
 
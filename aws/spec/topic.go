@@ -24,11 +24,11 @@ import (
 )
 
 type CreateTopic struct {
-	_      string `action:"create" entity:"topic" awsAPI:"sns" awsCall:"CreateTopic" awsInput:"sns.CreateTopicInput" awsOutput:"sns.CreateTopicOutput"`
+	_      string `action:"create" entity:"topic" awsAPI:"sns" awsCall:"CreateTopic" awsInput:"sns.CreateTopicInput" awsOutput:"sns.CreateTopicOutput" cfnType:"AWS::SNS::Topic"`
 	logger *logger.Logger
 	graph  cloudgraph.GraphAPI
 	api    snsiface.SNSAPI
-	Name   *string `awsName:"Name" awsType:"awsstr" templateName:"name" required:""`
+	Name   *string `awsName:"Name" awsType:"awsstr" templateName:"name" cfnName:"TopicName" required:""`
 }
 
 func (cmd *CreateTopic) ValidateParams(params []string) ([]string, error) {
@@ -28,12 +28,12 @@ import (
 )
 
 type CreateVolume struct {
-	_                string `action:"create" entity:"volume" awsAPI:"ec2" awsCall:"CreateVolume" awsInput:"ec2.CreateVolumeInput" awsOutput:"ec2.Volume" awsDryRun:""`
+	_                string `action:"create" entity:"volume" awsAPI:"ec2" awsCall:"CreateVolume" awsInput:"ec2.CreateVolumeInput" awsOutput:"ec2.Volume" awsDryRun:"" cfnType:"AWS::EC2::Volume"`
 	logger           *logger.Logger
 	graph            cloudgraph.GraphAPI
 	api              ec2iface.EC2API
-	Availabilityzone *string `awsName:"AvailabilityZone" awsType:"awsstr" templateName:"availabilityzone" required:""`
-	Size             *int64  `awsName:"Size" awsType:"awsint64" templateName:"size" required:""`
+	Availabilityzone *string `awsName:"AvailabilityZone" awsType:"awsstr" templateName:"availabilityzone" cfnName:"AvailabilityZone" required:""`
+	Size             *int64  `awsName:"Size" awsType:"awsint64" templateName:"size" cfnName:"Size" required:""`
 }
 
 func (cmd *CreateVolume) ValidateParams(params []string) ([]string, error) {
@@ -27,12 +27,12 @@ import (
 )
 
 type CreateVpc struct {
-	_      string `action:"create" entity:"vpc" awsAPI:"ec2" awsCall:"CreateVpc" awsInput:"ec2.CreateVpcInput" awsOutput:"ec2.CreateVpcOutput" awsDryRun:""`
+	_      string `action:"create" entity:"vpc" awsAPI:"ec2" awsCall:"CreateVpc" awsInput:"ec2.CreateVpcInput" awsOutput:"ec2.CreateVpcOutput" awsDryRun:"" cfnType:"AWS::EC2::VPC"`
 	logger *logger.Logger
 	graph  cloudgraph.GraphAPI
 	api    ec2iface.EC2API
-	CIDR   *string `awsName:"CidrBlock" awsType:"awsstr" templateName:"cidr" required:""`
-	Name   *string `awsName:"Name" templateName:"name"`
+	CIDR   *string `awsName:"CidrBlock" awsType:"awsstr" templateName:"cidr" cfnName:"CidrBlock" required:""`
+	Name   *string `awsName:"Name" templateName:"name" cfnName:"Tags.Name"`
 }
 
 func (cmd *CreateVpc) ValidateParams(params []string) ([]string, error) {
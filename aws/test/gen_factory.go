@@ -15,7 +15,7 @@ limitations under the License.
 
 // DO NOT EDIT
 // This file was automatically generated with go generate
-package awsat
+package awstest
 
 import (
 	"github.com/aws/aws-sdk-go/service/acm/acmiface"
@@ -40,21 +40,21 @@ import (
 	"github.com/wallix/awless/logger"
 )
 
-type AcceptanceFactory struct {
+type Factory struct {
 	Mock   interface{}
 	Logger *logger.Logger
 	Graph  cloudgraph.GraphAPI
 }
 
-func NewAcceptanceFactory(mock interface{}, g cloudgraph.GraphAPI, l ...*logger.Logger) *AcceptanceFactory {
+func NewFactory(mock interface{}, g cloudgraph.GraphAPI, l ...*logger.Logger) *Factory {
 	logger := logger.DiscardLogger
 	if len(l) > 0 {
 		logger = l[0]
 	}
-	return &AcceptanceFactory{Mock: mock, Graph: g, Logger: logger}
+	return &Factory{Mock: mock, Graph: g, Logger: logger}
 }
 
-func (f *AcceptanceFactory) Build(key string) func() interface{} {
+func (f *Factory) Build(key string) func() interface{} {
 	switch key {
 	case "attachalarm":
 		return func() interface{} {
@@ -1,18 +1,24 @@
-package awsat
+package awstest
 
 import (
 	"reflect"
-	"testing"
 )
 
-type mock interface {
+// Mock is implemented by the generated mocks of the AWS APIs, recording their calls
+type Mock interface {
 	Calls() map[string]int
 	SetInputs(map[string]interface{})
-	SetTesting(*testing.T)
+	SetTesting(TestingT)
+}
+
+// TestingT is the part of *testing.T used by mocks to report unexpected calls
+type TestingT interface {
+	Helper()
+	Fatalf(format string, args ...interface{})
 }
 
 type basicMock struct {
-	t         *testing.T
+	t         TestingT
 	calls     map[string]int
 	expInputs map[string]interface{}
 }
@@ -28,7 +34,7 @@ func (m *basicMock) Calls() map[string]int {
 	return m.calls
 }
 
-func (m *basicMock) SetTesting(t *testing.T) {
+func (m *basicMock) SetTesting(t TestingT) {
 	m.t = t
 }
 
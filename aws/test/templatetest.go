@@ -0,0 +1,384 @@
+package awstest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// TemplateTest runs a template offline against mocked AWS APIs, as described
+// in a *.test.yml file:
+//
+//	template: create_vpc.aws # defaults to the .aws file with the same name
+//	fillers:
+//	  vpc.cidr: 10.0.0.0/16
+//	graph: fixtures.triples  # local graph used to resolve aliases
+//	resources:               # resources added to the local graph
+//	  - {type: subnet, id: subnet-1, properties: {Name: my-subnet}}
+//	mocks:
+//	  ec2:
+//	    CreateVpc:
+//	      input: {CidrBlock: 10.0.0.0/16}
+//	      output: {Vpc: {VpcId: vpc-1}}
+//	    CreateTagsRequest:
+//	      error: tagging failed
+//	expect:
+//	  calls: [CreateVpc, CreateTagsRequest]
+//	  results: [vpc-1]
+type TemplateTest struct {
+	Path          string
+	Template      string
+	TemplatePath  string
+	Fillers       map[string]interface{}
+	Graph         *graph.Graph
+	Mocks         map[string]map[string]*MockedCall
+	ExpectCalls   []string
+	ExpectResults []string
+}
+
+// MockedCall is the expected input, and the output or error returned by a call to an AWS API
+type MockedCall struct {
+	Input  interface{} `yaml:"input"`
+	Output interface{} `yaml:"output"`
+	Error  string      `yaml:"error"`
+}
+
+type templateTestFile struct {
+	Template  string                            `yaml:"template"`
+	Fillers   map[string]interface{}            `yaml:"fillers"`
+	Graph     string                            `yaml:"graph"`
+	Resources []fixtureResource                 `yaml:"resources"`
+	Mocks     map[string]map[string]*MockedCall `yaml:"mocks"`
+	Expect    struct {
+		Calls   []string `yaml:"calls"`
+		Results []string `yaml:"results"`
+	} `yaml:"expect"`
+}
+
+type fixtureResource struct {
+	Type       string                 `yaml:"type"`
+	ID         string                 `yaml:"id"`
+	Properties map[string]interface{} `yaml:"properties"`
+}
+
+const TemplateTestSuffix = ".test.yml"
+
+// LoadTemplateTest reads a *.test.yml file, with its template and fixture graph
+func LoadTemplateTest(path string) (*TemplateTest, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file templateTestFile
+	if err = yaml.Unmarshal(b, &file); err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(path)
+	tplPath := file.Template
+	if tplPath == "" {
+		tplPath = strings.TrimSuffix(filepath.Base(path), TemplateTestSuffix) + ".aws"
+	}
+	tplPath = filepath.Join(dir, tplPath)
+	tplText, err := ioutil.ReadFile(tplPath)
+	if err != nil {
+		return nil, fmt.Errorf("template: %s", err)
+	}
+
+	test := &TemplateTest{
+		Path:          path,
+		Template:      string(tplText),
+		TemplatePath:  tplPath,
+		Fillers:       file.Fillers,
+		Graph:         graph.NewGraph(),
+		Mocks:         file.Mocks,
+		ExpectCalls:   file.Expect.Calls,
+		ExpectResults: file.Expect.Results,
+	}
+	if file.Graph != "" {
+		if test.Graph, err = graph.NewGraphFromFile(filepath.Join(dir, file.Graph)); err != nil {
+			return nil, fmt.Errorf("graph: %s", err)
+		}
+	}
+	for _, r := range file.Resources {
+		res := graph.InitResource(r.Type, r.ID)
+		for k, v := range r.Properties {
+			res.Properties()[k] = v
+		}
+		if err = test.Graph.AddResource(res); err != nil {
+			return nil, fmt.Errorf("resources: %s", err)
+		}
+	}
+	return test, nil
+}
+
+// Run compiles and runs the template against the mocks, returning an error on
+// any failed command, unexpected call or input, or unmet expectation
+func (tt *TemplateTest) Run(aliasFunc func(g *graph.Graph, entity, key, alias string) string) (err error) {
+	report := &testReport{}
+	defer func() {
+		if r := recover(); r != nil {
+			if failure, ok := r.(testFailure); ok {
+				err = errors.New(string(failure))
+			} else {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}
+	}()
+
+	mocks := make(map[string]Mock)
+	for api, newMock := range mocksByAPI {
+		mocks[api] = newMock()
+		mocks[api].SetTesting(report)
+		if err = setMockedCalls(api, mocks[api], tt.Mocks[api], report); err != nil {
+			return err
+		}
+	}
+	for api := range tt.Mocks {
+		if _, ok := mocks[api]; !ok {
+			return fmt.Errorf("unknown api '%s' in mocks", api)
+		}
+	}
+	defer func(factory awsspec.Factory) { awsspec.CommandFactory = factory }(awsspec.CommandFactory)
+	awsspec.CommandFactory = &templateTestFactory{mocks: mocks, graph: tt.Graph}
+
+	tpl, err := template.ParseFile(tt.TemplatePath, tt.Template)
+	if err != nil {
+		return err
+	}
+	env := template.NewEnv()
+	env.Log = logger.DiscardLogger
+	env.AddFillers(tt.Fillers)
+	env.Lookuper = func(tokens ...string) interface{} {
+		build := awsspec.CommandFactory.Build(strings.Join(tokens, ""))
+		if build == nil {
+			return nil
+		}
+		return build()
+	}
+	if aliasFunc != nil {
+		env.AliasFunc = func(entity, key, alias string) string { return aliasFunc(tt.Graph, entity, key, alias) }
+	}
+	compiled, env, err := template.Compile(tpl, env, template.NewRunnerCompileMode)
+	if err != nil {
+		return err
+	}
+
+	ran, err := compiled.Run(env)
+	if err != nil {
+		return err
+	}
+	var results []string
+	for _, cmd := range ran.CommandNodesIterator() {
+		if cmd.Err() != nil {
+			return fmt.Errorf("%s %s: %s", cmd.Action, cmd.Entity, cmd.Err())
+		}
+		if cmd.Result() != nil {
+			results = append(results, fmt.Sprint(cmd.Result()))
+		}
+	}
+
+	if len(tt.ExpectCalls) > 0 {
+		got, want := make(map[string]int), make(map[string]int)
+		for _, m := range mocks {
+			for call, count := range m.Calls() {
+				got[call] += count
+			}
+		}
+		for _, call := range tt.ExpectCalls {
+			want[call]++
+		}
+		if !reflect.DeepEqual(got, want) {
+			return fmt.Errorf("got calls %s, want %s", sortedCalls(got), sortedCalls(want))
+		}
+	}
+	if len(tt.ExpectResults) > 0 && !reflect.DeepEqual(results, tt.ExpectResults) {
+		return fmt.Errorf("got results %v, want %v", results, tt.ExpectResults)
+	}
+	return nil
+}
+
+// setMockedCalls sets the functions of the generated mock for the given calls,
+// the calls not given failing the test
+func setMockedCalls(api string, m Mock, calls map[string]*MockedCall, t TestingT) error {
+	value := reflect.ValueOf(m).Elem()
+	for method, call := range calls {
+		field := value.FieldByName(method + "Func")
+		if !field.IsValid() || field.Kind() != reflect.Func {
+			return fmt.Errorf("unknown method '%s' of api '%s' in mocks", method, api)
+		}
+		if call == nil {
+			call = &MockedCall{}
+		}
+		fn, err := mockedCallFunc(method, field.Type(), call, t)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %s", api, method, err)
+		}
+		field.Set(fn)
+	}
+	for i := 0; i < value.NumField(); i++ {
+		field, name := value.Field(i), value.Type().Field(i).Name
+		if field.Kind() == reflect.Func && field.IsNil() {
+			method := strings.TrimSuffix(name, "Func")
+			field.Set(reflect.MakeFunc(field.Type(), func([]reflect.Value) []reflect.Value {
+				t.Fatalf("unexpected call to %s.%s", api, method)
+				return nil
+			}))
+		}
+	}
+	return nil
+}
+
+var (
+	requestType = reflect.TypeOf(&request.Request{})
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+func mockedCallFunc(method string, typ reflect.Type, call *MockedCall, t TestingT) (reflect.Value, error) {
+	var callErr error
+	if call.Error != "" {
+		callErr = errors.New(call.Error)
+	}
+	var expectedInput reflect.Value
+	if call.Input != nil {
+		if typ.NumIn() == 0 {
+			return reflect.Value{}, errors.New("method without input")
+		}
+		expectedInput = reflect.New(typ.In(typ.NumIn() - 1).Elem())
+		if typ.IsVariadic() && typ.NumIn() > 1 { // WithContext methods
+			expectedInput = reflect.New(typ.In(1).Elem())
+		}
+		if err := decodeInto(call.Input, expectedInput.Interface()); err != nil {
+			return reflect.Value{}, fmt.Errorf("input: %s", err)
+		}
+	}
+
+	return reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+		var input reflect.Value
+		for _, arg := range args {
+			if arg.Kind() == reflect.Ptr && arg.Type().Elem().Kind() == reflect.Struct {
+				input = arg
+			}
+		}
+		if expectedInput.IsValid() && input.IsValid() && !reflect.DeepEqual(expectedInput.Interface(), input.Interface()) {
+			got, _ := json.Marshal(input.Interface())
+			want, _ := json.Marshal(expectedInput.Interface())
+			t.Fatalf("%s: got input %s, want %s", method, got, want)
+		}
+
+		results := make([]reflect.Value, typ.NumOut())
+		var output, req reflect.Value
+		for i := 0; i < typ.NumOut(); i++ {
+			out := typ.Out(i)
+			switch {
+			case out == requestType:
+				continue
+			case out == errorType:
+				results[i] = reflect.Zero(errorType)
+				if callErr != nil {
+					results[i] = reflect.ValueOf(callErr)
+				}
+			case out.Kind() == reflect.Ptr && out.Elem().Kind() == reflect.Struct:
+				output = reflect.New(out.Elem())
+				if call.Output != nil {
+					if err := decodeInto(call.Output, output.Interface()); err != nil {
+						t.Fatalf("%s: output: %s", method, err)
+					}
+				}
+				results[i] = output
+			default:
+				results[i] = reflect.Zero(out)
+			}
+		}
+		for i := 0; i < typ.NumOut(); i++ {
+			if typ.Out(i) == requestType {
+				var params, data interface{}
+				if input.IsValid() {
+					params = input.Interface()
+				}
+				if output.IsValid() {
+					data = output.Interface()
+				}
+				r := request.New(aws.Config{}, metadata.ClientInfo{}, request.Handlers{}, nil, &request.Operation{Name: method}, params, data)
+				r.Error = callErr
+				req = reflect.ValueOf(r)
+				results[i] = req
+			}
+		}
+		return results
+	}), nil
+}
+
+// decodeInto converts a value decoded from YAML into the given AWS SDK struct
+func decodeInto(v interface{}, to interface{}) error {
+	b, err := json.Marshal(jsonCompatible(v))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, to)
+}
+
+func jsonCompatible(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{})
+		for k, val := range vv {
+			m[fmt.Sprint(k)] = jsonCompatible(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range vv {
+			vv[i] = jsonCompatible(val)
+		}
+		return vv
+	default:
+		return v
+	}
+}
+
+func sortedCalls(calls map[string]int) string {
+	var all []string
+	for call, count := range calls {
+		all = append(all, fmt.Sprintf("%s(x%d)", call, count))
+	}
+	sort.Strings(all)
+	return "[" + strings.Join(all, ", ") + "]"
+}
+
+type templateTestFactory struct {
+	mocks map[string]Mock
+	graph *graph.Graph
+}
+
+func (f *templateTestFactory) Build(key string) func() interface{} {
+	def, ok := awsspec.AWSLookupDefinitions(key)
+	if !ok {
+		return nil
+	}
+	return NewFactory(f.mocks[def.Api], f.graph).Build(key)
+}
+
+type testFailure string
+
+// testReport fails a template test from the mocks, stopping its run
+type testReport struct{}
+
+func (r *testReport) Helper() {}
+
+func (r *testReport) Fatalf(format string, args ...interface{}) {
+	panic(testFailure(fmt.Sprintf(format, args...)))
+}
@@ -0,0 +1,133 @@
+package awstest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/graph"
+)
+
+func TestTemplateTest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awless-template-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	write("subnet.aws", "create subnet name=my-subnet cidr={subnet.cidr} vpc=@my-vpc availabilityzone=eu-west-1a")
+	aliasFunc := func(g *graph.Graph, entity, key, alias string) string {
+		resources, _ := g.FindResourcesByProperty("Name", alias)
+		if len(resources) > 0 {
+			return resources[0].Id()
+		}
+		return ""
+	}
+
+	tcases := []struct {
+		name, test, expErr string
+	}{
+		{name: "pass", test: `
+fillers:
+  subnet.cidr: 10.10.10.0/24
+resources:
+  - {type: vpc, id: vpc-1, properties: {Name: my-vpc}}
+mocks:
+  ec2:
+    CreateSubnet:
+      input: {AvailabilityZone: eu-west-1a, CidrBlock: 10.10.10.0/24, VpcId: vpc-1}
+      output: {Subnet: {SubnetId: new-subnet-id}}
+    CreateTagsRequest:
+      input:
+        Resources: [new-subnet-id]
+        Tags: [{Key: Name, Value: my-subnet}]
+expect:
+  calls: [CreateSubnet, CreateTagsRequest]
+  results: [new-subnet-id]
+`},
+		{name: "unexpected input", expErr: `CreateSubnet: got input {"AvailabilityZone":"eu-west-1a","CidrBlock":"10.10.10.0/24"`, test: `
+fillers:
+  subnet.cidr: 10.10.10.0/24
+resources:
+  - {type: vpc, id: vpc-1, properties: {Name: my-vpc}}
+mocks:
+  ec2:
+    CreateSubnet:
+      input: {CidrBlock: 10.0.0.0/16}
+`},
+		{name: "unexpected call", expErr: "unexpected call to ec2.CreateTagsRequest", test: `
+fillers:
+  subnet.cidr: 10.10.10.0/24
+resources:
+  - {type: vpc, id: vpc-1, properties: {Name: my-vpc}}
+mocks:
+  ec2:
+    CreateSubnet:
+      output: {Subnet: {SubnetId: new-subnet-id}}
+`},
+		{name: "api error", expErr: "tagging failed", test: `
+fillers:
+  subnet.cidr: 10.10.10.0/24
+resources:
+  - {type: vpc, id: vpc-1, properties: {Name: my-vpc}}
+mocks:
+  ec2:
+    CreateSubnet:
+      output: {Subnet: {SubnetId: new-subnet-id}}
+    CreateTagsRequest:
+      error: tagging failed
+`},
+		{name: "unmet calls", expErr: "got calls [CreateSubnet(x1), CreateTagsRequest(x1)], want [CreateSubnet(x2)]", test: `
+fillers:
+  subnet.cidr: 10.10.10.0/24
+resources:
+  - {type: vpc, id: vpc-1, properties: {Name: my-vpc}}
+mocks:
+  ec2:
+    CreateSubnet:
+      output: {Subnet: {SubnetId: new-subnet-id}}
+    CreateTagsRequest:
+expect:
+  calls: [CreateSubnet, CreateSubnet]
+`},
+		{name: "unresolved alias", expErr: "my-vpc", test: `
+fillers:
+  subnet.cidr: 10.10.10.0/24
+`},
+		{name: "unknown method", expErr: "unknown method 'CreateSubnets' of api 'ec2'", test: `
+mocks:
+  ec2:
+    CreateSubnets:
+`},
+	}
+
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			tt, err := LoadTemplateTest(write("subnet"+TemplateTestSuffix, tcase.test))
+			if err != nil {
+				t.Fatal(err)
+			}
+			awsspec.CommandFactory = awsspec.MockAWSSessionFactory
+			err = tt.Run(aliasFunc)
+			if awsspec.CommandFactory != awsspec.MockAWSSessionFactory {
+				t.Fatal("command factory not restored after run")
+			}
+			switch {
+			case tcase.expErr == "" && err != nil:
+				t.Fatal(err)
+			case tcase.expErr != "" && (err == nil || !strings.Contains(err.Error(), tcase.expErr)):
+				t.Fatalf("got %v, want error containing %q", err, tcase.expErr)
+			}
+		})
+	}
+}
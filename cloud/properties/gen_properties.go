@@ -131,8 +131,11 @@ const (
 	Location                          = "Location"
 	MACAddress                        = "MACAddress"
 	Main                              = "Main"
+	MaxMessageSize                    = "MaxMessageSize"
 	MaxSize                           = "MaxSize"
 	Memory                            = "Memory"
+	MessageRetentionPeriod            = "MessageRetentionPeriod"
+	MessageWait                       = "MessageWait"
 	Messages                          = "Messages"
 	MetricName                        = "MetricName"
 	MinSize                           = "MinSize"
@@ -158,6 +161,7 @@ const (
 	PathPrefix                        = "PathPrefix"
 	PendingTasksCount                 = "PendingTasksCount"
 	PlacementGroup                    = "PlacementGroup"
+	Policy                            = "Policy"
 	Port                              = "Port"
 	PortRange                         = "PortRange"
 	PreferredBackupDate               = "PreferredBackupDate"
@@ -174,6 +178,7 @@ const (
 	PublicIP                          = "PublicIP"
 	RecordCount                       = "RecordCount"
 	Records                           = "Records"
+	RedrivePolicy                     = "RedrivePolicy"
 	Region                            = "Region"
 	RegisteredContainerInstancesCount = "RegisteredContainerInstancesCount"
 	ReplicaOf                         = "ReplicaOf"
@@ -219,6 +224,7 @@ const (
 	Value                             = "Value"
 	Version                           = "Version"
 	Virtualization                    = "Virtualization"
+	VisibilityTimeout                 = "VisibilityTimeout"
 	Volume                            = "Volume"
 	Vpc                               = "Vpc"
 	Vpcs                              = "Vpcs"
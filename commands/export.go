@@ -0,0 +1,145 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/codify"
+	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/cloudformation"
+)
+
+func init() {
+	RootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportCloudformationCmd)
+	exportCmd.AddCommand(exportTemplateCmd)
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export templates to other formats, or existing resources as templates",
+}
+
+var exportCloudformationCmd = &cobra.Command{
+	Use:              "cloudformation PATH [PARAM=VALUE ...]",
+	Short:            "Print the creations of a template as a CloudFormation template, its unfilled holes becoming parameters",
+	Example:          "  awless export cloudformation ~/templates/my-infra.aws > my-infra.json\n  awless export cloudformation repo:create_vpc vpc.cidr=10.0.0.0/16",
+	PersistentPreRun: applyHooks(initLoggerHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("missing PATH arg (filepath or url)")
+		}
+
+		content, fullPath, err := getTemplateText(args[0])
+		exitOn(err)
+
+		tpl, err := template.ParseFile(fullPath, string(content))
+		exitOn(err)
+
+		fillers, err := template.ParseParams(strings.Join(args[1:], " "))
+		exitOn(err)
+
+		cfn, unsupported, err := exportCloudFormation(tpl, fullPath, fillers)
+		exitOn(err)
+
+		for _, u := range unsupported {
+			logger.Warningf("not exported: %s", u)
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		exitOn(enc.Encode(cfn))
+		return nil
+	},
+}
+
+var exportTemplateCmd = &cobra.Command{
+	Use:               "template REF",
+	Short:             "Print a template recreating a resource of the local graph and its subtree (ex: a VPC with its subnets, route tables, gateways, security groups and instances)",
+	Example:           "  awless export template vpc-123 > my-vpc.aws\n  awless export template @my-vpc",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("missing REF arg (resource id or @name)")
+		}
+
+		res, g := findResourceInLocalGraphs(args[0])
+		if res == nil {
+			return fmt.Errorf("resource '%s' not found in the local graph of region %s (run `awless sync` to update it)", args[0], config.GetAWSRegion())
+		}
+
+		tpl, skipped, err := codify.Template(g, res)
+		exitOn(err)
+
+		for _, s := range skipped {
+			logger.Warningf("not exported: %s", s)
+		}
+		fmt.Print(tpl.Format())
+		return nil
+	},
+}
+
+func exportCloudFormation(tpl *template.Template, path string, fillers map[string]interface{}) (*cloudformation.Template, []*cloudformation.Unsupported, error) {
+	env := template.NewEnv()
+	env.Log = logger.DefaultLogger
+	env.AddFillers(fillers)
+	env.IncludeFunc = includeTemplateFunc(path)
+	env.Lookuper = func(tokens ...string) interface{} {
+		build := awsspec.MockAWSSessionFactory.Build(strings.Join(tokens, ""))
+		if build == nil {
+			return nil
+		}
+		return build()
+	}
+	if g, err := loadLocalGraphsWithoutInit(); err == nil {
+		env.AliasFunc = func(entity, key, alias string) string {
+			return resolveAliasInGraph(g, entity, key, alias)
+		}
+	} else {
+		logger.Verbosef("aliases will not be resolved: %s", err)
+	}
+
+	compiled, _, err := template.Compile(tpl, env, template.ExportCompileMode)
+	if err != nil {
+		return nil, nil, err
+	}
+	cfn, unsupported := cloudformation.Export(compiled, cloudFormationMapping)
+	return cfn, unsupported, nil
+}
+
+func cloudFormationMapping(action, entity string) (*cloudformation.Mapping, bool) {
+	build := awsspec.MockAWSSessionFactory.Build(action + entity)
+	if build == nil {
+		return nil, false
+	}
+	res, ok := awsspec.CloudFormationResourceOf(build())
+	if !ok {
+		return nil, false
+	}
+	return &cloudformation.Mapping{Type: res.Type, Result: res.Result, Properties: res.Properties}, true
+}
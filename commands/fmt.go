@@ -0,0 +1,92 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+)
+
+var checkFormatFlag bool
+
+func init() {
+	RootCmd.AddCommand(fmtCmd)
+
+	fmtCmd.Flags().BoolVar(&checkFormatFlag, "check", false, "Only list the files not formatted, exiting with an error if any")
+}
+
+var fmtCmd = &cobra.Command{
+	Use:              "fmt FILE...",
+	Short:            "Rewrite template files in their canonical form",
+	Example:          "  awless fmt ~/templates/my-infra.aws\n  awless fmt --check templates/*.aws",
+	PersistentPreRun: applyHooks(initLoggerHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("missing FILE arg")
+		}
+
+		var unformatted int
+		for _, path := range args {
+			formatted, err := formatTemplateFile(path, !checkFormatFlag)
+			exitOn(err)
+			if !formatted && checkFormatFlag {
+				unformatted++
+				fmt.Println(path)
+			}
+		}
+
+		if unformatted > 0 {
+			logger.Errorf("%d template(s) not formatted, run `awless fmt` on them", unformatted)
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+// formatTemplateFile returns whether the template file was already formatted,
+// rewriting it in its canonical form otherwise if write is true
+func formatTemplateFile(path string, write bool) (bool, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	tpl, err := template.ParseFile(path, string(content))
+	if err != nil {
+		return false, err
+	}
+	formatted := tpl.Format()
+	if formatted == string(content) {
+		return true, nil
+	}
+	if write {
+		info, err := os.Stat(path)
+		if err != nil {
+			return false, err
+		}
+		if err := ioutil.WriteFile(path, []byte(formatted), info.Mode()); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
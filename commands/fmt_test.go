@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/template"
+)
+
+// awless fmt exits the process, so the command runs in a subprocess of the test binary
+func TestFmtCommand(t *testing.T) {
+	if args := os.Getenv("AWLESS_TEST_FMT_ARGS"); args != "" {
+		RootCmd.SetArgs(append([]string{"fmt"}, strings.Split(args, " ")...))
+		RootCmd.Execute()
+		return
+	}
+
+	dir, err := ioutil.TempDir("", "awless-fmt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	unformatted := "vpc =  create vpc   cidr=10.0.0.0/16\ncreate subnet vpc=$vpc cidr=10.0.0.0/24"
+	formatted := template.MustParse(unformatted).Format()
+
+	tcases := []struct {
+		content    string
+		check      bool
+		expStatus  int
+		expListed  bool
+		expContent string
+	}{
+		{content: formatted, check: true, expStatus: 0, expContent: formatted},
+		{content: unformatted, check: true, expStatus: 1, expListed: true, expContent: unformatted},
+		{content: unformatted, check: false, expStatus: 0, expContent: formatted},
+		{content: formatted, check: false, expStatus: 0, expContent: formatted},
+	}
+	for i, tcase := range tcases {
+		path := filepath.Join(dir, "infra.aws")
+		if err := ioutil.WriteFile(path, []byte(tcase.content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		args := path
+		if tcase.check {
+			args = "--check " + path
+		}
+		cmd := exec.Command(os.Args[0], "-test.run=TestFmtCommand")
+		cmd.Env = append(os.Environ(), "AWLESS_TEST_FMT_ARGS="+args)
+		out, err := cmd.Output()
+		var status int
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			status = exitErr.Sys().(interface{ ExitStatus() int }).ExitStatus()
+		} else if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := status, tcase.expStatus; got != want {
+			t.Fatalf("%d: exit status: got %d, want %d", i+1, got, want)
+		}
+		if got, want := strings.Contains(string(out), path), tcase.expListed; got != want {
+			t.Fatalf("%d: file listed in output: got %t, want %t (output: %q)", i+1, got, want, out)
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(content), tcase.expContent; got != want {
+			t.Fatalf("%d: got\n%q\nwant\n%q", i+1, got, want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/template"
+)
+
+var lintFormatFlag string
+
+func init() {
+	RootCmd.AddCommand(lintCmd)
+
+	lintCmd.Flags().StringVar(&lintFormatFlag, "format", "text", "Output format of the findings: text, json")
+}
+
+var lintCmd = &cobra.Command{
+	Use:              "lint PATH",
+	Short:            "Check a template for hygiene and security issues, exiting with an error on findings of severity error",
+	Example:          "  awless lint ~/templates/my-infra.aws\n  awless lint repo:create_vpc --format json",
+	PersistentPreRun: applyHooks(initLoggerHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("missing PATH arg (filepath or url)")
+		}
+
+		content, fullPath, err := getTemplateText(args[0])
+		exitOn(err)
+
+		tpl, err := template.ParseFile(fullPath, string(content))
+		exitOn(err)
+
+		findings := tpl.Lint()
+		exitOn(printLintFindings(os.Stdout, fullPath, findings, lintFormatFlag))
+
+		for _, f := range findings {
+			if f.Severity == template.SeverityError {
+				os.Exit(1)
+			}
+		}
+		return nil
+	},
+}
+
+func printLintFindings(w io.Writer, path string, findings []*template.LintFinding, format string) error {
+	switch format {
+	case "json":
+		if findings == nil {
+			findings = []*template.LintFinding{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(findings)
+	case "text":
+		for _, f := range findings {
+			fmt.Fprintf(w, "%s:%d: %s: %s (%s)\n", path, f.Line, f.Severity, f.Message, f.Rule)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown format '%s', expected text or json", format)
+	}
+}
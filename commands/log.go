@@ -31,6 +31,8 @@ var (
 	limitLogCountFlag             int
 	rawJSONLogFlag, idOnlyLogFlag bool
 	fullLogFlag, shortLogFlag     bool
+	outputsLogFlag                bool
+	logFormatFlag                 string
 )
 
 func init() {
@@ -43,6 +45,8 @@ func init() {
 	logCmd.Flags().BoolVar(&shortLogFlag, "short", false, "Display one or more template log with less info")
 	logCmd.Flags().BoolVar(&fullLogFlag, "full", false, "Display template logs with full info")
 	logCmd.Flags().BoolVar(&idOnlyLogFlag, "id-only", false, "Show only log template IDs (i.e. revert IDs)")
+	logCmd.Flags().BoolVar(&outputsLogFlag, "outputs", false, "Show only the outputs of the templates")
+	logCmd.Flags().StringVar(&logFormatFlag, "format", "table", "Output format of --outputs: table, json (default to table)")
 }
 
 var logCmd = &cobra.Command{
@@ -133,6 +137,8 @@ func getPrinter(args []string) logPrinter {
 		return &rawJSONPrinter{os.Stdout}
 	case idOnlyLogFlag:
 		return &idOnlyPrinter{os.Stdout}
+	case outputsLogFlag:
+		return &outputsLogPrinter{w: os.Stdout, format: logFormatFlag}
 	case shortLogFlag:
 		return &shortLogPrinter{os.Stdout}
 	case fullLogFlag:
@@ -6,6 +6,7 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/fatih/color"
@@ -29,7 +30,7 @@ func (p *fullLogPrinter) print(t *template.TemplateExecution) error {
 		fmt.Fprintf(p.w, "\t%s\n\n", t.Message)
 	}
 
-	for _, cmd := range t.CommandNodesIterator() {
+	for i, cmd := range t.CommandNodesIterator() {
 		var status string
 		if cmd.CmdErr != nil {
 			status = renderRedFn("KO")
@@ -37,11 +38,12 @@ func (p *fullLogPrinter) print(t *template.TemplateExecution) error {
 			status = renderGreenFn("OK")
 		}
 
+		// statements are numbered for selective reverts with `awless revert --only/--except`
 		var line string
 		if v, ok := cmd.CmdResult.(string); ok && v != "" {
-			line = fmt.Sprintf("    %s\t%s\t[%s]", status, cmd.String(), v)
+			line = fmt.Sprintf("  %2d %s\t%s\t[%s]", i+1, status, cmd.String(), v)
 		} else {
-			line = fmt.Sprintf("    %s\t%s", status, cmd.String())
+			line = fmt.Sprintf("  %2d %s\t%s", i+1, status, cmd.String())
 		}
 
 		fmt.Fprintln(p.w, line)
@@ -93,6 +95,38 @@ func (p *idOnlyPrinter) print(t *template.TemplateExecution) error {
 	return nil
 }
 
+type outputsLogPrinter struct {
+	w      io.Writer
+	format string
+}
+
+func (p *outputsLogPrinter) print(t *template.TemplateExecution) error {
+	outputs := t.Outputs
+	if outputs == nil {
+		outputs = make(map[string]interface{})
+	}
+	switch p.format {
+	case "json":
+		if err := json.NewEncoder(p.w).Encode(outputs); err != nil {
+			return fmt.Errorf("json printer: %s", err)
+		}
+	case "", "table":
+		var names []string
+		for name := range outputs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		tw := tabwriter.NewWriter(p.w, 0, 8, 2, ' ', 0)
+		for _, name := range names {
+			fmt.Fprintf(tw, "    %s\t%v\n", name, outputs[name])
+		}
+		tw.Flush()
+	default:
+		return fmt.Errorf("unknown outputs format '%s', expected table or json", p.format)
+	}
+	return nil
+}
+
 func writeLogHeader(t *template.TemplateExecution, w io.Writer) {
 	stats := t.Stats()
 
@@ -117,6 +151,12 @@ func writeLogHeader(t *template.TemplateExecution, w io.Writer) {
 	if !template.IsRevertible(t.Template) {
 		fmt.Fprintf(w, " (not revertible)")
 	}
+	if t.RolledBackBy != "" {
+		fmt.Fprintf(w, " (rolled back by %s)", t.RolledBackBy)
+	}
+	if t.RollbackOf != "" {
+		fmt.Fprintf(w, " (rollback of %s)", t.RollbackOf)
+	}
 }
 
 func writeMultilineLogHeader(t *template.TemplateExecution, w io.Writer) {
@@ -137,6 +177,12 @@ func writeMultilineLogHeader(t *template.TemplateExecution, w io.Writer) {
 	if t.Locale != "" {
 		fmt.Fprintf(w, "Region: %s\n", t.Locale)
 	}
+	if t.RolledBackBy != "" {
+		fmt.Fprintf(w, "Rolled back by: %s\n", t.RolledBackBy)
+	}
+	if t.RollbackOf != "" {
+		fmt.Fprintf(w, "Rollback of: %s\n", t.RollbackOf)
+	}
 	fmt.Fprintln(w)
 }
 
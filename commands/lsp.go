@@ -0,0 +1,100 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/doc"
+	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync"
+	"github.com/wallix/awless/template/lsp"
+)
+
+func init() {
+	RootCmd.AddCommand(lspCmd)
+}
+
+var lspCmd = &cobra.Command{
+	Use:              "lsp",
+	Short:            "Start a Language Server Protocol server over stdio, for editors to check and complete templates",
+	Example:          "  awless lsp",
+	PersistentPreRun: applyHooks(initLoggerHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		g, err := loadLocalGraphsWithoutInit()
+		if err != nil {
+			logger.Warningf("aliases will not be completed: %s", err)
+		}
+
+		server := &lsp.Server{
+			Actions: awsspec.DriverSupportedActions,
+			Params: func(action, entity string) ([]string, []string, bool) {
+				def, ok := awsspec.AWSLookupDefinitions(action + entity)
+				return def.RequiredParams, def.ExtraParams, ok
+			},
+			ParamDoc: func(action, entity, param string) (string, bool) {
+				return awsdoc.TemplateParamsDoc(action+entity, param)
+			},
+			Enums: func(action, entity, param string) []string {
+				return awsdoc.EnumDoc[fmt.Sprintf("%s.%s.%s", action, entity, param)]
+			},
+			Aliases: func(action, entity, param string) []string {
+				return resourcesNames(g, entity+"."+param)
+			},
+		}
+		exitOn(server.Run(os.Stdin, os.Stdout))
+		return nil
+	},
+}
+
+// loadLocalGraphsWithoutInit loads the local graphs of the current region without
+// initializing the awless environment, as its first install prompts on stdin
+func loadLocalGraphsWithoutInit() (*graph.Graph, error) {
+	if _, err := os.Stat(config.DBPath); err != nil {
+		return nil, fmt.Errorf("no awless environment: %s", err)
+	}
+	if err := config.LoadConfig(); err != nil {
+		return nil, err
+	}
+	return sync.LoadLocalGraphs(config.GetAWSRegion())
+}
+
+func resourcesNames(g *graph.Graph, hole string) (names []string) {
+	if g == nil {
+		return
+	}
+	entityTypes, _ := guessEntityTypeFromHoleQuestion(hole)
+	if len(entityTypes) == 0 {
+		return
+	}
+	resources, err := g.GetAllResources(entityTypes...)
+	if err != nil {
+		return
+	}
+	for _, res := range resources {
+		if name, ok := res.Properties()["Name"].(string); ok && name != "" {
+			names = append(names, name)
+		}
+	}
+	return
+}
@@ -19,8 +19,9 @@ package commands
 import (
 	"errors"
 	"fmt"
-
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/wallix/awless/config"
@@ -29,14 +30,22 @@ import (
 	"github.com/wallix/awless/template"
 )
 
+var (
+	revertOnlyFlag   string
+	revertExceptFlag string
+)
+
 func init() {
 	RootCmd.AddCommand(revertCmd)
+
+	revertCmd.Flags().StringVar(&revertOnlyFlag, "only", "", "Revert only the given statements, numbered as printed by awless log REVERTID (ex: 3,5-7)")
+	revertCmd.Flags().StringVar(&revertExceptFlag, "except", "", "Revert all statements but the given ones, numbered as printed by awless log REVERTID (ex: 3,5-7)")
 }
 
 var revertCmd = &cobra.Command{
 	Use:               "revert REVERTID",
 	Short:             "Revert a template execution given a revert ID (see `awless log` to list revert ids)",
-	Example:           "  awless revert 01BA7RV6ES86PZYCM3H28WM6KZ",
+	Example:           "  awless revert 01BA7RV6ES86PZYCM3H28WM6KZ\n  awless revert 01BA7RV6ES86PZYCM3H28WM6KZ --only 3,5-7",
 	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook, firstInstallDoneHook),
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
 
@@ -47,6 +56,10 @@ var revertCmd = &cobra.Command{
 
 		revertID := args[0]
 
+		if revertOnlyFlag != "" && revertExceptFlag != "" {
+			return errors.New("--only and --except cannot be used together")
+		}
+
 		var loaded *template.TemplateExecution
 		exitOn(database.Execute(func(db *database.DB) (terr error) {
 			loaded, terr = db.GetTemplate(revertID)
@@ -63,7 +76,29 @@ var revertCmd = &cobra.Command{
 			logger.Warningf("This template was originally run with profile %s", prof)
 		}
 
-		reverted, err := loaded.Template.Revert()
+		var reverted *template.Template
+		var err error
+		message := fmt.Sprintf("Revert: %s", loaded.Message)
+		switch {
+		case revertOnlyFlag != "":
+			selected, perr := parseStatementRanges(revertOnlyFlag)
+			exitOn(perr)
+			reverted, err = loaded.Template.RevertSelected(selected)
+			message = fmt.Sprintf("Revert statements %s: %s", revertOnlyFlag, loaded.Message)
+		case revertExceptFlag != "":
+			excepted, perr := parseStatementRanges(revertExceptFlag)
+			exitOn(perr)
+			selected := make(map[int]bool)
+			for i := 1; i <= len(loaded.CommandNodesIterator()); i++ {
+				if !excepted[i] {
+					selected[i] = true
+				}
+			}
+			reverted, err = loaded.Template.RevertSelected(selected)
+			message = fmt.Sprintf("Revert all statements but %s: %s", revertExceptFlag, loaded.Message)
+		default:
+			reverted, err = loaded.Template.Revert()
+		}
 		exitOn(err)
 
 		tplExec := &template.TemplateExecution{
@@ -72,10 +107,33 @@ var revertCmd = &cobra.Command{
 			Profile:  config.GetAWSProfile(),
 			Source:   reverted.String(),
 		}
-		tplExec.SetMessage(fmt.Sprintf("Revert: %s", loaded.Message))
+		tplExec.SetMessage(message)
 
 		exitOn(NewRunner(tplExec.Template, tplExec.Message, tplExec.Path).Run())
 
 		return nil
 	},
 }
+
+// parseStatementRanges parses comma separated statement numbers and ranges, as in 3,5-7
+func parseStatementRanges(s string) (map[int]bool, error) {
+	nums := make(map[int]bool)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		bounds := strings.SplitN(part, "-", 2)
+		from, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid statement number '%s' in '%s'", part, s)
+		}
+		to := from
+		if len(bounds) == 2 {
+			if to, err = strconv.Atoi(bounds[1]); err != nil || to < from {
+				return nil, fmt.Errorf("invalid statement range '%s' in '%s'", part, s)
+			}
+		}
+		for i := from; i <= to; i++ {
+			nums[i] = true
+		}
+	}
+	return nums, nil
+}
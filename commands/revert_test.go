@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStatementRanges(t *testing.T) {
+	tcases := []struct {
+		in     string
+		exp    map[int]bool
+		expErr bool
+	}{
+		{in: "3", exp: map[int]bool{3: true}},
+		{in: "3,5-7", exp: map[int]bool{3: true, 5: true, 6: true, 7: true}},
+		{in: "1-2, 4", exp: map[int]bool{1: true, 2: true, 4: true}},
+		{in: "", expErr: true},
+		{in: "a", expErr: true},
+		{in: "7-5", expErr: true},
+		{in: "5-", expErr: true},
+	}
+	for i, tcase := range tcases {
+		got, err := parseStatementRanges(tcase.in)
+		if tcase.expErr {
+			if err == nil {
+				t.Fatalf("%d: %s: expected error", i+1, tcase.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if want := tcase.exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %v, want %v", i+1, got, want)
+		}
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -38,11 +39,13 @@ import (
 	"github.com/fxaguessy/readline"
 	"github.com/spf13/cobra"
 	"github.com/wallix/awless-scheduler/client"
+	"github.com/wallix/awless/aws/codify"
 	"github.com/wallix/awless/aws/doc"
 	"github.com/wallix/awless/aws/services"
 	"github.com/wallix/awless/aws/spec"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/database"
 	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/sync"
@@ -54,6 +57,13 @@ var (
 	scheduleRevertInFlag    string
 	runLogMessage           string
 	listRemoteTemplatesFlag bool
+	helpTemplateFlag        bool
+	runParallelFlag         int
+	rollbackOnFailureFlag   bool
+	requireRevertableFlag   bool
+	resumeFlag              string
+	planOutFlag             string
+	planFlag                string
 )
 
 func init() {
@@ -61,6 +71,13 @@ func init() {
 	runCmd.Flags().BoolVar(&listRemoteTemplatesFlag, "list", false, "List templates available at https://github.com/wallix/awless-templates")
 	runCmd.Flags().StringVar(&scheduleRunInFlag, "run-in", "", "Postpone the execution of this template")
 	runCmd.Flags().StringVar(&scheduleRevertInFlag, "revert-in", "", "Schedule the revertion of this template")
+	runCmd.Flags().IntVar(&runParallelFlag, "parallel", 1, "Maximum number of independent commands run concurrently")
+	runCmd.Flags().StringVar(&resumeFlag, "resume", "", "Resume a failed template execution given its revert ID, skipping its succeeded commands")
+	runCmd.Flags().StringVar(&planOutFlag, "plan-out", "", "Write the compiled and dry run template as a JSON plan to the given file, without running it")
+	runCmd.Flags().StringVar(&planFlag, "plan", "", "Run exactly the plan of the given file, written with --plan-out, if its template is unchanged")
+	runCmd.Flags().BoolVar(&rollbackOnFailureFlag, "rollback-on-failure", false, "Revert the successful commands of the template when one of its commands fails")
+	runCmd.Flags().BoolVar(&requireRevertableFlag, "require-revertable", false, "Refuse to run templates with statements which will not be revertible")
+	runCmd.Flags().BoolVar(&helpTemplateFlag, "help-template", false, "Print the params declared by the template instead of running it")
 	runCmd.Flags().StringVarP(&runLogMessage, "message", "m", "", "Add a message for this template execution to be persisted in your logs")
 
 	var actions []string
@@ -93,6 +110,14 @@ var runCmd = &cobra.Command{
 			exitOn(listRemoteTemplates())
 			return nil
 		}
+		if resumeFlag != "" {
+			exitOn(resumeTemplate(resumeFlag))
+			return nil
+		}
+		if planFlag != "" {
+			exitOn(applyPlan(planFlag, args))
+			return nil
+		}
 		if len(args) < 1 {
 			return errors.New("missing PATH arg (filepath or url)")
 		}
@@ -106,9 +131,14 @@ var runCmd = &cobra.Command{
 
 		logger.Verbosef("Loaded template text:\n\n%s\n", removeComments(content))
 
-		templ, err := template.Parse(string(content))
+		templ, err := template.ParseFile(fullPath, string(content))
 		exitOn(err)
 
+		if helpTemplateFlag {
+			printTemplateParams(os.Stdout, fullPath, templ.ParamDeclarations())
+			return nil
+		}
+
 		extraParams, err := template.ParseParams(strings.Join(args[1:], " "))
 		exitOn(err)
 
@@ -121,19 +151,156 @@ var runCmd = &cobra.Command{
 			Source:   templ.String(),
 		}
 
-		exitOn(NewRunner(tplExec.Template, tplExec.Message, tplExec.Path, config.Defaults, extraParams).Run())
+		runner := NewRunner(tplExec.Template, tplExec.Message, tplExec.Path, config.Defaults, extraParams)
+		reportRevertibility(runner)
+		if planOutFlag != "" {
+			runner.PlanFunc = writePlanFunc(planOutFlag)
+		}
+		exitOn(runner.Run())
 
 		return nil
 	},
 }
 
-func missingHolesStdinFunc() func(string, []string) interface{} {
+func writePlanFunc(path string) func(*template.Plan) error {
+	return func(plan *template.Plan) error {
+		b, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, append(b, '\n'), 0644); err != nil {
+			return fmt.Errorf("writing plan: %s", err)
+		}
+		logger.Infof("Plan of %d command(s) written to %s. Apply it with `awless run --plan %s`", len(plan.Statements), path, path)
+		return nil
+	}
+}
+
+// applyPlan runs a plan written with --plan-out, given the template it was
+// made from, which defaults to the one at the path recorded in the plan
+func applyPlan(path string, args []string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading plan: %s", err)
+	}
+	plan := &template.Plan{}
+	if err = json.Unmarshal(b, plan); err != nil {
+		return fmt.Errorf("invalid plan %s: %s", path, err)
+	}
+	if loc := plan.Locale; loc != "" && loc != config.GetAWSRegion() {
+		return fmt.Errorf("plan %s was made for region %s, apply it with `awless run --plan %s -r %s -p %s`", path, loc, path, loc, plan.Profile)
+	}
+	if prof := plan.Profile; prof != config.GetAWSProfile() {
+		logger.Warningf("This plan was made with profile %s", prof)
+	}
+
+	tplPath := plan.Path
+	if len(args) > 0 {
+		tplPath = args[0]
+	}
+	if tplPath == "" {
+		return errors.New("missing PATH arg of the planned template (filepath or url)")
+	}
+	content, fullPath, err := getTemplateText(tplPath)
+	if err != nil {
+		return err
+	}
+	templ, err := template.ParseFile(fullPath, string(content))
+	if err != nil {
+		return err
+	}
+
+	runner := NewRunner(templ, strings.TrimSpace(runLogMessage), fullPath)
+	runner.Plan = plan
+	reportRevertibility(runner)
+	return runner.Run()
+}
+
+func resumeTemplate(id string) error {
+	var loaded *template.TemplateExecution
+	if err := database.Execute(func(db *database.DB) (terr error) {
+		loaded, terr = db.GetTemplate(id)
+		return
+	}); err != nil {
+		return err
+	}
+
+	if loaded.Stats().KOCount == 0 {
+		return fmt.Errorf("template %s did not fail, nothing to resume", id)
+	}
+	if loc := loaded.Locale; loc != "" && loc != config.GetAWSRegion() {
+		return fmt.Errorf("template %s was originally run in region %s, resume with `awless run --resume %s -r %s -p %s`", id, loc, id, loc, loaded.Profile)
+	}
+	if prof := loaded.Profile; prof != config.GetAWSProfile() {
+		logger.Warningf("This template was originally run with profile %s", prof)
+	}
+
+	templ, err := template.Parse(loaded.Source)
+	if err != nil {
+		return fmt.Errorf("cannot parse source of template %s: %s", id, err)
+	}
+
+	msg := strings.TrimSpace(runLogMessage)
+	if msg == "" {
+		msg = fmt.Sprintf("Resume of %s", id)
+	}
+	runner := NewRunner(templ, msg, loaded.Path, config.Defaults, loaded.Fillers)
+	runner.Resumed = loaded.Template
+	reportRevertibility(runner)
+	return runner.Run()
+}
+
+// reportRevertibility warns before running about the statements which will not be revertible.
+// Only set by `awless run`, as one-liners and reverts would warn on most of their statements.
+func reportRevertibility(runner *template.Runner) {
+	runner.ReportRevertibility = true
+	runner.RequireRevertible = requireRevertableFlag
+	runner.RecreatableFunc = codify.Recreatable
+}
+
+func printTemplateParams(w io.Writer, path string, params []*template.ParamDeclaration) {
+	if len(params) == 0 {
+		fmt.Fprintf(w, "No params declared in %s\n", path)
+		return
+	}
+	fmt.Fprintf(w, "Params of %s:\n", path)
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	for _, p := range params {
+		var details []string
+		if len(p.AllowedValues) > 0 {
+			details = append(details, fmt.Sprintf("one of: %s", strings.Join(p.AllowedValues, ", ")))
+		}
+		if p.Default != "" {
+			details = append(details, fmt.Sprintf("default: %s", p.Default))
+		}
+		desc := p.Description
+		if len(details) > 0 {
+			desc = strings.TrimSpace(fmt.Sprintf("%s (%s)", desc, strings.Join(details, "; ")))
+		}
+		fmt.Fprintf(tw, "  %s\t%s", p.Name, p.Type)
+		if desc != "" {
+			fmt.Fprintf(tw, "\t%s", desc)
+		}
+		fmt.Fprintln(tw)
+	}
+	tw.Flush()
+}
+
+func missingHolesStdinFunc(declared ...*template.ParamDeclaration) func(string, []string) interface{} {
 	var count int
 	return func(hole string, paramPaths []string) (response interface{}) {
 		if count < 1 {
 			fmt.Println("Please specify (Ctrl+C to quit, Tab for completion):")
 		}
 		var docs, enums []string
+		for _, p := range declared {
+			if p.Name == hole {
+				if p.Description != "" {
+					docs = append(docs, fmt.Sprintf("%s (%s)", p.Description, p.Type))
+				}
+				enums = append(enums, p.AllowedValues...)
+			}
+		}
 		var typedParam *awsdoc.ParamType
 		for _, param := range paramPaths {
 			splits := strings.Split(param, ".")
@@ -213,6 +380,39 @@ func askHole(hole string, autocomplete readline.AutoCompleter) (interface{}, err
 	return nil, nil
 }
 
+// missingSecretHolesStdinFunc prompts for secret holes without echoing their values
+func missingSecretHolesStdinFunc() func(string, []string) interface{} {
+	return func(hole string, paramPaths []string) (response interface{}) {
+		var err error
+		for response, err = askSecretHole(hole); err != nil; response, err = askSecretHole(hole) {
+			logger.Errorf("invalid value: %s", err)
+		}
+		return
+	}
+}
+
+func askSecretHole(hole string) (interface{}, error) {
+	l, err := readline.NewEx(&readline.Config{
+		InterruptPrompt: "^C",
+		EOFPrompt:       "exit",
+	})
+	if err != nil {
+		exitOn(err)
+	}
+	defer l.Close()
+
+	secret, err := l.ReadPassword(renderCyanBoldFn(hole + " (secret)? "))
+	switch {
+	case err == readline.ErrInterrupt:
+		os.Exit(0)
+	case err != nil:
+		return nil, err
+	case len(secret) == 0:
+		return nil, errors.New("empty")
+	}
+	return string(secret), nil
+}
+
 type onceLoader struct {
 	g    *graph.Graph
 	err  error
@@ -375,6 +575,10 @@ func resolveAliasFunc(entity, key, alias string) string {
 		fmt.Printf("resolve alias '%s': cannot load local graphs for region %s: %s\n", alias, config.GetAWSRegion(), err)
 		return ""
 	}
+	return resolveAliasInGraph(gph, entity, key, alias)
+}
+
+func resolveAliasInGraph(gph *graph.Graph, entity, key, alias string) string {
 	resType := key
 	if strings.Contains(key, "id") {
 		resType = entity
@@ -400,6 +604,62 @@ func resolveAliasFunc(entity, key, alias string) string {
 	return ""
 }
 
+// snapshotDeletedFunc returns the states in the local graph of the resources a delete is about to remove,
+// with the statements recreating them
+func snapshotDeletedFunc(entity string, params map[string]interface{}) ([]*template.Snapshot, error) {
+	gph, err := sync.LoadLocalGraphs(config.GetAWSRegion())
+	if err != nil {
+		return nil, fmt.Errorf("cannot load local graphs for region %s: %s", config.GetAWSRegion(), err)
+	}
+	return snapshotInGraph(gph, entity, params)
+}
+
+func snapshotInGraph(gph *graph.Graph, entity string, params map[string]interface{}) (snapshots []*template.Snapshot, err error) {
+	for _, ref := range deletedRefs(params) {
+		res := findDeletedInGraph(gph, entity, ref)
+		if res == nil {
+			snapshots = append(snapshots, &template.Snapshot{Type: entity, ID: ref,
+				Unrecreatable: []string{fmt.Sprintf("%s %s: not found in the local graph (run `awless sync` to update it)", entity, ref)}})
+			continue
+		}
+		recreate, skipped, err := codify.Resource(gph, res)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, &template.Snapshot{Type: res.Type(), ID: res.Id(), Properties: res.Properties(), Recreate: recreate, Unrecreatable: skipped})
+	}
+	return snapshots, nil
+}
+
+// deletedRefs returns the ids, names or urls identifying the resources a delete removes
+func deletedRefs(params map[string]interface{}) (refs []string) {
+	for _, key := range []string{"id", "name", "url", "arn"} {
+		switch v := params[key].(type) {
+		case string:
+			return []string{v}
+		case []string:
+			return v
+		case []interface{}:
+			for _, e := range v {
+				refs = append(refs, fmt.Sprint(e))
+			}
+			return refs
+		}
+	}
+	return nil
+}
+
+func findDeletedInGraph(gph *graph.Graph, entity, ref string) *graph.Resource {
+	if res, err := gph.FindResource(ref); err == nil && res != nil && res.Type() == entity {
+		return res
+	}
+	resources, err := gph.ResolveResources(&graph.And{Resolvers: []graph.Resolver{&graph.ByProperty{Key: "Name", Value: ref}, &graph.ByType{Typ: entity}}})
+	if err != nil || len(resources) != 1 {
+		return nil
+	}
+	return resources[0]
+}
+
 func sprintProcessedParams(processed map[string]interface{}) string {
 	if len(processed) == 0 {
 		return "<none>"
@@ -471,6 +731,40 @@ func getTemplateText(path string) (content []byte, expanded string, err error) {
 	return content, expanded, nil
 }
 
+// includeTemplateFunc loads included templates with the same sources as `awless run`.
+// Relative file paths are resolved from the directory of the including template.
+func includeTemplateFunc(rootPath string) func(path, from string) (string, string, error) {
+	return func(path, from string) (string, string, error) {
+		if from == "" {
+			from = rootPath
+		}
+		content, expanded, err := getTemplateText(resolveIncludePath(path, from))
+		if err != nil {
+			return "", "", err
+		}
+		logger.ExtraVerbosef("included template '%s'", expanded)
+		return string(content), expanded, nil
+	}
+}
+
+func resolveIncludePath(path, from string) string {
+	if from == "" || strings.HasPrefix(path, "repo:") || strings.HasPrefix(path, "http") || filepath.IsAbs(path) {
+		return path
+	}
+	if strings.HasPrefix(from, "http") {
+		base, err := url.Parse(from)
+		if err != nil {
+			return path
+		}
+		rel, err := url.Parse(path)
+		if err != nil {
+			return path
+		}
+		return base.ResolveReference(rel).String()
+	}
+	return filepath.Join(filepath.Dir(from), path)
+}
+
 func removeComments(b []byte) []byte {
 	scn := bufio.NewScanner(bytes.NewReader(b))
 	var cleaned bytes.Buffer
@@ -1,6 +1,15 @@
 package commands
 
-import "testing"
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/template"
+)
 
 func TestIsCSV(t *testing.T) {
 	tcases := []struct {
@@ -43,3 +52,86 @@ func TestJoinSentence(t *testing.T) {
 		}
 	}
 }
+
+func TestResolveIncludePath(t *testing.T) {
+	tcases := []struct {
+		path, from, exp string
+	}{
+		{path: "lib/vpc.aws", from: "", exp: "lib/vpc.aws"},
+		{path: "lib/vpc.aws", from: "/home/templates/infra.aws", exp: "/home/templates/lib/vpc.aws"},
+		{path: "../vpc.aws", from: "/home/templates/infra.aws", exp: "/home/vpc.aws"},
+		{path: "/opt/vpc.aws", from: "/home/templates/infra.aws", exp: "/opt/vpc.aws"},
+		{path: "repo:create_vpc", from: "/home/templates/infra.aws", exp: "repo:create_vpc"},
+		{path: "lib/vpc.aws", from: "https://example.com/templates/infra.aws", exp: "https://example.com/templates/lib/vpc.aws"},
+		{path: "https://example.com/vpc.aws", from: "/home/templates/infra.aws", exp: "https://example.com/vpc.aws"},
+	}
+	for i, tcase := range tcases {
+		if got, want := resolveIncludePath(tcase.path, tcase.from), tcase.exp; got != want {
+			t.Errorf("%d. got %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+func TestPrintTemplateParams(t *testing.T) {
+	var buff bytes.Buffer
+	printTemplateParams(&buff, "infra.aws", []*template.ParamDeclaration{
+		{Name: "vpc.cidr", Type: "cidr", Default: "10.0.0.0/16", Description: "CIDR of the VPC"},
+		{Name: "instance.type", Type: "string", AllowedValues: []string{"t2.micro", "t2.small"}},
+		{Name: "name", Type: "string"},
+	})
+	expected := `Params of infra.aws:
+  vpc.cidr       cidr    CIDR of the VPC (default: 10.0.0.0/16)
+  instance.type  string  (one of: t2.micro, t2.small)
+  name           string
+`
+	if got, want := buff.String(), expected; got != want {
+		t.Fatalf("got\n%q\nwant\n%q", got, want)
+	}
+
+	buff.Reset()
+	printTemplateParams(&buff, "infra.aws", nil)
+	if got, want := buff.String(), "No params declared in infra.aws\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestSnapshotInGraph(t *testing.T) {
+	g := graph.NewGraph()
+	user := graph.InitResource(cloud.User, "AIDA1")
+	user.Properties()[properties.Name] = "alice"
+	group := graph.InitResource(cloud.Group, "AGPA1")
+	group.Properties()[properties.Name] = "admins"
+	g.AddResource(user, group)
+	g.AddAppliesOnRelation(group, user)
+
+	snapshots, err := snapshotInGraph(g, "user", map[string]interface{}{"name": "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(snapshots), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := snapshots[0].ID, "AIDA1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := snapshots[0].Recreate, "alice = create user name=alice\nattach user name=alice group=admins"; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+
+	snapshots, err = snapshotInGraph(g, "group", map[string]interface{}{"name": []interface{}{"admins", "devs"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(snapshots), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := snapshots[0].Recreate, "admins = create group name=admins\nattach user name=alice group=admins"; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+	if got, want := snapshots[1].Recreate, ""; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got, want := snapshots[1].Unrecreatable, []string{"group devs: not found in the local graph (run `awless sync` to update it)"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -25,8 +25,13 @@ func NewRunner(tpl *template.Template, msg, tplPath string, fillers ...map[strin
 	runner.Message = msg
 	runner.TemplatePath = tplPath
 	runner.Fillers = fillers
+	runner.Parallelism = runParallelFlag
+	runner.RollbackOnFailure = rollbackOnFailureFlag
 	runner.AliasFunc = resolveAliasFunc
-	runner.MissingHolesFunc = missingHolesStdinFunc()
+	runner.MissingHolesFunc = missingHolesStdinFunc(tpl.ParamDeclarations()...)
+	runner.MissingSecretHolesFunc = missingSecretHolesStdinFunc()
+	runner.IncludeFunc = includeTemplateFunc(tplPath)
+	runner.SnapshotFunc = snapshotDeletedFunc
 
 	runner.Validators = []template.Validator{
 		&template.UniqueNameValidator{LookupGraph: func(key string) (*graph.Graph, bool) {
@@ -97,7 +102,13 @@ func NewRunner(tpl *template.Template, msg, tplPath string, fillers ...map[strin
 			logger.Errorf("Cannot save executed template in awless logs: %s", err)
 		}
 
-		if template.IsRevertible(tplExec.Template) {
+		if len(tplExec.Outputs) > 0 {
+			fmt.Println()
+			logger.Info("Outputs:")
+			(&outputsLogPrinter{w: os.Stdout}).print(tplExec)
+		}
+
+		if template.IsRevertible(tplExec.Template) && tplExec.RolledBackBy == "" {
 			fmt.Println()
 			logger.Infof("Revert this template with `awless revert %s`", tplExec.Template.ID)
 		}
@@ -0,0 +1,94 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/test"
+	"github.com/wallix/awless/logger"
+)
+
+func init() {
+	RootCmd.AddCommand(testCmd)
+}
+
+var testCmd = &cobra.Command{
+	Use:              "test DIR",
+	Short:            "Run offline, against mocked AWS APIs, the templates of a directory having a *.test.yml expectations file",
+	Example:          "  awless test ~/templates\n  awless test .",
+	PersistentPreRun: applyHooks(initLoggerHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("missing DIR arg")
+		}
+
+		if failed := runTemplateTests(os.Stdout, args[0]); failed > 0 {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+// runTemplateTests runs the *.test.yml files found in dir, returning the number of failed ones
+func runTemplateTests(w io.Writer, dir string) (failed int) {
+	var tests []string
+	var untested int
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		switch {
+		case strings.HasSuffix(path, awstest.TemplateTestSuffix):
+			tests = append(tests, path)
+		case filepath.Ext(path) == ".aws":
+			if _, err := os.Stat(strings.TrimSuffix(path, ".aws") + awstest.TemplateTestSuffix); os.IsNotExist(err) {
+				logger.Verbosef("no test for template %s", path)
+				untested++
+			}
+		}
+		return nil
+	})
+	exitOn(err)
+
+	for _, path := range tests {
+		test, err := awstest.LoadTemplateTest(path)
+		if err == nil {
+			err = test.Run(resolveAliasInGraph)
+		}
+		if err != nil {
+			failed++
+			fmt.Fprintf(w, "%s %s: %s\n", renderRedFn("FAIL"), path, err)
+		} else {
+			fmt.Fprintf(w, "%s %s\n", renderGreenFn("PASS"), path)
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d passed, %d failed", len(tests)-failed, failed)
+	if untested > 0 {
+		fmt.Fprintf(w, ", %d template(s) without test", untested)
+	}
+	fmt.Fprintln(w)
+	return
+}
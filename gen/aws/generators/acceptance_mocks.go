@@ -130,7 +130,7 @@ func generateAcceptanceMocks() {
 				}
 				functions = append(functions, functionInfo{
 					Name:         meth.Name(),
-					Sig:          fmt.Sprintf("func (m *%sMock) %s(%s) %s", api, meth.Name(), paramBuff.String(), returnsBuff.String()),
+					Sig:          fmt.Sprintf("func (m *%sMock) %s(%s) %s", strings.Title(api), meth.Name(), paramBuff.String(), returnsBuff.String()),
 					AnonymousSig: fmt.Sprintf("func (%s) %s", paramBuff.String(), returnsBuff.String()),
 					ParamNames:   paramNames,
 				})
@@ -141,7 +141,7 @@ func generateAcceptanceMocks() {
 	}
 
 	templ, err := template.New("mocks").Funcs(
-		template.FuncMap{"Join": strings.Join},
+		template.FuncMap{"Join": strings.Join, "Title": strings.Title},
 	).Parse(atMocksTemplate)
 	if err != nil {
 		panic(err)
@@ -152,7 +152,7 @@ func generateAcceptanceMocks() {
 		panic(err)
 	}
 
-	if err = ioutil.WriteFile(filepath.Join(AWSAT_DIR, "gen_mocks.go"), buff.Bytes(), 0666); err != nil {
+	if err = ioutil.WriteFile(filepath.Join(AWSTEST_DIR, "gen_mocks.go"), buff.Bytes(), 0666); err != nil {
 		panic(err)
 	}
 }
@@ -186,7 +186,7 @@ func generateAcceptanceFactory() {
 		panic(err)
 	}
 
-	if err = ioutil.WriteFile(filepath.Join(AWSAT_DIR, "gen_factory.go"), buff.Bytes(), 0666); err != nil {
+	if err = ioutil.WriteFile(filepath.Join(AWSTEST_DIR, "gen_factory.go"), buff.Bytes(), 0666); err != nil {
 		panic(err)
 	}
 }
@@ -221,28 +221,28 @@ limitations under the License.
 
 // DO NOT EDIT
 // This file was automatically generated with go generate
-package awsat
+package awstest
 
 import (
   "github.com/wallix/awless/aws/spec"
   "github.com/wallix/awless/cloud/graph"
 )
 
-type AcceptanceFactory struct {
+type Factory struct {
 	Mock   interface{}
 	Logger *logger.Logger
 	Graph cloudgraph.GraphAPI
 }
 
-func NewAcceptanceFactory(mock interface{}, g cloudgraph.GraphAPI, l ...*logger.Logger) *AcceptanceFactory {
+func NewFactory(mock interface{}, g cloudgraph.GraphAPI, l ...*logger.Logger) *Factory {
 	logger := logger.DiscardLogger
 	if len(l) > 0 {
 		logger = l[0]
 	}
-	return &AcceptanceFactory{Mock: mock, Graph:g, Logger: logger}
+	return &Factory{Mock: mock, Graph:g, Logger: logger}
 }
 
-func (f *AcceptanceFactory) Build(key string) func() interface{} {
+func (f *Factory) Build(key string) func() interface{} {
 	switch key {
 		{{- range $cmdName, $cmd := . }}
 		case "{{ $cmd.Action }}{{ $cmd.Entity }}":
@@ -274,12 +274,12 @@ limitations under the License.
 
 // DO NOT EDIT
 // This file was automatically generated with go generate
-package awsat
+package awstest
 
 
 {{ range $api, $apiInfo := . }}
 
-type {{ $api }}Mock struct {
+type {{ Title $api }}Mock struct {
   basicMock
   {{ $api }}iface.{{ $apiInfo.IfaceName }}
   {{- range $, $method := $apiInfo.Methods }}
@@ -296,4 +296,9 @@ type {{ $api }}Mock struct {
 {{ end }}
 {{- end }}
 
+var mocksByAPI = map[string]func() Mock{
+{{- range $api, $apiInfo := . }}
+	"{{ $api }}": func() Mock { return &{{ Title $api }}Mock{} },
+{{- end }}
+}
 `
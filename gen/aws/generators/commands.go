@@ -107,6 +107,7 @@ func generateCommands() {
 type cmdData struct {
 	Action, Entity, API, Call, Input, Output string
 	Params                                   []templateParam
+	SecretParams                             []string
 	HasDryRun                                bool
 	GenDryRun                                bool
 }
@@ -115,6 +116,7 @@ type templateParam struct {
 	Name       string
 	AwsField   string
 	IsRequired bool
+	IsSecret   bool
 }
 
 type findStructs struct {
@@ -146,6 +148,11 @@ func (v *findStructs) Visit(node ast.Node) (w ast.Visitor) {
 					})
 					cmd.Params = params
 				}
+				for _, p := range params {
+					if p.IsSecret {
+						cmd.SecretParams = append(cmd.SecretParams, p.Name)
+					}
+				}
 				v.result[typ.Name.Name] = *cmd
 			}
 		}
@@ -191,6 +198,9 @@ func extractParam(s string) (p templateParam) {
 	if _, ok := tags["required"]; ok {
 		p.IsRequired = true
 	}
+	if _, ok := tags["secret"]; ok {
+		p.IsSecret = true
+	}
 	if v, ok := tags["awsName"]; ok {
 		p.AwsField = v
 	}
@@ -214,7 +224,7 @@ func extractTags(s string) map[string]string {
 	splits := strings.Split(s[1:len(s)-1], " ")
 	tags := make(map[string]string)
 	for _, e := range splits {
-		el := strings.Split(e, ":")
+		el := strings.SplitN(e, ":", 2)
 		if len(el) > 1 {
 			if len(el[1]) < 2 || el[1][0] != '"' || el[1][len(el[1])-1] != '"' {
 				panic(fmt.Sprintf("malformed tag: '%s':'%s'", el[0], el[1]))
@@ -370,6 +380,12 @@ func (cmd *{{ $cmdName }}) ParamsHelp() string {
 func (cmd *{{ $cmdName }}) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
+{{- if $tag.SecretParams }}
+
+func (cmd *{{ $cmdName }}) SecretParams() []string {
+	return []string{ {{- range $param := $tag.SecretParams }}"{{ $param }}", {{- end}} }
+}
+{{- end }}
 {{ end }}
 `
 
@@ -423,7 +439,7 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 		return func() interface{} { return New{{ $cmdName }}(f.Sess, f.Graph, f.Log) }
 	{{- end}}
 	}
-	return nil
+	return f.buildCheckResource(key)
 }
 
 var (
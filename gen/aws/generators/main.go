@@ -18,8 +18,8 @@
 //go:generate gofmt -s -w ../../../aws/spec
 //go:generate goimports -w ../../../aws/spec
 
-//go:generate gofmt -s -w ../../../acceptance/aws
-//go:generate goimports -w ../../../acceptance/aws
+//go:generate gofmt -s -w ../../../aws/test
+//go:generate goimports -w ../../../aws/test
 
 package main
 
@@ -34,7 +34,7 @@ var (
 	FETCHERS_DIR         = filepath.Join(ROOT_DIR, "aws", "fetch")
 	SERVICES_DIR         = filepath.Join(ROOT_DIR, "aws", "services")
 	SPEC_DIR             = filepath.Join(ROOT_DIR, "aws", "spec")
-	AWSAT_DIR            = filepath.Join(ROOT_DIR, "acceptance", "aws")
+	AWSTEST_DIR          = filepath.Join(ROOT_DIR, "aws", "test")
 	DOC_DIR              = filepath.Join(ROOT_DIR, "aws", "doc")
 	CLOUD_PROPERTIES_DIR = filepath.Join(ROOT_DIR, "cloud", "properties")
 	CLOUD_RDF_DIR        = filepath.Join(ROOT_DIR, "cloud", "rdf")
@@ -0,0 +1,327 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cloudformation exports awless templates as CloudFormation templates
+package cloudformation
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+const formatVersion = "2010-09-09"
+
+// Template is a CloudFormation template, to be marshalled to JSON
+type Template struct {
+	AWSTemplateFormatVersion string
+	Description              string                `json:",omitempty"`
+	Parameters               map[string]*Parameter `json:",omitempty"`
+	Resources                map[string]*Resource
+	Outputs                  map[string]*Output `json:",omitempty"`
+}
+
+type Parameter struct {
+	Type          string
+	Description   string   `json:",omitempty"`
+	AllowedValues []string `json:",omitempty"`
+}
+
+type Resource struct {
+	Type       string
+	Properties map[string]interface{} `json:",omitempty"`
+}
+
+type Output struct {
+	Value interface{}
+}
+
+// Mapping describes the CloudFormation resource created by a command:
+// its type, the attribute returned by the command (its Ref when empty),
+// and the CloudFormation property of each param ("Tags.Name" being a Name tag)
+type Mapping struct {
+	Type, Result string
+	Properties   map[string]string
+}
+
+// MappingFunc returns the mapping of the commands of an action and entity, false when unsupported
+type MappingFunc func(action, entity string) (*Mapping, bool)
+
+// Unsupported is a statement, or a param of a statement, with no CloudFormation equivalent
+type Unsupported struct {
+	Line      int
+	Statement string
+	Param     string
+	Reason    string
+}
+
+func (u *Unsupported) String() string {
+	var buff bytes.Buffer
+	if u.Line > 0 {
+		fmt.Fprintf(&buff, "line %d: ", u.Line)
+	}
+	buff.WriteString(u.Statement)
+	if u.Param != "" {
+		fmt.Fprintf(&buff, ": param '%s'", u.Param)
+	}
+	fmt.Fprintf(&buff, ": %s", u.Reason)
+	return buff.String()
+}
+
+// Export maps the creations of a template, compiled with template.ExportCompileMode, to CloudFormation resources.
+// References become Ref or Fn::GetAtt and unresolved holes become parameters.
+// The statements and params which cannot be exported are returned, not to be silently dropped.
+func Export(tpl *template.Template, mapping MappingFunc) (*Template, []*Unsupported) {
+	e := &exporter{
+		cfn: &Template{
+			AWSTemplateFormatVersion: formatVersion,
+			Parameters:               make(map[string]*Parameter),
+			Resources:                make(map[string]*Resource),
+			Outputs:                  make(map[string]*Output),
+		},
+		mapping:    mapping,
+		usedIDs:    make(map[string]bool),
+		holeParams: make(map[string]string),
+		declared:   make(map[string]*declaredResource),
+		paramDecls: make(map[string]*template.ParamDeclaration),
+	}
+	for _, decl := range tpl.ParamDeclarations() {
+		e.paramDecls[decl.Name] = decl
+	}
+	for _, st := range tpl.Statements {
+		e.exportStatement(st)
+	}
+	if len(e.cfn.Parameters) == 0 {
+		e.cfn.Parameters = nil
+	}
+	if len(e.cfn.Outputs) == 0 {
+		e.cfn.Outputs = nil
+	}
+	return e.cfn, e.unsupported
+}
+
+type declaredResource struct {
+	id, result string
+}
+
+type exporter struct {
+	cfn         *Template
+	mapping     MappingFunc
+	unsupported []*Unsupported
+	usedIDs     map[string]bool
+	holeParams  map[string]string
+	declared    map[string]*declaredResource
+	paramDecls  map[string]*template.ParamDeclaration
+}
+
+func (e *exporter) exportStatement(st *ast.Statement) {
+	switch n := st.Node.(type) {
+	case *ast.CommandNode:
+		e.exportCommand(st, "", n)
+	case *ast.DeclarationNode:
+		if cmd, ok := n.Expr.(*ast.CommandNode); ok {
+			e.exportCommand(st, n.Ident, cmd)
+		}
+	case *ast.OutputNode:
+		val, err := e.value(n.Value)
+		if err != nil {
+			e.report(st, "", err.Error())
+			return
+		}
+		e.cfn.Outputs[e.logicalID(n.Name)] = &Output{Value: val}
+	}
+}
+
+func (e *exporter) exportCommand(st *ast.Statement, ident string, cmd *ast.CommandNode) {
+	if cmd.Action != "create" {
+		e.report(st, "", fmt.Sprintf("only creations can be exported, not '%s'", cmd.Action))
+		return
+	}
+	m, ok := e.mapping(cmd.Action, cmd.Entity)
+	if !ok {
+		e.report(st, "", fmt.Sprintf("no CloudFormation resource for %s", cmd.Entity))
+		return
+	}
+
+	name := ident
+	if name == "" {
+		name = cmd.Entity
+	}
+	id := e.logicalID(name)
+	res := &Resource{Type: m.Type, Properties: make(map[string]interface{})}
+
+	var keys []string
+	for k := range cmd.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		prop, ok := m.Properties[k]
+		if !ok {
+			e.report(st, k, fmt.Sprintf("no CloudFormation property of %s", m.Type))
+			continue
+		}
+		val, err := e.value(cmd.Params[k])
+		if err != nil {
+			e.report(st, k, err.Error())
+			continue
+		}
+		setProperty(res.Properties, prop, val)
+	}
+
+	e.cfn.Resources[id] = res
+	if ident != "" {
+		e.declared[ident] = &declaredResource{id: id, result: m.Result}
+	}
+}
+
+func (e *exporter) report(st *ast.Statement, param, reason string) {
+	e.unsupported = append(e.unsupported, &Unsupported{Line: st.Line, Statement: st.String(), Param: param, Reason: reason})
+}
+
+func (e *exporter) value(v ast.CompositeValue) (interface{}, error) {
+	switch vv := v.(type) {
+	case ast.ListValue:
+		var list []interface{}
+		for _, elem := range vv.Elems() {
+			val, err := e.value(elem)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, val)
+		}
+		return list, nil
+	case ast.MapValue:
+		m := make(map[string]interface{})
+		for _, k := range vv.Keys() {
+			val, err := e.value(vv.Elem(k))
+			if err != nil {
+				return nil, err
+			}
+			m[k] = val
+		}
+		return m, nil
+	case ast.ConcatenationValue:
+		var parts []interface{}
+		resolved := true
+		for _, part := range vv.Parts() {
+			val, err := e.value(part)
+			if err != nil {
+				return nil, err
+			}
+			if _, isString := val.(string); !isString {
+				resolved = false
+			}
+			parts = append(parts, val)
+		}
+		if resolved {
+			return vv.Value(), nil
+		}
+		return map[string]interface{}{"Fn::Join": []interface{}{"", parts}}, nil
+	}
+
+	if withHoles, ok := v.(ast.WithHoles); ok {
+		for hole := range withHoles.GetHoles() {
+			return map[string]interface{}{"Ref": e.holeParam(hole)}, nil
+		}
+	}
+	if withRefs, ok := v.(ast.WithRefs); ok {
+		for _, ref := range withRefs.GetRefs() {
+			res, ok := e.declared[ref]
+			if !ok {
+				return nil, fmt.Errorf("$%s is not an exported resource", ref)
+			}
+			if res.result != "" {
+				return map[string]interface{}{"Fn::GetAtt": []string{res.id, res.result}}, nil
+			}
+			return map[string]interface{}{"Ref": res.id}, nil
+		}
+	}
+	if withAlias, ok := v.(ast.WithAlias); ok {
+		if aliases := withAlias.GetAliases(); len(aliases) > 0 {
+			return nil, fmt.Errorf("unresolved alias @%s", aliases[0])
+		}
+	}
+	return v.Value(), nil
+}
+
+func (e *exporter) holeParam(hole string) string {
+	if id, ok := e.holeParams[hole]; ok {
+		return id
+	}
+	id := e.logicalID(hole)
+	param := &Parameter{Type: "String", Description: hole}
+	if decl, ok := e.paramDecls[hole]; ok {
+		if decl.Description != "" {
+			param.Description = decl.Description
+		}
+		param.AllowedValues = decl.AllowedValues
+	}
+	e.cfn.Parameters[id] = param
+	e.holeParams[hole] = id
+	return id
+}
+
+// logicalID returns a unique alphanumeric identifier from a name, as in "MyVpc" for "my_vpc"
+func (e *exporter) logicalID(name string) string {
+	var buff bytes.Buffer
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) || r > unicode.MaxASCII {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		buff.WriteRune(r)
+	}
+	base := buff.String()
+	if base == "" {
+		base = "Resource"
+	}
+	id := base
+	for i := 2; e.usedIDs[id]; i++ {
+		id = fmt.Sprintf("%s%d", base, i)
+	}
+	e.usedIDs[id] = true
+	return id
+}
+
+func setProperty(props map[string]interface{}, path string, val interface{}) {
+	if strings.HasPrefix(path, "Tags.") {
+		tags, _ := props["Tags"].([]interface{})
+		props["Tags"] = append(tags, map[string]interface{}{"Key": strings.TrimPrefix(path, "Tags."), "Value": val})
+		return
+	}
+	splits := strings.SplitN(path, ".", 2)
+	if len(splits) == 1 {
+		props[path] = val
+		return
+	}
+	nested, ok := props[splits[0]].(map[string]interface{})
+	if !ok {
+		nested = make(map[string]interface{})
+		props[splits[0]] = nested
+	}
+	setProperty(nested, splits[1], val)
+}
@@ -0,0 +1,108 @@
+package cloudformation
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+)
+
+func awsMapping(action, entity string) (*Mapping, bool) {
+	build := awsspec.MockAWSSessionFactory.Build(action + entity)
+	if build == nil {
+		return nil, false
+	}
+	res, ok := awsspec.CloudFormationResourceOf(build())
+	if !ok {
+		return nil, false
+	}
+	return &Mapping{Type: res.Type, Result: res.Result, Properties: res.Properties}, true
+}
+
+func exportText(t *testing.T, text string, fillers map[string]interface{}) (string, []*Unsupported) {
+	tpl, err := template.Parse(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := template.NewEnv()
+	env.Log = logger.DiscardLogger
+	env.AddFillers(fillers)
+	env.Lookuper = func(tokens ...string) interface{} {
+		return awsspec.MockAWSSessionFactory.Build(strings.Join(tokens, ""))()
+	}
+	compiled, _, err := template.Compile(tpl, env, template.ExportCompileMode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfn, unsupported := Export(compiled, awsMapping)
+	b, err := json.Marshal(cfn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b), unsupported
+}
+
+func TestExport(t *testing.T) {
+	text := `vpc = create vpc cidr=10.0.0.0/16 name=my-vpc
+my_subnet = create subnet cidr={subnet.cidr} vpc=$vpc name={env}+"-subnet"
+ip = create elasticip domain=vpc
+create natgateway elasticip-id=$ip subnet=$my_subnet
+create targetgroup name=tg port=80 protocol=HTTP vpc=$vpc matcher=200
+output subnet = $my_subnet`
+
+	got, unsupported := exportText(t, text, map[string]interface{}{"subnet.cidr": "10.0.1.0/24"})
+	if len(unsupported) > 0 {
+		t.Fatalf("unexpected unsupported %v", unsupported)
+	}
+	exp := `{"AWSTemplateFormatVersion":"2010-09-09",
+"Parameters":{"Env":{"Type":"String","Description":"env"}},
+"Resources":{
+"Ip":{"Type":"AWS::EC2::EIP","Properties":{"Domain":"vpc"}},
+"MySubnet":{"Type":"AWS::EC2::Subnet","Properties":{"CidrBlock":"10.0.1.0/24","Tags":[{"Key":"Name","Value":{"Fn::Join":["",[{"Ref":"Env"},"-subnet"]]}}],"VpcId":{"Ref":"Vpc"}}},
+"Natgateway":{"Type":"AWS::EC2::NatGateway","Properties":{"AllocationId":{"Fn::GetAtt":["Ip","AllocationId"]},"SubnetId":{"Ref":"MySubnet"}}},
+"Targetgroup":{"Type":"AWS::ElasticLoadBalancingV2::TargetGroup","Properties":{"Matcher":{"HttpCode":200},"Name":"tg","Port":80,"Protocol":"HTTP","VpcId":{"Ref":"Vpc"}}},
+"Vpc":{"Type":"AWS::EC2::VPC","Properties":{"CidrBlock":"10.0.0.0/16","Tags":[{"Key":"Name","Value":"my-vpc"}]}}},
+"Outputs":{"Subnet":{"Value":{"Ref":"MySubnet"}}}}`
+	assertJSONEqual(t, got, exp)
+}
+
+func TestExportReportsUnsupported(t *testing.T) {
+	text := `key = create keypair name=my-key
+inst = create instance image=ami-123 type=t2.micro name=web subnet=sub-1 keypair=$key count=1
+start instance id=$inst`
+
+	got, unsupported := exportText(t, text, nil)
+	var reported []string
+	for _, u := range unsupported {
+		reported = append(reported, u.String())
+	}
+	exp := []string{
+		"line 1: key = create keypair name=my-key: no CloudFormation resource for keypair",
+		"line 2: inst = create instance count=1 image=ami-123 keypair=$key name=web subnet=sub-1 type=t2.micro: param 'count': no CloudFormation property of AWS::EC2::Instance",
+		"line 2: inst = create instance count=1 image=ami-123 keypair=$key name=web subnet=sub-1 type=t2.micro: param 'keypair': $key is not an exported resource",
+		"line 3: start instance id=$inst: only creations can be exported, not 'start'",
+	}
+	if !reflect.DeepEqual(reported, exp) {
+		t.Fatalf("got\n%s\nwant\n%s", strings.Join(reported, "\n"), strings.Join(exp, "\n"))
+	}
+	assertJSONEqual(t, got, `{"AWSTemplateFormatVersion":"2010-09-09","Resources":{"Inst":{"Type":"AWS::EC2::Instance","Properties":{
+"ImageId":"ami-123","InstanceType":"t2.micro","SubnetId":"sub-1","Tags":[{"Key":"Name","Value":"web"}]}}}}`)
+}
+
+func assertJSONEqual(t *testing.T, got, want string) {
+	t.Helper()
+	var g, w interface{}
+	if err := json.Unmarshal([]byte(got), &g); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+}
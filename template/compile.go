@@ -3,6 +3,8 @@ package template
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"sort"
 	"strings"
 
@@ -13,17 +15,26 @@ import (
 type LookupFunc func(...string) interface{}
 
 type Env struct {
-	Lookuper LookupFunc
-	IsDryRun bool
+	Lookuper    LookupFunc
+	IsDryRun    bool
+	Parallelism int
 
 	ResolvedVariables map[string]interface{}
 
 	Fillers          map[string]interface{}
 	AliasFunc        func(entity, key, alias string) string
 	MissingHolesFunc func(string, []string) interface{}
-	Log              *logger.Logger
+	// MissingSecretHolesFunc is used instead of MissingHolesFunc for secret holes, not to echo their values
+	MissingSecretHolesFunc func(string, []string) interface{}
+	IncludeFunc            func(path, from string) (text string, fullPath string, err error)
+	Log                    *logger.Logger
+	// SnapshotFunc returns the states of the resources a delete is about to remove
+	SnapshotFunc func(entity string, params map[string]interface{}) ([]*Snapshot, error)
 
 	processedFillers map[string]interface{}
+	params           map[string]*ast.ParamNode
+	resumed          []*ast.Statement
+	resumeIndex      int
 }
 
 func NewEnv() *Env {
@@ -61,6 +72,23 @@ func (e *Env) addToProcessedFillers(fills ...map[string]interface{}) {
 	}
 }
 
+func (e *Env) declareParam(n *ast.ParamNode) {
+	if e.params == nil {
+		e.params = make(map[string]*ast.ParamNode)
+	}
+	e.params[n.Name] = n
+}
+
+// checkFiller verifies a value supplied for a hole against its param declaration, if any
+func (e *Env) checkFiller(hole string, value interface{}) error {
+	if param, declared := e.params[hole]; declared {
+		if err := param.Check(value); err != nil {
+			return fmt.Errorf("invalid value for {%s}: %s", hole, err)
+		}
+	}
+	return nil
+}
+
 func (e *Env) GetProcessedFillers() (copy map[string]interface{}) {
 	copy = make(map[string]interface{}, 0)
 	for k, v := range e.processedFillers {
@@ -73,6 +101,7 @@ type Mode []compileFunc
 
 var (
 	TestCompileMode = []compileFunc{
+		expandBlocksPass,
 		verifyCommandsDefinedPass,
 		failOnDeclarationWithNoResultPass,
 		validateCommandsParamsPass,
@@ -82,24 +111,45 @@ var (
 		resolveMissingHolesPass,
 		resolveAliasPass,
 		inlineVariableValuePass,
+		evaluateFunctionsPass,
 	}
 
 	NewRunnerCompileMode = []compileFunc{
+		expandBlocksPass,
 		verifyCommandsDefinedPass,
 		failOnDeclarationWithNoResultPass,
 		validateCommandsParamsPass,
 		normalizeMissingRequiredParamsAsHolePass,
+		markSecretParamsPass,
 		checkInvalidReferenceDeclarationsPass,
 		resolveHolesPass,
 		resolveMissingHolesPass,
 		resolveAliasPass,
 		inlineVariableValuePass,
+		evaluateFunctionsPass,
 		failOnUnresolvedHolesPass,
 		failOnUnresolvedAliasPass,
 		convertParamsPass,
 		validateCommandsPass,
 		injectCommandsPass,
 	}
+
+	// ExportCompileMode resolves a template to be exported to another format, as CloudFormation.
+	// Its unresolved holes are kept, to become parameters of the exported template, so its params
+	// are neither converted nor validated, and its commands are not injected since they are not run.
+	ExportCompileMode = []compileFunc{
+		expandBlocksPass,
+		verifyCommandsDefinedPass,
+		failOnDeclarationWithNoResultPass,
+		validateCommandsParamsPass,
+		normalizeMissingRequiredParamsAsHolePass,
+		checkInvalidReferenceDeclarationsPass,
+		resolveHolesPass,
+		resolveAliasPass,
+		inlineVariableValuePass,
+		evaluateFunctionsPass,
+		failOnUnresolvedAliasPass,
+	}
 )
 
 func Compile(tpl *Template, env *Env, mode ...Mode) (*Template, *Env, error) {
@@ -137,6 +187,185 @@ func (p *multiPass) compile(tpl *Template, env *Env) (newTpl *Template, newEnv *
 	return
 }
 
+// expandBlocksPass evaluates the conditions of if/else blocks and the lists of
+// for loops, replacing each block with the statements it actually produces.
+// Conditions and lists can only use holes, values and variables declared with values.
+// Included templates are loaded and inlined in place of the include statements.
+func expandBlocksPass(tpl *Template, env *Env) (*Template, *Env, error) {
+	expander := &blocksExpander{env: env, values: make(map[string]ast.CompositeValue), declared: ast.DeclaredIdentifiers(tpl.Statements)}
+	expanded, err := expander.expand(tpl.Statements)
+	if err != nil {
+		return tpl, env, err
+	}
+	tpl.Statements = expanded
+	return tpl, env, nil
+}
+
+type blocksExpander struct {
+	env       *Env
+	values    map[string]ast.CompositeValue
+	includes  []string // full paths of the templates being included, to detect cycles
+	anonymous int
+	declared  map[string]bool
+}
+
+func (e *blocksExpander) resolve(v ast.CompositeValue) (interface{}, error) {
+	return resolveCompileTimeValue(v, e.values, e.env)
+}
+
+func (e *blocksExpander) expand(statements []*ast.Statement) (expanded []*ast.Statement, err error) {
+	for _, st := range statements {
+		switch n := st.Node.(type) {
+		case *ast.IfNode:
+			cond, err := n.Condition.Evaluate(e.resolve)
+			if err != nil {
+				return nil, locatedErr(st.Position(), fmt.Errorf("if %s: %s", n.Condition, err))
+			}
+			branch, err := e.expand(n.Branch(cond))
+			if err != nil {
+				return nil, err
+			}
+			expanded = append(expanded, branch...)
+		case *ast.ForNode:
+			items, err := n.Items(e.resolve)
+			if err != nil {
+				return nil, locatedErr(st.Position(), fmt.Errorf("for %s in %s: %s", n.Var, n.List, err))
+			}
+			unrolled, err := e.expand(n.Unroll(items, e.declared))
+			if err != nil {
+				return nil, err
+			}
+			expanded = append(expanded, unrolled...)
+		case *ast.IncludeNode:
+			included, err := e.include(n)
+			if err != nil {
+				if isLocated(err) { // in the included template
+					return nil, err
+				}
+				return nil, locatedErr(st.Position(), fmt.Errorf("include %s: %s", n.Path, err))
+			}
+			expanded = append(expanded, included...)
+		case *ast.ParamNode:
+			if err := e.declare(n); err != nil {
+				return nil, locatedErr(st.Position(), fmt.Errorf("param %s: %s", n.Name, err))
+			}
+		case *ast.CommentNode:
+		case *ast.DeclarationNode:
+			if value, isValue := n.Expr.(*ast.ValueNode); isValue {
+				e.values[n.Ident] = value.Value
+			}
+			expanded = append(expanded, st)
+		default:
+			expanded = append(expanded, st)
+		}
+	}
+	return
+}
+
+// declare checks the fillers given for a declared param, or fills it with its default value
+func (e *blocksExpander) declare(n *ast.ParamNode) error {
+	if !contains(ast.ParamTypes, n.Type) {
+		return fmt.Errorf("unknown type '%s', expected one of %s", n.Type, strings.Join(ast.ParamTypes, ", "))
+	}
+	e.env.declareParam(n)
+	if n.Default != nil {
+		def, err := e.resolve(n.Default)
+		if err != nil {
+			return err
+		}
+		if err := n.Check(def); err != nil {
+			return fmt.Errorf("invalid default value: %s", err)
+		}
+		if _, filled := e.env.Fillers[n.Name]; !filled {
+			e.env.AddFillers(map[string]interface{}{n.Name: def})
+		}
+	}
+	if value, filled := e.env.Fillers[n.Name]; filled {
+		return e.env.checkFiller(n.Name, value)
+	}
+	return nil
+}
+
+func (e *blocksExpander) include(n *ast.IncludeNode) ([]*ast.Statement, error) {
+	if e.env.IncludeFunc == nil {
+		return nil, errors.New("no loader for included templates")
+	}
+	var from string
+	if len(e.includes) > 0 {
+		from = e.includes[len(e.includes)-1]
+	}
+	text, fullPath, err := e.env.IncludeFunc(n.Path, from)
+	if err != nil {
+		return nil, err
+	}
+	if contains(e.includes, fullPath) {
+		return nil, fmt.Errorf("cycle detected: %s -> %s", strings.Join(e.includes, " -> "), fullPath)
+	}
+	included, err := ParseFile(fullPath, text)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := n.Prefix
+	if prefix == "" { // variables of anonymous includes are private
+		e.anonymous++
+		prefix = fmt.Sprintf("include%d", e.anonymous)
+	}
+
+	scope := e.values
+	e.values = make(map[string]ast.CompositeValue)
+	for k, v := range scope {
+		e.values[k] = v
+	}
+	e.includes = append(e.includes, fullPath)
+	defer func() {
+		e.values = scope
+		e.includes = e.includes[:len(e.includes)-1]
+	}()
+
+	return e.expand(n.Inline(included.Statements, prefix))
+}
+
+func resolveCompileTimeValue(v ast.CompositeValue, values map[string]ast.CompositeValue, env *Env) (interface{}, error) {
+	clone := v.Clone()
+	if withRefs, ok := clone.(ast.WithRefs); ok {
+		for _, ref := range withRefs.GetRefs() {
+			declared, ok := values[ref]
+			if !ok {
+				return nil, fmt.Errorf("'$%s' is not a value known at compile time", ref)
+			}
+			resolved, err := resolveCompileTimeValue(declared, values, env)
+			if err != nil {
+				return nil, err
+			}
+			withRefs.ProcessRefs(map[string]interface{}{ref: resolved})
+		}
+	}
+	if withHoles, ok := clone.(ast.WithHoles); ok {
+		env.addToProcessedFillers(withHoles.ProcessHoles(env.Fillers))
+		var missing []string
+		for hole := range withHoles.GetHoles() {
+			missing = append(missing, hole)
+		}
+		sort.Strings(missing)
+		for _, hole := range missing {
+			if env.MissingHolesFunc == nil {
+				return nil, fmt.Errorf("unresolved hole '%s'", hole)
+			}
+			value := env.MissingHolesFunc(hole, nil)
+			if err := env.checkFiller(hole, value); err != nil {
+				return nil, err
+			}
+			env.AddFillers(map[string]interface{}{hole: value})
+		}
+		env.addToProcessedFillers(withHoles.ProcessHoles(env.Fillers))
+	}
+	if clone.Value() == nil {
+		return nil, fmt.Errorf("cannot resolve value of '%s' at compile time", clone)
+	}
+	return clone.Value(), nil
+}
+
 func verifyCommandsDefinedPass(tpl *Template, env *Env) (*Template, *Env, error) {
 	if env.Lookuper == nil {
 		return tpl, env, fmt.Errorf("command lookuper is undefined")
@@ -146,7 +375,7 @@ func verifyCommandsDefinedPass(tpl *Template, env *Env) (*Template, *Env, error)
 		key := fmt.Sprintf("%s%s", node.Action, node.Entity)
 		cmd := env.Lookuper(key)
 		if cmd == nil {
-			return tpl, env, fmt.Errorf("cannot find command for '%s'", key)
+			return tpl, env, locatedErr(tpl.positionOf(node), fmt.Errorf("cannot find command for '%s'", key))
 		}
 	}
 	return tpl, env, nil
@@ -174,7 +403,7 @@ func failOnDeclarationWithNoResultPass(tpl *Template, env *Env) (*Template, *Env
 
 	for _, dcl := range tpl.declarationNodesIterator() {
 		if err := failOnDeclarationWithNoResult(dcl); err != nil {
-			return tpl, env, err
+			return tpl, env, locatedErr(tpl.positionOf(dcl), err)
 		}
 	}
 	return tpl, env, nil
@@ -192,10 +421,19 @@ func validateCommandsParamsPass(tpl *Template, env *Env) (*Template, *Env, error
 		}
 		if v, ok := cmd.(VP); ok {
 			if _, err := v.ValidateParams(node.Keys()); err != nil {
-				return cmdErr(node, err)
+				unexpected := func(val ast.CompositeValue) bool {
+					for k, p := range node.Params {
+						if p == val {
+							_, err := v.ValidateParams([]string{k})
+							return err != nil
+						}
+					}
+					return false
+				}
+				return locatedErr(tpl.valuePositionOf(node, unexpected), cmdErr(node, err))
 			}
 		} else {
-			return cmdErr(node, "command does not implement param validation")
+			return locatedErr(tpl.positionOf(node), cmdErr(node, "command does not implement param validation"))
 		}
 		return nil
 	}
@@ -217,7 +455,7 @@ func normalizeMissingRequiredParamsAsHolePass(tpl *Template, env *Env) (*Templat
 		if v, ok := cmd.(VP); ok {
 			missing, err := v.ValidateParams(node.Keys())
 			if err != nil {
-				return cmdErr(node, err)
+				return locatedErr(tpl.positionOf(node), cmdErr(node, err))
 			}
 			for _, e := range missing {
 				normalized := fmt.Sprintf("%s.%s", node.Entity, e)
@@ -255,7 +493,7 @@ func convertParamsPass(tpl *Template, env *Env) (*Template, *Env, error) {
 			}
 			converted, err := convFunc(values)
 			if err != nil {
-				return cmdErr(node, err)
+				return locatedErr(tpl.positionOf(node), cmdErr(node, err))
 			}
 			for _, k := range keys {
 				delete(node.Params, k)
@@ -290,7 +528,7 @@ func validateCommandsPass(tpl *Template, env *Env) (*Template, *Env, error) {
 				}
 			}
 			for _, validErr := range v.ValidateCommand(node.ToDriverParams(), refsKey) {
-				errs = append(errs, fmt.Errorf("%s %s: %s", node.Action, node.Entity, validErr.Error()))
+				errs = append(errs, locatedErr(tpl.positionOf(node), fmt.Errorf("%s %s: %s", node.Action, node.Entity, validErr.Error())))
 			}
 		}
 		return nil
@@ -302,11 +540,16 @@ func validateCommandsPass(tpl *Template, env *Env) (*Template, *Env, error) {
 	case 0:
 		return tpl, env, nil
 	case 1:
+		if located, ok := errs[0].(*ast.PositionError); ok {
+			return tpl, env, &ast.PositionError{Pos: located.Pos, Err: fmt.Errorf("validation error: %s", located.Err)}
+		}
 		return tpl, env, fmt.Errorf("validation error: %s", errs[0])
 	default:
 		var errsSrings []string
 		for _, err := range errs {
-			if err != nil {
+			if located, ok := err.(*ast.PositionError); ok { // without excerpts, to keep the list readable
+				errsSrings = append(errsSrings, fmt.Sprintf("%s: %s", located.Pos, located.Err))
+			} else if err != nil {
 				errsSrings = append(errsSrings, err.Error())
 			}
 		}
@@ -339,7 +582,12 @@ func checkInvalidReferenceDeclarationsPass(tpl *Template, env *Env) (*Template,
 	var each = func(withRef ast.WithRefs) error {
 		for _, ref := range withRef.GetRefs() {
 			if _, ok := knownRefs[ref]; !ok {
-				return fmt.Errorf("using reference '$%s' but '%s' is undefined in template\n", ref, ref)
+				node, _ := withRef.(ast.Node)
+				pos := tpl.valuePositionOf(node, func(v ast.CompositeValue) bool {
+					r, ok := v.(ast.WithRefs)
+					return ok && contains(r.GetRefs(), ref)
+				})
+				return locatedErr(pos, fmt.Errorf("using reference '$%s' but '%s' is undefined in template", ref, ref))
 			}
 		}
 		return nil
@@ -363,7 +611,7 @@ func checkInvalidReferenceDeclarationsPass(tpl *Template, env *Env) (*Template,
 		if decl, isDecl := st.Node.(*ast.DeclarationNode); isDecl {
 			ref := decl.Ident
 			if _, ok := knownRefs[ref]; ok {
-				return tpl, env, fmt.Errorf("using reference '$%s' but '%s' has already been assigned in template\n", ref, ref)
+				return tpl, env, locatedErr(st.Position(), fmt.Errorf("using reference '$%s' but '%s' has already been assigned in template", ref, ref))
 			}
 			knownRefs[ref] = true
 		}
@@ -381,6 +629,9 @@ func inlineVariableValuePass(tpl *Template, env *Env) (*Template, *Env, error) {
 		if isDecl {
 			value, isValue := decl.Expr.(*ast.ValueNode)
 			if isValue {
+				if err := ast.EvaluateValueFunctions(value.Value); err != nil {
+					return tpl, env, err
+				}
 				if val := value.Value.Value(); val != nil {
 					env.ResolvedVariables[decl.Ident] = val
 				}
@@ -402,15 +653,111 @@ func inlineVariableValuePass(tpl *Template, env *Env) (*Template, *Env, error) {
 	return newTpl, env, nil
 }
 
+// Function calls are computed once holes and variables are resolved.
+// Calls depending on command results are computed at runtime.
+func evaluateFunctionsPass(tpl *Template, env *Env) (*Template, *Env, error) {
+	if err := ast.EvaluateFunctions(tpl.Statements); err != nil {
+		return tpl, env, err
+	}
+	return tpl, env, nil
+}
+
+// Params declared secret by their commands, as passwords, are marked
+// for their values never to be printed, logged or persisted. So are the values
+// of the variables they reference, which would otherwise be inlined in clear.
+func markSecretParamsPass(tpl *Template, env *Env) (*Template, *Env, error) {
+	type S interface {
+		SecretParams() []string
+	}
+	secretRefs := make(map[string]string) // referenced variable -> secret hole
+	markSecret := func(v ast.CompositeValue, hole string) ast.CompositeValue {
+		if withRefs, ok := v.(ast.WithRefs); ok {
+			for _, ref := range withRefs.GetRefs() {
+				if _, ok := secretRefs[ref]; !ok {
+					secretRefs[ref] = hole
+				}
+			}
+		}
+		return ast.MarkSecret(v, hole)
+	}
+	tpl.visitCommandNodes(func(node *ast.CommandNode) {
+		cmd, ok := env.Lookuper(fmt.Sprintf("%s%s", node.Action, node.Entity)).(S)
+		if !ok {
+			return
+		}
+		for _, param := range cmd.SecretParams() {
+			if v, ok := node.Params[param]; ok {
+				node.Params[param] = markSecret(v, fmt.Sprintf("%s.%s", node.Entity, param))
+			}
+		}
+	})
+
+	marked := make(map[string]bool)
+	for len(marked) < len(secretRefs) { // until the variables referenced by secret variables are marked
+		before := len(marked)
+		for _, decl := range tpl.declarationNodesIterator() {
+			hole, isSecret := secretRefs[decl.Ident]
+			if !isSecret || marked[decl.Ident] {
+				continue
+			}
+			marked[decl.Ident] = true
+			if value, ok := decl.Expr.(*ast.ValueNode); ok {
+				value.Value = markSecret(value.Value, hole)
+			}
+		}
+		if len(marked) == before { // references to undeclared variables
+			break
+		}
+	}
+	return tpl, env, nil
+}
+
 func resolveHolesPass(tpl *Template, env *Env) (*Template, *Env, error) {
+	fillers := env.Fillers
+	if secrets := ast.SecretHoles(tpl.Statements); len(secrets) > 0 {
+		fillers = make(map[string]interface{})
+		for k, v := range env.Fillers {
+			if secrets[k] {
+				var err error
+				if v, err = readSecret(v); err != nil {
+					return tpl, env, locatedErr(tpl.holePosition(k), fmt.Errorf("secret {%s}: %s", k, err))
+				}
+			}
+			fillers[k] = v
+		}
+	}
+
 	tpl.visitHoles(func(h ast.WithHoles) {
-		processed := h.ProcessHoles(env.Fillers)
+		processed := h.ProcessHoles(fillers)
 		env.addToProcessedFillers(processed)
 	})
 
 	return tpl, env, nil
 }
 
+// readSecret returns the value of a secret filler given as env:VAR or file:PATH,
+// for secrets not to be typed in command lines
+func readSecret(v interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	switch {
+	case !ok:
+		return v, nil
+	case strings.HasPrefix(s, "env:"):
+		name := strings.TrimPrefix(s, "env:")
+		if val := os.Getenv(name); val != "" {
+			return val, nil
+		}
+		return nil, fmt.Errorf("environment variable %s is empty", name)
+	case strings.HasPrefix(s, "file:"):
+		b, err := ioutil.ReadFile(strings.TrimPrefix(s, "file:"))
+		if err != nil {
+			return nil, err
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+	}
+	return v, nil
+}
+
 func resolveMissingHolesPass(tpl *Template, env *Env) (*Template, *Env, error) {
 	uniqueHoles := make(map[string][]string)
 	tpl.visitHoles(func(h ast.WithHoles) {
@@ -430,9 +777,17 @@ func resolveMissingHolesPass(tpl *Template, env *Env) (*Template, *Env, error) {
 	sort.Strings(sortedHoles)
 	fillers := make(map[string]interface{})
 
+	secrets := ast.SecretHoles(tpl.Statements)
 	for _, k := range sortedHoles {
-		if env.MissingHolesFunc != nil {
-			actual := env.MissingHolesFunc(k, uniqueHoles[k])
+		missingHolesFunc := env.MissingHolesFunc
+		if secrets[k] {
+			missingHolesFunc = env.MissingSecretHolesFunc
+		}
+		if missingHolesFunc != nil {
+			actual := missingHolesFunc(k, uniqueHoles[k])
+			if err := env.checkFiller(k, actual); err != nil {
+				return tpl, env, locatedErr(tpl.holePosition(k), err)
+			}
 			fillers[k] = actual
 		}
 	}
@@ -447,6 +802,7 @@ func resolveMissingHolesPass(tpl *Template, env *Env) (*Template, *Env, error) {
 
 func resolveAliasPass(tpl *Template, env *Env) (*Template, *Env, error) {
 	var emptyResolv []string
+	var emptyResolvPos ast.Position
 	resolvAliasFunc := func(entity string, key string) func(string) (string, bool) {
 		return func(alias string) (string, bool) {
 			if env.AliasFunc == nil {
@@ -469,17 +825,23 @@ func resolveAliasPass(tpl *Template, env *Env) (*Template, *Env, error) {
 			for k, v := range ee.Params {
 				if vv, ok := v.(ast.WithAlias); ok {
 					vv.ResolveAlias(resolvAliasFunc(ee.Entity, k))
+					if len(emptyResolv) > 0 && !emptyResolvPos.IsValid() {
+						emptyResolvPos = ast.PositionOf(v)
+					}
 				}
 			}
 		case *ast.ValueNode:
 			if vv, ok := ee.Value.(ast.WithAlias); ok {
 				vv.ResolveAlias(resolvAliasFunc("", ""))
+				if len(emptyResolv) > 0 && !emptyResolvPos.IsValid() {
+					emptyResolvPos = ast.PositionOf(ee.Value)
+				}
 			}
 		}
 	}
 
 	if len(emptyResolv) > 0 {
-		return tpl, env, fmt.Errorf("cannot resolve aliases: %q. Maybe you need to update your local model with `awless sync` ?", emptyResolv)
+		return tpl, env, locatedErr(emptyResolvPos, fmt.Errorf("cannot resolve aliases: %q. Maybe you need to update your local model with `awless sync` ?", emptyResolv))
 	}
 
 	return tpl, env, nil
@@ -500,7 +862,7 @@ func failOnUnresolvedHolesPass(tpl *Template, env *Env) (*Template, *Env, error)
 
 	if len(unresolved) > 0 {
 		sort.Strings(unresolved)
-		return tpl, env, fmt.Errorf("template contains unresolved holes: %v", unresolved)
+		return tpl, env, locatedErr(tpl.holePosition(unresolved[0]), fmt.Errorf("template contains unresolved holes: %v", unresolved))
 	}
 
 	return tpl, env, nil
@@ -508,11 +870,15 @@ func failOnUnresolvedHolesPass(tpl *Template, env *Env) (*Template, *Env, error)
 
 func failOnUnresolvedAliasPass(tpl *Template, env *Env) (*Template, *Env, error) {
 	var unresolved []string
+	var pos ast.Position
 
 	visitAliases := func(withAlias ast.WithAlias) {
 		for _, alias := range withAlias.GetAliases() {
 			unresolved = append(unresolved, alias)
 		}
+		if v, ok := withAlias.(ast.CompositeValue); ok && len(unresolved) > 0 && !pos.IsValid() {
+			pos = ast.PositionOf(v)
+		}
 	}
 
 	for _, n := range tpl.expressionNodesIterator() {
@@ -531,7 +897,7 @@ func failOnUnresolvedAliasPass(tpl *Template, env *Env) (*Template, *Env, error)
 	}
 
 	if len(unresolved) > 0 {
-		return tpl, env, fmt.Errorf("template contains unresolved alias: %v", unresolved)
+		return tpl, env, locatedErr(pos, fmt.Errorf("template contains unresolved alias: %v", unresolved))
 	}
 
 	return tpl, env, nil
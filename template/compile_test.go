@@ -113,6 +113,79 @@ create loadbalancer name=mylb subnets=subnet-1, subnet-2
 			expProcessedFillers:  map[string]interface{}{},
 			expResolvedVariables: map[string]interface{}{},
 		}, //retro-compatibility with old list style, without brackets
+		{
+			tpl: `
+count = {instance.count}
+if $count == 42 {
+  create vpc cidr={test.cidr}
+} else {
+  create vpc cidr=10.0.3.0/24
+}
+`,
+			expect:               `create vpc cidr=10.0.2.0/24`,
+			expProcessedFillers:  map[string]interface{}{"instance.count": 42, "test.cidr": "10.0.2.0/24"},
+			expResolvedVariables: map[string]interface{}{"count": 42},
+		},
+		{
+			tpl: `
+vpc = create vpc cidr=10.0.0.0/16
+for az in [eu-west-1a, eu-west-1b] {
+  sub = create subnet cidr={test.cidr} vpc=$vpc availabilityzone=$az
+  update subnet id=$sub public=true
+}
+`,
+			expect: `vpc = create vpc cidr=10.0.0.0/16
+sub_1 = create subnet availabilityzone=eu-west-1a cidr=10.0.2.0/24 vpc=$vpc
+update subnet id=$sub_1 public=true
+sub_2 = create subnet availabilityzone=eu-west-1b cidr=10.0.2.0/24 vpc=$vpc
+update subnet id=$sub_2 public=true`,
+			expProcessedFillers:  map[string]interface{}{"test.cidr": "10.0.2.0/24"},
+			expResolvedVariables: map[string]interface{}{},
+		},
+		{
+			tpl: `
+vpc_1 = create vpc cidr=10.0.0.0/16
+for c in [10.1.0.0/16, 10.2.0.0/16] {
+  vpc = create vpc cidr=$c
+}
+create subnet cidr={test.cidr} vpc=$vpc_1
+`,
+			expect: `vpc_1 = create vpc cidr=10.0.0.0/16
+vpc_2 = create vpc cidr=10.1.0.0/16
+vpc_3 = create vpc cidr=10.2.0.0/16
+create subnet cidr=10.0.2.0/24 vpc=$vpc_1`,
+			expProcessedFillers:  map[string]interface{}{"test.cidr": "10.0.2.0/24"},
+			expResolvedVariables: map[string]interface{}{},
+		},
+		{
+			tpl: `
+name = {instance.name}
+vpc = create vpc cidr={test.cidr}
+output vpc.id = $vpc
+output instance = $name
+`,
+			expect: `vpc = create vpc cidr=10.0.2.0/24
+output vpc.id = $vpc
+output instance = myinstance`,
+			expProcessedFillers:  map[string]interface{}{"test.cidr": "10.0.2.0/24", "instance.name": "myinstance"},
+			expResolvedVariables: map[string]interface{}{"name": "myinstance"},
+		},
+		{
+			tpl: `
+name = upper({instance.name})
+vpc = create vpc cidr={test.cidr}
+for i in [1, 2] {
+  create subnet vpc=$vpc cidr=cidrsubnet({test.cidr}, 2, $i) name=join([lower($name), $i], '-')
+}
+create tag resource=$vpc key=Name value=lower($vpc)
+`,
+			expect: `vpc = create vpc cidr=10.0.2.0/24
+create subnet cidr=10.0.2.64/26 name=myinstance-1 vpc=$vpc
+create subnet cidr=10.0.2.128/26 name=myinstance-2 vpc=$vpc
+create tag key=Name resource=$vpc value=lower($vpc)`,
+			expProcessedFillers:  map[string]interface{}{"test.cidr": "10.0.2.0/24", "instance.name": "myinstance"},
+			expResolvedVariables: map[string]interface{}{"name": "MYINSTANCE"},
+		},
 	}
 
 	for i, tcase := range tcases {
@@ -19,6 +19,7 @@ package ast
 import (
 	"bytes"
 	"fmt"
+	"net"
 	"regexp"
 	"sort"
 	"strconv"
@@ -38,10 +39,22 @@ type AST struct {
 	currentKey         string
 	currentListBuilder *listValueBuilder
 	stmtBuilder        *statementBuilder
+	blockBuilders      []*blockBuilder
+	blankLines         int
+	heredocDelimiter   string
+	source             *Source
 }
 
 type Statement struct {
 	Node
+	// BlankLineBefore keeps track of the blank lines separating the statement from the previous one
+	BlankLineBefore bool
+	// Line is the line of the statement in the parsed text, 0 when unknown
+	Line int
+	// Column is the column of the statement in its line, starting at 1
+	Column int
+	// Source is the parsed text of the statement, nil when unknown
+	Source *Source
 }
 
 type DeclarationNode struct {
@@ -49,6 +62,578 @@ type DeclarationNode struct {
 	Expr  ExpressionNode
 }
 
+// WithNestedStatements is implemented by block nodes
+// (ex: conditionals) holding statements of their own
+type WithNestedStatements interface {
+	NestedStatements() []*Statement
+}
+
+type IfNode struct {
+	Condition *Condition
+	Then      []*Statement
+	Else      []*Statement
+}
+
+func (n *IfNode) NestedStatements() (all []*Statement) {
+	all = append(all, n.Then...)
+	return append(all, n.Else...)
+}
+
+// Branch returns the statements to execute given the result of the condition
+func (n *IfNode) Branch(cond bool) []*Statement {
+	if cond {
+		return n.Then
+	}
+	return n.Else
+}
+
+func (n *IfNode) clone() Node {
+	return &IfNode{
+		Condition: n.Condition.clone(),
+		Then:      cloneStatements(n.Then),
+		Else:      cloneStatements(n.Else),
+	}
+}
+
+func (n *IfNode) String() string {
+	var buff bytes.Buffer
+	fmt.Fprintf(&buff, "if %s {\n", n.Condition)
+	writeBlock(&buff, n.Then)
+	buff.WriteString("}")
+	if len(n.Else) == 1 {
+		if elseIf, ok := n.Else[0].Node.(*IfNode); ok {
+			fmt.Fprintf(&buff, " else %s", elseIf)
+			return buff.String()
+		}
+	}
+	if len(n.Else) > 0 {
+		buff.WriteString(" else {\n")
+		writeBlock(&buff, n.Else)
+		buff.WriteString("}")
+	}
+	return buff.String()
+}
+
+func writeBlock(buff *bytes.Buffer, stmts []*Statement) {
+	for _, stmt := range stmts {
+		var heredocDelim string
+		for _, line := range strings.Split(stmt.String(), "\n") {
+			if heredocDelim != "" && !heredocDelimiterClash(line, heredocDelim) {
+				buff.WriteString(line + "\n") // heredoc bodies are kept verbatim
+				continue
+			}
+			fmt.Fprintf(buff, "\t%s\n", line)
+			heredocDelim = ""
+			if _, isComment := stmt.Node.(*CommentNode); !isComment {
+				if matches := openingHeredocRegex.FindStringSubmatch(line); len(matches) > 1 {
+					heredocDelim = matches[1]
+				}
+			}
+		}
+	}
+}
+
+var openingHeredocRegex = regexp.MustCompile(`(?:^|[=\s])<<([a-zA-Z0-9-_.]+)$`)
+
+type ForNode struct {
+	Var  string
+	List CompositeValue
+	Body []*Statement
+}
+
+func (n *ForNode) NestedStatements() []*Statement {
+	return n.Body
+}
+
+// Items returns the values to iterate over. Items of a list literal are kept
+// as is, otherwise the resolved value of the list is iterated over.
+func (n *ForNode) Items(resolve func(CompositeValue) (interface{}, error)) ([]CompositeValue, error) {
+	if list, ok := n.List.(*listValue); ok {
+		var items []CompositeValue
+		for _, val := range list.vals {
+			items = append(items, val.Clone())
+		}
+		return items, nil
+	}
+	resolved, err := resolve(n.List)
+	if err != nil {
+		return nil, err
+	}
+	var items []CompositeValue
+	switch vv := resolved.(type) {
+	case []interface{}:
+		for _, v := range vv {
+			items = append(items, &interfaceValue{val: v})
+		}
+	case []string:
+		for _, v := range vv {
+			items = append(items, &interfaceValue{val: v})
+		}
+	default:
+		items = append(items, &interfaceValue{val: vv})
+	}
+	return items, nil
+}
+
+// Unroll returns the statements of the body repeated for each item,
+// with the loop variable replaced by the item. Variables declared in the body
+// are scoped to their iteration, and thus renamed with the iteration number,
+// or the next number free of the identifiers in declared, which is updated.
+func (n *ForNode) Unroll(items []CompositeValue, declared map[string]bool) (unrolled []*Statement) {
+	for i, item := range items {
+		body := cloneStatements(n.Body)
+		replaceRefInStatements(body, n.Var, item)
+		renameDeclarations(body, func(ident string) string {
+			renamed := fmt.Sprintf("%s_%d", ident, i+1)
+			for num := i + 2; declared[renamed]; num++ {
+				renamed = fmt.Sprintf("%s_%d", ident, num)
+			}
+			declared[renamed] = true
+			return renamed
+		})
+		unrolled = append(unrolled, body...)
+	}
+	return
+}
+
+func (n *ForNode) clone() Node {
+	return &ForNode{
+		Var:  n.Var,
+		List: n.List.Clone(),
+		Body: cloneStatements(n.Body),
+	}
+}
+
+func (n *ForNode) String() string {
+	var buff bytes.Buffer
+	fmt.Fprintf(&buff, "for %s in %s {\n", n.Var, n.List)
+	writeBlock(&buff, n.Body)
+	buff.WriteString("}")
+	return buff.String()
+}
+
+// IncludeNode inlines another template, filling its holes with the params.
+// Variables declared in the included template are exported under the prefix.
+type IncludeNode struct {
+	Prefix, Path string
+	Params       map[string]CompositeValue
+}
+
+func (n *IncludeNode) clone() Node {
+	clone := &IncludeNode{
+		Prefix: n.Prefix, Path: n.Path,
+		Params: make(map[string]CompositeValue),
+	}
+	for k, v := range n.Params {
+		clone.Params[k] = v.Clone()
+	}
+	return clone
+}
+
+func (n *IncludeNode) String() string {
+	var buff bytes.Buffer
+	if n.Prefix != "" {
+		fmt.Fprintf(&buff, "%s = ", n.Prefix)
+	}
+	fmt.Fprintf(&buff, "include %s", quoteStringIfNeeded(n.Path))
+	var all []string
+	for k, v := range n.Params {
+		all = append(all, fmt.Sprintf("%s=%s", k, v.String()))
+	}
+	sort.Strings(all)
+	if len(all) > 0 {
+		fmt.Fprintf(&buff, " %s", strings.Join(all, " "))
+	}
+	return buff.String()
+}
+
+// Inline returns the statements of the included template with its declarations
+// prefixed and its holes filled with the include params
+func (n *IncludeNode) Inline(included []*Statement, prefix string) []*Statement {
+	stmts := cloneStatements(included)
+	renameDeclarations(stmts, func(ident string) string { return prefix + "." + ident })
+	for _, st := range stmts {
+		if output, ok := st.Node.(*OutputNode); ok {
+			output.Name = prefix + "." + output.Name
+		}
+	}
+	for _, st := range stmts {
+		if nested, ok := st.Node.(*IncludeNode); ok && nested.Prefix != "" {
+			renamed := prefix + "." + nested.Prefix
+			mapValues(stmts, func(v CompositeValue) CompositeValue {
+				if ref, ok := v.(*referenceValue); ok && strings.HasPrefix(ref.ref, nested.Prefix+".") {
+					ref.ref = prefix + "." + ref.ref
+				}
+				return v
+			})
+			nested.Prefix = renamed
+		}
+	}
+	var kept []*Statement
+	for _, st := range stmts {
+		if param, ok := st.Node.(*ParamNode); ok {
+			if _, filled := n.Params[param.Name]; filled {
+				continue
+			}
+		}
+		kept = append(kept, st)
+	}
+	stmts = kept
+	for hole, value := range n.Params {
+		fill := value
+		mapValues(stmts, func(v CompositeValue) CompositeValue {
+			if h, ok := v.(*holeValue); ok && h.hole == hole && h.val == nil && h.alias == nil {
+				return fill.Clone()
+			}
+			return v
+		})
+	}
+	return stmts
+}
+
+// ParamNode declares the type, default value, allowed values and
+// description of a template hole
+type ParamNode struct {
+	Name, Type, Description string
+	Allowed, Default        CompositeValue
+}
+
+var ParamTypes = []string{"string", "int", "bool", "cidr", "ip", "list"}
+
+func (n *ParamNode) clone() Node {
+	clone := &ParamNode{Name: n.Name, Type: n.Type, Description: n.Description}
+	if n.Allowed != nil {
+		clone.Allowed = n.Allowed.Clone()
+	}
+	if n.Default != nil {
+		clone.Default = n.Default.Clone()
+	}
+	return clone
+}
+
+func (n *ParamNode) String() string {
+	var buff bytes.Buffer
+	fmt.Fprintf(&buff, "param %s: %s", n.Name, n.Type)
+	if n.Allowed != nil {
+		fmt.Fprintf(&buff, " in %s", n.Allowed)
+	}
+	if n.Default != nil {
+		fmt.Fprintf(&buff, " = %s", n.Default)
+	}
+	if n.Description != "" {
+		fmt.Fprintf(&buff, " \"%s\"", n.Description)
+	}
+	return buff.String()
+}
+
+// AllowedValues returns the literal values a param is restricted to, if any
+func (n *ParamNode) AllowedValues() (all []string) {
+	switch v := n.Allowed.(type) {
+	case nil:
+	case *listValue:
+		for _, e := range v.vals {
+			all = append(all, fmt.Sprint(e.Value()))
+		}
+	default:
+		all = append(all, fmt.Sprint(v.Value()))
+	}
+	return
+}
+
+// Check verifies that a value supplied for the param complies with its declaration
+func (n *ParamNode) Check(value interface{}) error {
+	if values, isList := value.([]interface{}); isList {
+		if n.Type != "list" {
+			return fmt.Errorf("expected a %s, got list %v", n.Type, value)
+		}
+		for _, v := range values {
+			if err := n.checkAllowed(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	str := fmt.Sprint(value)
+	switch n.Type {
+	case "int":
+		if _, err := strconv.Atoi(str); err != nil {
+			return fmt.Errorf("expected an int, got '%s'", str)
+		}
+	case "bool":
+		if _, err := strconv.ParseBool(str); err != nil {
+			return fmt.Errorf("expected a bool, got '%s'", str)
+		}
+	case "cidr":
+		if _, _, err := net.ParseCIDR(str); err != nil {
+			return fmt.Errorf("expected a cidr, got '%s'", str)
+		}
+	case "ip":
+		if net.ParseIP(str) == nil {
+			return fmt.Errorf("expected an ip, got '%s'", str)
+		}
+	case "string", "list":
+	default:
+		return fmt.Errorf("unknown type '%s', expected one of %s", n.Type, strings.Join(ParamTypes, ", "))
+	}
+	return n.checkAllowed(value)
+}
+
+func (n *ParamNode) checkAllowed(value interface{}) error {
+	allowed := n.AllowedValues()
+	if len(allowed) == 0 {
+		return nil
+	}
+	str := fmt.Sprint(value)
+	for _, a := range allowed {
+		if a == str {
+			return nil
+		}
+	}
+	return fmt.Errorf("'%s' is not one of %s", str, strings.Join(allowed, ", "))
+}
+
+// RenameDeclarations renames the declarations of statements and the references to them
+func RenameDeclarations(stmts []*Statement, rename func(string) string) {
+	renameDeclarations(stmts, rename)
+}
+
+// ReferLiterals replaces the string literals of statements by references
+// to the declarations refs maps them to
+func ReferLiterals(stmts []*Statement, refs map[string]string) {
+	mapValues(stmts, func(v CompositeValue) CompositeValue {
+		if i, ok := v.(*interfaceValue); ok {
+			if str, ok := i.val.(string); ok {
+				if ref, ok := refs[str]; ok {
+					return &referenceValue{positioned: i.positioned, ref: ref}
+				}
+			}
+		}
+		return v
+	})
+}
+
+// DeclaredIdentifiers returns the identifiers declared in the statements,
+// including the ones nested in blocks
+func DeclaredIdentifiers(stmts []*Statement) map[string]bool {
+	declared := make(map[string]bool)
+	for _, decl := range declarationsIn(stmts) {
+		declared[decl.Ident] = true
+	}
+	return declared
+}
+
+func renameDeclarations(stmts []*Statement, rename func(string) string) {
+	for _, decl := range declarationsIn(stmts) {
+		renamed := rename(decl.Ident)
+		replaceRefInStatements(stmts, decl.Ident, &referenceValue{ref: renamed})
+		decl.Ident = renamed
+	}
+}
+
+// mapValues replaces all the values of the statements (including the ones
+// nested in blocks, lists and concatenations) with the result of fn
+func mapValues(stmts []*Statement, fn func(CompositeValue) CompositeValue) {
+	mapParams := func(params map[string]CompositeValue) {
+		for k, v := range params {
+			params[k] = transformValue(v, fn)
+		}
+	}
+	for _, st := range stmts {
+		switch n := st.Node.(type) {
+		case *DeclarationNode:
+			switch expr := n.Expr.(type) {
+			case *CommandNode:
+				mapParams(expr.Params)
+			case *ValueNode:
+				expr.Value = transformValue(expr.Value, fn)
+			}
+		case *CommandNode:
+			mapParams(n.Params)
+		case *IfNode:
+			n.Condition.Left = transformValue(n.Condition.Left, fn)
+			n.Condition.Right = transformValue(n.Condition.Right, fn)
+			mapValues(n.Then, fn)
+			mapValues(n.Else, fn)
+		case *ForNode:
+			n.List = transformValue(n.List, fn)
+			mapValues(n.Body, fn)
+		case *IncludeNode:
+			mapParams(n.Params)
+		case *OutputNode:
+			n.Value = transformValue(n.Value, fn)
+		}
+	}
+}
+
+func transformValue(v CompositeValue, fn func(CompositeValue) CompositeValue) CompositeValue {
+	switch vv := v.(type) {
+	case nil:
+		return nil
+	case *listValue:
+		for i, val := range vv.vals {
+			vv.vals[i] = transformValue(val, fn)
+		}
+	case *concatenationValue:
+		for i, val := range vv.vals {
+			vv.vals[i] = transformValue(val, fn)
+		}
+	case *funcValue:
+		for i, arg := range vv.args {
+			vv.args[i] = transformValue(arg, fn)
+		}
+	case *mapValue:
+		for k, val := range vv.vals {
+			vv.vals[k] = transformValue(val, fn)
+		}
+	}
+	return fn(v)
+}
+
+// MarkSecret marks the holes of a value as secret. Literal values become
+// secret holes of the given name, already filled, so that they are never printed.
+func MarkSecret(v CompositeValue, hole string) CompositeValue {
+	return transformValue(v, func(v CompositeValue) CompositeValue {
+		switch vv := v.(type) {
+		case *holeValue:
+			vv.secret = true
+		case *interfaceValue:
+			return &holeValue{positioned: vv.positioned, hole: hole, val: vv.val, secret: true}
+		}
+		return v
+	})
+}
+
+// SecretHoles returns the names of the secret holes of statements
+func SecretHoles(stmts []*Statement) map[string]bool {
+	holes := make(map[string]bool)
+	mapValues(stmts, func(v CompositeValue) CompositeValue {
+		if h, ok := v.(*holeValue); ok && h.secret {
+			holes[h.hole] = true
+		}
+		return v
+	})
+	return holes
+}
+
+func replaceRefInStatements(stmts []*Statement, key string, value CompositeValue) {
+	for _, st := range stmts {
+		switch n := st.Node.(type) {
+		case *DeclarationNode:
+			if withRefs, ok := n.Expr.(WithRefs); ok {
+				withRefs.ReplaceRef(key, value.Clone())
+			}
+		case WithRefs:
+			n.ReplaceRef(key, value.Clone())
+		case *IfNode:
+			n.Condition.Left = replaceRefInValue(n.Condition.Left, key, value)
+			n.Condition.Right = replaceRefInValue(n.Condition.Right, key, value)
+			replaceRefInStatements(n.Then, key, value)
+			replaceRefInStatements(n.Else, key, value)
+		case *ForNode:
+			n.List = replaceRefInValue(n.List, key, value)
+			if n.Var != key { // inner loop variable shadows the reference
+				replaceRefInStatements(n.Body, key, value)
+			}
+		case *IncludeNode:
+			for k, v := range n.Params {
+				n.Params[k] = replaceRefInValue(v, key, value)
+			}
+		}
+	}
+}
+
+func replaceRefInValue(v CompositeValue, key string, value CompositeValue) CompositeValue {
+	if withRefs, ok := v.(WithRefs); ok {
+		if withRefs.IsRef(key) {
+			return value.Clone()
+		}
+		withRefs.ReplaceRef(key, value.Clone())
+	}
+	return v
+}
+
+func declarationsIn(stmts []*Statement) (decls []*DeclarationNode) {
+	for _, st := range stmts {
+		switch n := st.Node.(type) {
+		case *DeclarationNode:
+			decls = append(decls, n)
+		case WithNestedStatements:
+			decls = append(decls, declarationsIn(n.NestedStatements())...)
+		}
+	}
+	return
+}
+
+const (
+	EqualOperator    = "=="
+	NotEqualOperator = "!="
+)
+
+// Condition is either a single value evaluated as a boolean,
+// or the comparison of 2 values
+type Condition struct {
+	Left, Right CompositeValue
+	Operator    string
+}
+
+// Evaluate the condition given a func resolving the actual value of its operands
+func (c *Condition) Evaluate(resolve func(CompositeValue) (interface{}, error)) (bool, error) {
+	left, err := resolve(c.Left)
+	if err != nil {
+		return false, err
+	}
+	if c.Operator == "" {
+		return toBool(left)
+	}
+	right, err := resolve(c.Right)
+	if err != nil {
+		return false, err
+	}
+	switch c.Operator {
+	case EqualOperator:
+		return fmt.Sprint(left) == fmt.Sprint(right), nil
+	case NotEqualOperator:
+		return fmt.Sprint(left) != fmt.Sprint(right), nil
+	default:
+		return false, fmt.Errorf("unknown operator '%s'", c.Operator)
+	}
+}
+
+func toBool(i interface{}) (bool, error) {
+	switch ii := i.(type) {
+	case bool:
+		return ii, nil
+	case int:
+		return ii != 0, nil
+	case string:
+		b, err := strconv.ParseBool(ii)
+		if err != nil {
+			return false, fmt.Errorf("cannot evaluate '%s' as a boolean", ii)
+		}
+		return b, nil
+	default:
+		return false, fmt.Errorf("cannot evaluate '%v' as a boolean", i)
+	}
+}
+
+func (c *Condition) clone() *Condition {
+	clone := &Condition{Operator: c.Operator}
+	if c.Left != nil {
+		clone.Left = c.Left.Clone()
+	}
+	if c.Right != nil {
+		clone.Right = c.Right.Clone()
+	}
+	return clone
+}
+
+func (c *Condition) String() string {
+	if c.Operator == "" {
+		return c.Left.String()
+	}
+	return fmt.Sprintf("%s %s %s", c.Left, c.Operator, c.Right)
+}
+
 type ExpressionNode interface {
 	Node
 	Result() interface{}
@@ -69,11 +654,26 @@ type CommandNode struct {
 	Command
 	CmdResult interface{}
 	CmdErr    error
+	// CmdPriorValues are the values, before running, of the params an update changes
+	CmdPriorValues map[string]interface{}
+	// CmdSnapshots are the states, before running, of the resources a delete removes
+	CmdSnapshots []*Snapshot
 
 	Action, Entity string
 	Params         map[string]CompositeValue
 }
 
+// Snapshot is the state of a resource taken just before it is deleted
+type Snapshot struct {
+	Type       string                 `json:"type"`
+	ID         string                 `json:"id"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	// Recreate holds the statements recreating the resource, empty when it cannot be recreated
+	Recreate string `json:"recreate,omitempty"`
+	// Unrecreatable are the parts of the resource, or the resource itself, which cannot be recreated
+	Unrecreatable []string `json:"unrecreatable,omitempty"`
+}
+
 func (c *CommandNode) Result() interface{} { return c.CmdResult }
 func (c *CommandNode) Err() error          { return c.CmdErr }
 
@@ -152,6 +752,21 @@ func (c *CommandNode) ProcessRefs(refs map[string]interface{}) {
 	}
 }
 
+// EvaluateFunctions computes the function calls of the params once their references are resolved
+func (c *CommandNode) EvaluateFunctions() error {
+	var keys []string
+	for k := range c.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := EvaluateValueFunctions(c.Params[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *CommandNode) GetRefs() (refs []string) {
 	for _, param := range c.Params {
 		if withRef, ok := param.(WithRefs); ok {
@@ -193,7 +808,7 @@ func (c *CommandNode) ToFillerParams() map[string]interface{} {
 		if v.Value() != nil {
 			params[k] = v.Value()
 		} else if _, ok := v.(WithAlias); ok {
-			params[k] = v
+			params[k] = withoutPosition(v) // fillers are not located in the templates they fill
 		}
 	}
 	return params
@@ -264,13 +879,56 @@ func (n *ValueNode) GetHoles() map[string][]string {
 	return make(map[string][]string)
 }
 
+// OutputNode exports a value of the template once run
+type OutputNode struct {
+	Name string
+	ValueNode
+}
+
+func (n *OutputNode) clone() Node {
+	return &OutputNode{Name: n.Name, ValueNode: ValueNode{Value: n.Value.Clone()}}
+}
+
+func (n *OutputNode) String() string {
+	return fmt.Sprintf("output %s = %s", n.Name, n.Value)
+}
+
+func (n *OutputNode) Result() interface{} { return n.Value.Value() }
+
+// CommentNode keeps the comments of a template, ignored when compiling and running it.
+// An inline comment ends the line of the previous statement.
+type CommentNode struct {
+	Text   string
+	Inline bool
+}
+
+func (n *CommentNode) clone() Node {
+	return &CommentNode{Text: n.Text, Inline: n.Inline}
+}
+
+func (n *CommentNode) String() string {
+	return n.Text
+}
+
 func (s *Statement) Clone() *Statement {
-	newStat := &Statement{}
+	newStat := &Statement{BlankLineBefore: s.BlankLineBefore, Line: s.Line, Column: s.Column, Source: s.Source}
 	newStat.Node = s.Node.clone()
 
 	return newStat
 }
 
+// Position returns the position of the statement in the parsed text, invalid when unknown
+func (s *Statement) Position() Position {
+	return Position{Source: s.Source, Line: s.Line, Column: s.Column}
+}
+
+func cloneStatements(stmts []*Statement) (clones []*Statement) {
+	for _, stmt := range stmts {
+		clones = append(clones, stmt.Clone())
+	}
+	return
+}
+
 func (a *AST) String() string {
 	var all []string
 	for _, stat := range a.Statements {
@@ -307,6 +965,8 @@ func printParamValue(i interface{}) string {
 		return "[" + strings.Join(strs, ",") + "]"
 	case string:
 		return quoteStringIfNeeded(ii)
+	case Heredoc:
+		return heredoc(string(ii))
 	default:
 		return fmt.Sprintf("%v", i)
 	}
@@ -322,14 +982,19 @@ func (a *AST) Clone() *AST {
 
 var SimpleStringValue = regexp.MustCompile("^[a-zA-Z0-9-._:/+;~@<>*]+$") // in sync with [a-zA-Z0-9-._:/+;~@<>]+ in PEG (with ^ and $ around)
 
+var identifierRegex = regexp.MustCompile("^[a-zA-Z0-9-_.]+$") // in sync with Identifier in PEG
+
 func quoteStringIfNeeded(input string) string {
+	if strings.ContainsAny(input, "\r\n") {
+		return heredoc(input)
+	}
 	if _, err := strconv.Atoi(input); err == nil {
 		return "'" + input + "'"
 	}
 	if _, err := strconv.ParseFloat(input, 64); err == nil {
 		return "'" + input + "'"
 	}
-	if SimpleStringValue.MatchString(input) {
+	if SimpleStringValue.MatchString(input) && !strings.HasPrefix(input, "<<") {
 		return input
 	} else {
 		return quoteString(input)
@@ -344,6 +1009,36 @@ func quoteString(str string) string {
 	}
 }
 
+// heredoc prints a multi-line string as a heredoc value,
+// its delimiter not being the beginning of any of its lines
+func heredoc(str string) string {
+	if str == "" {
+		return "<<EOF\nEOF"
+	}
+	delim := "EOF"
+	for i := 2; heredocDelimiterClash(str, delim); i++ {
+		delim = fmt.Sprintf("EOF%d", i)
+	}
+	return fmt.Sprintf("<<%s\n%s\n%s", delim, str, delim)
+}
+
+func heredocDelimiterClash(str, delim string) bool {
+	for _, line := range strings.FieldsFunc(str, func(r rune) bool { return r == '\n' || r == '\r' }) {
+		rest := strings.TrimLeft(line, " \t")
+		if !strings.HasPrefix(rest, delim) {
+			continue
+		}
+		if after := []rune(strings.TrimPrefix(rest, delim)); len(after) == 0 || !isIdentifierRune(after[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+func isIdentifierRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.'
+}
+
 func isQuoted(str string) bool {
 	if len(str) < 2 {
 		return false
@@ -49,13 +49,77 @@ func TestCloneAST(t *testing.T) {
 		t.Fatalf("\ngot %#v\n\nwant %#v", got, want)
 	}
 
-	clone.Statements[0].Node.(*DeclarationNode).Expr.(*CommandNode).Params["new"] = &interfaceValue{"mynode"}
+	clone.Statements[0].Node.(*DeclarationNode).Expr.(*CommandNode).Params["new"] = &interfaceValue{val: "mynode"}
 
 	if got, want := clone.Statements, tree.Statements; reflect.DeepEqual(got, want) {
 		t.Fatalf("\ngot %s\n\nwant %s", got, want)
 	}
 }
 
+func TestConditionEvaluate(t *testing.T) {
+	resolve := func(v CompositeValue) (interface{}, error) { return v.Value(), nil }
+	tcases := []struct {
+		cond   *Condition
+		expect bool
+		expErr bool
+	}{
+		{cond: &Condition{Left: &interfaceValue{val: "true"}}, expect: true},
+		{cond: &Condition{Left: &interfaceValue{val: "false"}}, expect: false},
+		{cond: &Condition{Left: &interfaceValue{val: 0}}, expect: false},
+		{cond: &Condition{Left: &interfaceValue{val: "yes"}}, expErr: true},
+		{cond: &Condition{Left: &interfaceValue{val: "prod"}, Operator: "==", Right: &interfaceValue{val: "prod"}}, expect: true},
+		{cond: &Condition{Left: &interfaceValue{val: 2}, Operator: "==", Right: &interfaceValue{val: "2"}}, expect: true},
+		{cond: &Condition{Left: &interfaceValue{val: "prod"}, Operator: "!=", Right: &interfaceValue{val: "prod"}}, expect: false},
+	}
+	for i, tcase := range tcases {
+		got, err := tcase.cond.Evaluate(resolve)
+		if tcase.expErr {
+			if err == nil {
+				t.Fatalf("%d: expected error, got none", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if want := tcase.expect; got != want {
+			t.Fatalf("%d: got %t, want %t", i+1, got, want)
+		}
+	}
+}
+
+func TestParamCheck(t *testing.T) {
+	allowed := &listValue{vals: []CompositeValue{&interfaceValue{val: "a"}, &interfaceValue{val: "b"}}}
+	tcases := []struct {
+		param  *ParamNode
+		value  interface{}
+		expErr bool
+	}{
+		{param: &ParamNode{Type: "string"}, value: "anything"},
+		{param: &ParamNode{Type: "string"}, value: []interface{}{"a", "b"}, expErr: true},
+		{param: &ParamNode{Type: "int"}, value: 3},
+		{param: &ParamNode{Type: "int"}, value: "3"},
+		{param: &ParamNode{Type: "int"}, value: "three", expErr: true},
+		{param: &ParamNode{Type: "bool"}, value: "true"},
+		{param: &ParamNode{Type: "bool"}, value: "yes", expErr: true},
+		{param: &ParamNode{Type: "cidr"}, value: "10.0.0.0/16"},
+		{param: &ParamNode{Type: "cidr"}, value: "10.0.0.0", expErr: true},
+		{param: &ParamNode{Type: "ip"}, value: "10.0.0.1"},
+		{param: &ParamNode{Type: "ip"}, value: "10.0.0.256", expErr: true},
+		{param: &ParamNode{Type: "list"}, value: []interface{}{"a", "b"}},
+		{param: &ParamNode{Type: "list", Allowed: allowed}, value: []interface{}{"a", "c"}, expErr: true},
+		{param: &ParamNode{Type: "string", Allowed: allowed}, value: "b"},
+		{param: &ParamNode{Type: "string", Allowed: allowed}, value: "c", expErr: true},
+		{param: &ParamNode{Type: "unknown"}, value: "a", expErr: true},
+	}
+	for i, tcase := range tcases {
+		err := tcase.param.Check(tcase.value)
+		if got, want := err != nil, tcase.expErr; got != want {
+			t.Fatalf("%d: got error %v, want error %t", i+1, err, want)
+		}
+	}
+}
+
 func TestIsQuoted(t *testing.T) {
 	tcases := []struct {
 		in  string
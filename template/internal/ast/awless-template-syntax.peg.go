@@ -1,10 +1,14 @@
 package ast
 
+// Code generated by peg -inline -switch awless-template-syntax.peg DO NOT EDIT.
+
 import (
 	"fmt"
-	"math"
+	"io"
+	"os"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 const endSymbol rune = 1114112
@@ -21,19 +25,36 @@ const (
 	ruleDeclaration
 	ruleValueExpr
 	ruleCmdExpr
+	ruleIfExpr
+	ruleElseExpr
+	ruleCondition
+	ruleComparisonOperator
+	ruleForExpr
+	ruleIncludeExpr
+	ruleIncludePath
+	ruleParamDecl
+	ruleOutputDecl
+	ruleBlock
 	ruleParams
 	ruleParam
 	ruleIdentifier
 	ruleCompositeValue
 	ruleListValue
 	ruleListWithoutSquareBrackets
+	ruleMapValue
+	ruleMapEntry
+	ruleMapKey
 	ruleNoRefValue
 	ruleValue
+	ruleFuncValue
+	ruleFuncArg
 	ruleCustomTypedValue
 	ruleUnquotedParamValue
 	ruleUnquotedParam
 	ruleConcatenationValue
 	ruleQuotedStringValue
+	ruleHeredocValue
+	ruleHeredocEnd
 	ruleQuotedString
 	ruleDoubleQuotedValue
 	ruleSingleQuotedValue
@@ -47,6 +68,7 @@ const (
 	ruleHolesStringValue
 	ruleHoleWithSuffixValue
 	ruleComment
+	ruleInlineComment
 	ruleSingleQuote
 	ruleDoubleQuote
 	ruleWhiteSpacing
@@ -57,8 +79,8 @@ const (
 	ruleEndOfLine
 	ruleEndOfFile
 	ruleAction0
-	ruleAction1
 	rulePegText
+	ruleAction1
 	ruleAction2
 	ruleAction3
 	ruleAction4
@@ -84,6 +106,40 @@ const (
 	ruleAction24
 	ruleAction25
 	ruleAction26
+	ruleAction27
+	ruleAction28
+	ruleAction29
+	ruleAction30
+	ruleAction31
+	ruleAction32
+	ruleAction33
+	ruleAction34
+	ruleAction35
+	ruleAction36
+	ruleAction37
+	ruleAction38
+	ruleAction39
+	ruleAction40
+	ruleAction41
+	ruleAction42
+	ruleAction43
+	ruleAction44
+	ruleAction45
+	ruleAction46
+	ruleAction47
+	ruleAction48
+	ruleAction49
+	ruleAction50
+	ruleAction51
+	ruleAction52
+	ruleAction53
+	ruleAction54
+	ruleAction55
+	ruleAction56
+	ruleAction57
+	ruleAction58
+	ruleAction59
+	ruleAction60
 )
 
 var rul3s = [...]string{
@@ -95,19 +151,36 @@ var rul3s = [...]string{
 	"Declaration",
 	"ValueExpr",
 	"CmdExpr",
+	"IfExpr",
+	"ElseExpr",
+	"Condition",
+	"ComparisonOperator",
+	"ForExpr",
+	"IncludeExpr",
+	"IncludePath",
+	"ParamDecl",
+	"OutputDecl",
+	"Block",
 	"Params",
 	"Param",
 	"Identifier",
 	"CompositeValue",
 	"ListValue",
 	"ListWithoutSquareBrackets",
+	"MapValue",
+	"MapEntry",
+	"MapKey",
 	"NoRefValue",
 	"Value",
+	"FuncValue",
+	"FuncArg",
 	"CustomTypedValue",
 	"UnquotedParamValue",
 	"UnquotedParam",
 	"ConcatenationValue",
 	"QuotedStringValue",
+	"HeredocValue",
+	"HeredocEnd",
 	"QuotedString",
 	"DoubleQuotedValue",
 	"SingleQuotedValue",
@@ -121,6 +194,7 @@ var rul3s = [...]string{
 	"HolesStringValue",
 	"HoleWithSuffixValue",
 	"Comment",
+	"InlineComment",
 	"SingleQuote",
 	"DoubleQuote",
 	"WhiteSpacing",
@@ -131,8 +205,8 @@ var rul3s = [...]string{
 	"EndOfLine",
 	"EndOfFile",
 	"Action0",
-	"Action1",
 	"PegText",
+	"Action1",
 	"Action2",
 	"Action3",
 	"Action4",
@@ -158,6 +232,40 @@ var rul3s = [...]string{
 	"Action24",
 	"Action25",
 	"Action26",
+	"Action27",
+	"Action28",
+	"Action29",
+	"Action30",
+	"Action31",
+	"Action32",
+	"Action33",
+	"Action34",
+	"Action35",
+	"Action36",
+	"Action37",
+	"Action38",
+	"Action39",
+	"Action40",
+	"Action41",
+	"Action42",
+	"Action43",
+	"Action44",
+	"Action45",
+	"Action46",
+	"Action47",
+	"Action48",
+	"Action49",
+	"Action50",
+	"Action51",
+	"Action52",
+	"Action53",
+	"Action54",
+	"Action55",
+	"Action56",
+	"Action57",
+	"Action58",
+	"Action59",
+	"Action60",
 }
 
 type token32 struct {
@@ -174,19 +282,19 @@ type node32 struct {
 	up, next *node32
 }
 
-func (node *node32) print(pretty bool, buffer string) {
+func (node *node32) print(w io.Writer, pretty bool, buffer string) {
 	var print func(node *node32, depth int)
 	print = func(node *node32, depth int) {
 		for node != nil {
 			for c := 0; c < depth; c++ {
-				fmt.Printf(" ")
+				fmt.Fprintf(w, " ")
 			}
 			rule := rul3s[node.pegRule]
 			quote := strconv.Quote(string(([]rune(buffer)[node.begin:node.end])))
 			if !pretty {
-				fmt.Printf("%v %v\n", rule, quote)
+				fmt.Fprintf(w, "%v %v\n", rule, quote)
 			} else {
-				fmt.Printf("\x1B[34m%v\x1B[m %v\n", rule, quote)
+				fmt.Fprintf(w, "\x1B[36m%v\x1B[m %v\n", rule, quote)
 			}
 			if node.up != nil {
 				print(node.up, depth+1)
@@ -197,12 +305,12 @@ func (node *node32) print(pretty bool, buffer string) {
 	print(node, 0)
 }
 
-func (node *node32) Print(buffer string) {
-	node.print(false, buffer)
+func (node *node32) Print(w io.Writer, buffer string) {
+	node.print(w, false, buffer)
 }
 
-func (node *node32) PrettyPrint(buffer string) {
-	node.print(true, buffer)
+func (node *node32) PrettyPrint(w io.Writer, buffer string) {
+	node.print(w, true, buffer)
 }
 
 type tokens32 struct {
@@ -245,24 +353,24 @@ func (t *tokens32) AST() *node32 {
 }
 
 func (t *tokens32) PrintSyntaxTree(buffer string) {
-	t.AST().Print(buffer)
+	t.AST().Print(os.Stdout, buffer)
+}
+
+func (t *tokens32) WriteSyntaxTree(w io.Writer, buffer string) {
+	t.AST().Print(w, buffer)
 }
 
 func (t *tokens32) PrettyPrintSyntaxTree(buffer string) {
-	t.AST().PrettyPrint(buffer)
+	t.AST().PrettyPrint(os.Stdout, buffer)
 }
 
 func (t *tokens32) Add(rule pegRule, begin, end, index uint32) {
-	if tree := t.tree; int(index) >= len(tree) {
-		expanded := make([]token32, 2*len(tree))
-		copy(expanded, tree)
-		t.tree = expanded
-	}
-	t.tree[index] = token32{
-		pegRule: rule,
-		begin:   begin,
-		end:     end,
+	tree, i := t.tree, int(index)
+	if i >= len(tree) {
+		t.tree = append(tree, token32{pegRule: rule, begin: begin, end: end})
+		return
 	}
+	tree[i] = token32{pegRule: rule, begin: begin, end: end}
 }
 
 func (t *tokens32) Tokens() []token32 {
@@ -274,7 +382,7 @@ type Peg struct {
 
 	Buffer string
 	buffer []rune
-	rules  [71]func() bool
+	rules  [123]func() bool
 	parse  func(rule ...int) error
 	reset  func()
 	Pretty bool
@@ -326,7 +434,7 @@ type parseError struct {
 }
 
 func (e *parseError) Error() string {
-	tokens, error := []token32{e.max}, "\n"
+	tokens, err := []token32{e.max}, "\n"
 	positions, p := make([]int, 2*len(tokens)), 0
 	for _, token := range tokens {
 		positions[p], p = int(token.begin), p+1
@@ -339,14 +447,14 @@ func (e *parseError) Error() string {
 	}
 	for _, token := range tokens {
 		begin, end := int(token.begin), int(token.end)
-		error += fmt.Sprintf(format,
+		err += fmt.Sprintf(format,
 			rul3s[token.pegRule],
 			translations[begin].line, translations[begin].symbol,
 			translations[end].line, translations[end].symbol,
 			strconv.Quote(string(e.p.buffer[begin:end])))
 	}
 
-	return error
+	return err
 }
 
 func (p *Peg) PrintSyntaxTree() {
@@ -357,6 +465,16 @@ func (p *Peg) PrintSyntaxTree() {
 	}
 }
 
+func (p *Peg) WriteSyntaxTree(w io.Writer) {
+	p.tokens32.WriteSyntaxTree(w, p.Buffer)
+}
+
+func (p *Peg) SprintSyntaxTree() string {
+	var bldr strings.Builder
+	p.WriteSyntaxTree(&bldr)
+	return bldr.String()
+}
+
 func (p *Peg) Execute() {
 	buffer, _buffer, text, begin, end := p.Buffer, p.buffer, "", 0, 0
 	for _, token := range p.Tokens() {
@@ -369,69 +487,156 @@ func (p *Peg) Execute() {
 		case ruleAction0:
 			p.NewStatement()
 		case ruleAction1:
-			p.StatementDone()
+			p.addStatementPosition(begin)
 		case ruleAction2:
-			p.addDeclarationIdentifier(text)
+			p.StatementDone()
 		case ruleAction3:
-			p.addValue()
+			p.addDeclarationIdentifier(text)
 		case ruleAction4:
-			p.addAction(text)
+			p.addValue()
 		case ruleAction5:
-			p.addEntity(text)
+			p.addAction(text)
 		case ruleAction6:
-			p.addParamKey(text)
+			p.addEntity(text)
 		case ruleAction7:
-			p.addFirstValueInList()
+			p.addCondition()
 		case ruleAction8:
-			p.lastValueInList()
+			p.beginIfBlock()
 		case ruleAction9:
-			p.addFirstValueInList()
+			p.endBlock()
 		case ruleAction10:
-			p.lastValueInList()
+			p.beginElseBlock()
 		case ruleAction11:
-			p.addAliasParam(text)
+			p.NewStatement()
 		case ruleAction12:
-			p.addParamRefValue(text)
+			p.StatementDone()
 		case ruleAction13:
-			p.addParamCidrValue(text)
+			p.beginElseBlock()
 		case ruleAction14:
-			p.addParamIpValue(text)
+			p.addConditionOperator(text)
 		case ruleAction15:
-			p.addParamValue(text)
+			p.addLoopVariable(text)
 		case ruleAction16:
-			p.addParamValue(text)
+			p.beginForBlock()
 		case ruleAction17:
-			p.addFirstValueInConcatenation()
+			p.endBlock()
 		case ruleAction18:
-			p.lastValueInConcatenation()
+			p.addIncludePrefix(text)
 		case ruleAction19:
-			p.addFirstValueInConcatenation()
+			p.addIncludePath(text)
 		case ruleAction20:
-			p.lastValueInConcatenation()
+			p.addParamDeclaration(text)
 		case ruleAction21:
-			p.addStringValue(text)
+			p.addParamDeclarationType(text)
 		case ruleAction22:
-			p.addParamHoleValue(text)
+			p.addParamDeclarationAllowedValues()
 		case ruleAction23:
-			p.addFirstValueInConcatenation()
+			p.addParamDeclarationDefault()
 		case ruleAction24:
-			p.lastValueInConcatenation()
+			p.addParamDeclarationDescription(text)
 		case ruleAction25:
-			p.addFirstValueInConcatenation()
+			p.addOutputName(text)
 		case ruleAction26:
+			p.addParamKey(text)
+		case ruleAction27:
+			p.addValuePosition(begin)
+		case ruleAction28:
+			p.addFirstValueInList()
+		case ruleAction29:
+			p.lastValueInList()
+		case ruleAction30:
+			p.addFirstValueInList()
+		case ruleAction31:
+			p.lastValueInList()
+		case ruleAction32:
+			p.addFirstValueInMap()
+		case ruleAction33:
+			p.lastValueInMap()
+		case ruleAction34:
+			p.addMapKey(text)
+		case ruleAction35:
+			p.addMapKey(text)
+		case ruleAction36:
+			p.addAliasParam(text)
+		case ruleAction37:
+			p.addValuePosition(begin)
+		case ruleAction38:
+			p.addParamRefValue(text)
+		case ruleAction39:
+			p.addFunction(text)
+		case ruleAction40:
+			p.lastValueInFunction()
+		case ruleAction41:
+			p.addValuePosition(begin)
+		case ruleAction42:
+			p.addParamCidrValue(text)
+		case ruleAction43:
+			p.addParamIpValue(text)
+		case ruleAction44:
+			p.addParamValue(text)
+		case ruleAction45:
+			p.addParamValue(text)
+		case ruleAction46:
+			p.addFirstValueInConcatenation()
+		case ruleAction47:
+			p.lastValueInConcatenation()
+		case ruleAction48:
+			p.addFirstValueInConcatenation()
+		case ruleAction49:
+			p.lastValueInConcatenation()
+		case ruleAction50:
+			p.addStringValue(text)
+		case ruleAction51:
+			p.addHeredocValue("")
+		case ruleAction52:
+			p.addHeredocValue(text)
+		case ruleAction53:
+			p.addParamHoleValue(text)
+		case ruleAction54:
+			p.addFirstValueInConcatenation()
+		case ruleAction55:
+			p.lastValueInConcatenation()
+		case ruleAction56:
+			p.addFirstValueInConcatenation()
+		case ruleAction57:
 			p.lastValueInConcatenation()
+		case ruleAction58:
+			p.addComment(text)
+		case ruleAction59:
+			p.addInlineComment(text)
+		case ruleAction60:
+			p.addBlankLine()
 
 		}
 	}
 	_, _, _, _, _ = buffer, _buffer, text, begin, end
 }
 
-func (p *Peg) Init() {
+func Pretty(pretty bool) func(*Peg) error {
+	return func(p *Peg) error {
+		p.Pretty = pretty
+		return nil
+	}
+}
+
+func Size(size int) func(*Peg) error {
+	return func(p *Peg) error {
+		p.tokens32 = tokens32{tree: make([]token32, 0, size)}
+		return nil
+	}
+}
+func (p *Peg) Init(options ...func(*Peg) error) error {
 	var (
 		max                  token32
 		position, tokenIndex uint32
 		buffer               []rune
 	)
+	for _, option := range options {
+		err := option(p)
+		if err != nil {
+			return err
+		}
+	}
 	p.reset = func() {
 		max = token32{}
 		position, tokenIndex = 0, 0
@@ -445,7 +650,7 @@ func (p *Peg) Init() {
 	p.reset()
 
 	_rules := p.rules
-	tree := tokens32{tree: make([]token32, math.MaxInt16)}
+	tree := p.tokens32
 	p.parse = func(rule ...int) error {
 		r := 1
 		if len(rule) > 0 {
@@ -509,312 +714,44 @@ func (p *Peg) Init() {
 				l5:
 					position, tokenIndex = position5, tokenIndex5
 				}
-				{
-					position6 := position
-					{
-						add(ruleAction0, position)
-					}
-					if !_rules[ruleWhiteSpacing]() {
-						goto l0
-					}
-					{
-						position8, tokenIndex8 := position, tokenIndex
-						if !_rules[ruleCmdExpr]() {
-							goto l9
-						}
-						goto l8
-					l9:
-						position, tokenIndex = position8, tokenIndex8
-						{
-							position11 := position
-							{
-								position12 := position
-								if !_rules[ruleIdentifier]() {
-									goto l10
-								}
-								add(rulePegText, position12)
-							}
-							{
-								add(ruleAction2, position)
-							}
-							if !_rules[ruleEqual]() {
-								goto l10
-							}
-							{
-								position14, tokenIndex14 := position, tokenIndex
-								if !_rules[ruleCmdExpr]() {
-									goto l15
-								}
-								goto l14
-							l15:
-								position, tokenIndex = position14, tokenIndex14
-								{
-									position16 := position
-									{
-										add(ruleAction3, position)
-									}
-									if !_rules[ruleCompositeValue]() {
-										goto l10
-									}
-									add(ruleValueExpr, position16)
-								}
-							}
-						l14:
-							add(ruleDeclaration, position11)
-						}
-						goto l8
-					l10:
-						position, tokenIndex = position8, tokenIndex8
-						{
-							position18 := position
-							{
-								position19, tokenIndex19 := position, tokenIndex
-								if buffer[position] != rune('#') {
-									goto l20
-								}
-								position++
-							l21:
-								{
-									position22, tokenIndex22 := position, tokenIndex
-									{
-										position23, tokenIndex23 := position, tokenIndex
-										if !_rules[ruleEndOfLine]() {
-											goto l23
-										}
-										goto l22
-									l23:
-										position, tokenIndex = position23, tokenIndex23
-									}
-									if !matchDot() {
-										goto l22
-									}
-									goto l21
-								l22:
-									position, tokenIndex = position22, tokenIndex22
-								}
-								goto l19
-							l20:
-								position, tokenIndex = position19, tokenIndex19
-								if buffer[position] != rune('/') {
-									goto l0
-								}
-								position++
-								if buffer[position] != rune('/') {
-									goto l0
-								}
-								position++
-							l24:
-								{
-									position25, tokenIndex25 := position, tokenIndex
-									{
-										position26, tokenIndex26 := position, tokenIndex
-										if !_rules[ruleEndOfLine]() {
-											goto l26
-										}
-										goto l25
-									l26:
-										position, tokenIndex = position26, tokenIndex26
-									}
-									if !matchDot() {
-										goto l25
-									}
-									goto l24
-								l25:
-									position, tokenIndex = position25, tokenIndex25
-								}
-							}
-						l19:
-							add(ruleComment, position18)
-						}
-					}
-				l8:
-					if !_rules[ruleWhiteSpacing]() {
-						goto l0
-					}
-				l27:
-					{
-						position28, tokenIndex28 := position, tokenIndex
-						if !_rules[ruleEndOfLine]() {
-							goto l28
-						}
-						goto l27
-					l28:
-						position, tokenIndex = position28, tokenIndex28
-					}
-					{
-						add(ruleAction1, position)
-					}
-					add(ruleStatement, position6)
+				if !_rules[ruleStatement]() {
+					goto l0
 				}
-			l30:
+			l6:
 				{
-					position31, tokenIndex31 := position, tokenIndex
+					position7, tokenIndex7 := position, tokenIndex
 					if !_rules[ruleBlankLine]() {
-						goto l31
+						goto l7
 					}
-					goto l30
-				l31:
-					position, tokenIndex = position31, tokenIndex31
+					goto l6
+				l7:
+					position, tokenIndex = position7, tokenIndex7
 				}
 			l2:
 				{
 					position3, tokenIndex3 := position, tokenIndex
-				l32:
+				l8:
 					{
-						position33, tokenIndex33 := position, tokenIndex
+						position9, tokenIndex9 := position, tokenIndex
 						if !_rules[ruleBlankLine]() {
-							goto l33
+							goto l9
 						}
-						goto l32
-					l33:
-						position, tokenIndex = position33, tokenIndex33
+						goto l8
+					l9:
+						position, tokenIndex = position9, tokenIndex9
 					}
-					{
-						position34 := position
-						{
-							add(ruleAction0, position)
-						}
-						if !_rules[ruleWhiteSpacing]() {
-							goto l3
-						}
-						{
-							position36, tokenIndex36 := position, tokenIndex
-							if !_rules[ruleCmdExpr]() {
-								goto l37
-							}
-							goto l36
-						l37:
-							position, tokenIndex = position36, tokenIndex36
-							{
-								position39 := position
-								{
-									position40 := position
-									if !_rules[ruleIdentifier]() {
-										goto l38
-									}
-									add(rulePegText, position40)
-								}
-								{
-									add(ruleAction2, position)
-								}
-								if !_rules[ruleEqual]() {
-									goto l38
-								}
-								{
-									position42, tokenIndex42 := position, tokenIndex
-									if !_rules[ruleCmdExpr]() {
-										goto l43
-									}
-									goto l42
-								l43:
-									position, tokenIndex = position42, tokenIndex42
-									{
-										position44 := position
-										{
-											add(ruleAction3, position)
-										}
-										if !_rules[ruleCompositeValue]() {
-											goto l38
-										}
-										add(ruleValueExpr, position44)
-									}
-								}
-							l42:
-								add(ruleDeclaration, position39)
-							}
-							goto l36
-						l38:
-							position, tokenIndex = position36, tokenIndex36
-							{
-								position46 := position
-								{
-									position47, tokenIndex47 := position, tokenIndex
-									if buffer[position] != rune('#') {
-										goto l48
-									}
-									position++
-								l49:
-									{
-										position50, tokenIndex50 := position, tokenIndex
-										{
-											position51, tokenIndex51 := position, tokenIndex
-											if !_rules[ruleEndOfLine]() {
-												goto l51
-											}
-											goto l50
-										l51:
-											position, tokenIndex = position51, tokenIndex51
-										}
-										if !matchDot() {
-											goto l50
-										}
-										goto l49
-									l50:
-										position, tokenIndex = position50, tokenIndex50
-									}
-									goto l47
-								l48:
-									position, tokenIndex = position47, tokenIndex47
-									if buffer[position] != rune('/') {
-										goto l3
-									}
-									position++
-									if buffer[position] != rune('/') {
-										goto l3
-									}
-									position++
-								l52:
-									{
-										position53, tokenIndex53 := position, tokenIndex
-										{
-											position54, tokenIndex54 := position, tokenIndex
-											if !_rules[ruleEndOfLine]() {
-												goto l54
-											}
-											goto l53
-										l54:
-											position, tokenIndex = position54, tokenIndex54
-										}
-										if !matchDot() {
-											goto l53
-										}
-										goto l52
-									l53:
-										position, tokenIndex = position53, tokenIndex53
-									}
-								}
-							l47:
-								add(ruleComment, position46)
-							}
-						}
-					l36:
-						if !_rules[ruleWhiteSpacing]() {
-							goto l3
-						}
-					l55:
-						{
-							position56, tokenIndex56 := position, tokenIndex
-							if !_rules[ruleEndOfLine]() {
-								goto l56
-							}
-							goto l55
-						l56:
-							position, tokenIndex = position56, tokenIndex56
-						}
-						{
-							add(ruleAction1, position)
-						}
-						add(ruleStatement, position34)
+					if !_rules[ruleStatement]() {
+						goto l3
 					}
-				l58:
+				l10:
 					{
-						position59, tokenIndex59 := position, tokenIndex
+						position11, tokenIndex11 := position, tokenIndex
 						if !_rules[ruleBlankLine]() {
-							goto l59
+							goto l11
 						}
-						goto l58
-					l59:
-						position, tokenIndex = position59, tokenIndex59
+						goto l10
+					l11:
+						position, tokenIndex = position11, tokenIndex11
 					}
 					goto l2
 				l3:
@@ -824,17 +761,17 @@ func (p *Peg) Init() {
 					goto l0
 				}
 				{
-					position60 := position
+					position12 := position
 					{
-						position61, tokenIndex61 := position, tokenIndex
+						position13, tokenIndex13 := position, tokenIndex
 						if !matchDot() {
-							goto l61
+							goto l13
 						}
 						goto l0
-					l61:
-						position, tokenIndex = position61, tokenIndex61
+					l13:
+						position, tokenIndex = position13, tokenIndex13
 					}
-					add(ruleEndOfFile, position60)
+					add(ruleEndOfFile, position12)
 				}
 				add(ruleScript, position1)
 			}
@@ -843,1718 +780,3110 @@ func (p *Peg) Init() {
 			position, tokenIndex = position0, tokenIndex0
 			return false
 		},
-		/* 1 Statement <- <(Action0 WhiteSpacing (CmdExpr / Declaration / Comment) WhiteSpacing EndOfLine* Action1)> */
-		nil,
-		/* 2 Action <- <[a-z]+> */
-		nil,
-		/* 3 Entity <- <([a-z] / [0-9])+> */
-		nil,
-		/* 4 Declaration <- <(<Identifier> Action2 Equal (CmdExpr / ValueExpr))> */
-		nil,
-		/* 5 ValueExpr <- <(Action3 CompositeValue)> */
-		nil,
-		/* 6 CmdExpr <- <(<Action> Action4 MustWhiteSpacing <Entity> Action5 (MustWhiteSpacing Params)?)> */
+		/* 1 Statement <- <(Action0 WhiteSpacing <&.> Action1 (IfExpr / ForExpr / IncludeExpr / ParamDecl / OutputDecl / CmdExpr / Declaration / Comment) WhiteSpacing InlineComment? EndOfLine? Action2)> */
 		func() bool {
-			position67, tokenIndex67 := position, tokenIndex
+			position14, tokenIndex14 := position, tokenIndex
 			{
-				position68 := position
-				{
-					position69 := position
-					{
-						position70 := position
-						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l67
-						}
-						position++
-					l71:
-						{
-							position72, tokenIndex72 := position, tokenIndex
-							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l72
-							}
-							position++
-							goto l71
-						l72:
-							position, tokenIndex = position72, tokenIndex72
-						}
-						add(ruleAction, position70)
-					}
-					add(rulePegText, position69)
-				}
+				position15 := position
 				{
-					add(ruleAction4, position)
+					add(ruleAction0, position)
 				}
-				if !_rules[ruleMustWhiteSpacing]() {
-					goto l67
+				if !_rules[ruleWhiteSpacing]() {
+					goto l14
 				}
 				{
-					position74 := position
+					position17 := position
 					{
-						position75 := position
-						{
-							position78, tokenIndex78 := position, tokenIndex
-							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l79
-							}
-							position++
-							goto l78
-						l79:
-							position, tokenIndex = position78, tokenIndex78
-							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l67
-							}
-							position++
-						}
-					l78:
-					l76:
-						{
-							position77, tokenIndex77 := position, tokenIndex
-							{
-								position80, tokenIndex80 := position, tokenIndex
-								if c := buffer[position]; c < rune('a') || c > rune('z') {
-									goto l81
-								}
-								position++
-								goto l80
-							l81:
-								position, tokenIndex = position80, tokenIndex80
-								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l77
-								}
-								position++
-							}
-						l80:
-							goto l76
-						l77:
-							position, tokenIndex = position77, tokenIndex77
+						position18, tokenIndex18 := position, tokenIndex
+						if !matchDot() {
+							goto l14
 						}
-						add(ruleEntity, position75)
+						position, tokenIndex = position18, tokenIndex18
 					}
-					add(rulePegText, position74)
+					add(rulePegText, position17)
 				}
 				{
-					add(ruleAction5, position)
+					add(ruleAction1, position)
 				}
 				{
-					position83, tokenIndex83 := position, tokenIndex
-					if !_rules[ruleMustWhiteSpacing]() {
-						goto l83
+					position20, tokenIndex20 := position, tokenIndex
+					if !_rules[ruleIfExpr]() {
+						goto l21
 					}
+					goto l20
+				l21:
+					position, tokenIndex = position20, tokenIndex20
 					{
-						position85 := position
+						position23 := position
+						if buffer[position] != rune('f') {
+							goto l22
+						}
+						position++
+						if buffer[position] != rune('o') {
+							goto l22
+						}
+						position++
+						if buffer[position] != rune('r') {
+							goto l22
+						}
+						position++
+						if !_rules[ruleMustWhiteSpacing]() {
+							goto l22
+						}
 						{
-							position88 := position
-							{
-								position89 := position
-								if !_rules[ruleIdentifier]() {
-									goto l83
-								}
-								add(rulePegText, position89)
+							position24 := position
+							if !_rules[ruleIdentifier]() {
+								goto l22
+							}
+							add(rulePegText, position24)
+						}
+						{
+							add(ruleAction15, position)
+						}
+						if !_rules[ruleMustWhiteSpacing]() {
+							goto l22
+						}
+						if buffer[position] != rune('i') {
+							goto l22
+						}
+						position++
+						if buffer[position] != rune('n') {
+							goto l22
+						}
+						position++
+						if !_rules[ruleMustWhiteSpacing]() {
+							goto l22
+						}
+						if !_rules[ruleCompositeValue]() {
+							goto l22
+						}
+						if !_rules[ruleWhiteSpacing]() {
+							goto l22
+						}
+						if buffer[position] != rune('{') {
+							goto l22
+						}
+						position++
+						{
+							add(ruleAction16, position)
+						}
+						if !_rules[ruleBlock]() {
+							goto l22
+						}
+						if buffer[position] != rune('}') {
+							goto l22
+						}
+						position++
+						{
+							add(ruleAction17, position)
+						}
+						add(ruleForExpr, position23)
+					}
+					goto l20
+				l22:
+					position, tokenIndex = position20, tokenIndex20
+					{
+						position29 := position
+						{
+							position30, tokenIndex30 := position, tokenIndex
+							{
+								position32 := position
+								if !_rules[ruleIdentifier]() {
+									goto l30
+								}
+								add(rulePegText, position32)
+							}
+							{
+								add(ruleAction18, position)
+							}
+							if !_rules[ruleEqual]() {
+								goto l30
+							}
+							goto l31
+						l30:
+							position, tokenIndex = position30, tokenIndex30
+						}
+					l31:
+						if buffer[position] != rune('i') {
+							goto l28
+						}
+						position++
+						if buffer[position] != rune('n') {
+							goto l28
+						}
+						position++
+						if buffer[position] != rune('c') {
+							goto l28
+						}
+						position++
+						if buffer[position] != rune('l') {
+							goto l28
+						}
+						position++
+						if buffer[position] != rune('u') {
+							goto l28
+						}
+						position++
+						if buffer[position] != rune('d') {
+							goto l28
+						}
+						position++
+						if buffer[position] != rune('e') {
+							goto l28
+						}
+						position++
+						if !_rules[ruleMustWhiteSpacing]() {
+							goto l28
+						}
+						{
+							position34 := position
+							{
+								switch buffer[position] {
+								case '\'':
+									if !_rules[ruleSingleQuote]() {
+										goto l28
+									}
+									{
+										position36 := position
+										{
+											position39, tokenIndex39 := position, tokenIndex
+											if buffer[position] != rune('\'') {
+												goto l39
+											}
+											position++
+											goto l28
+										l39:
+											position, tokenIndex = position39, tokenIndex39
+										}
+										if !matchDot() {
+											goto l28
+										}
+									l37:
+										{
+											position38, tokenIndex38 := position, tokenIndex
+											{
+												position40, tokenIndex40 := position, tokenIndex
+												if buffer[position] != rune('\'') {
+													goto l40
+												}
+												position++
+												goto l38
+											l40:
+												position, tokenIndex = position40, tokenIndex40
+											}
+											if !matchDot() {
+												goto l38
+											}
+											goto l37
+										l38:
+											position, tokenIndex = position38, tokenIndex38
+										}
+										add(rulePegText, position36)
+									}
+									if !_rules[ruleSingleQuote]() {
+										goto l28
+									}
+								case '"':
+									if !_rules[ruleDoubleQuote]() {
+										goto l28
+									}
+									{
+										position41 := position
+										{
+											position44, tokenIndex44 := position, tokenIndex
+											if buffer[position] != rune('"') {
+												goto l44
+											}
+											position++
+											goto l28
+										l44:
+											position, tokenIndex = position44, tokenIndex44
+										}
+										if !matchDot() {
+											goto l28
+										}
+									l42:
+										{
+											position43, tokenIndex43 := position, tokenIndex
+											{
+												position45, tokenIndex45 := position, tokenIndex
+												if buffer[position] != rune('"') {
+													goto l45
+												}
+												position++
+												goto l43
+											l45:
+												position, tokenIndex = position45, tokenIndex45
+											}
+											if !matchDot() {
+												goto l43
+											}
+											goto l42
+										l43:
+											position, tokenIndex = position43, tokenIndex43
+										}
+										add(rulePegText, position41)
+									}
+									if !_rules[ruleDoubleQuote]() {
+										goto l28
+									}
+								default:
+									{
+										position46 := position
+										if !_rules[ruleUnquotedParam]() {
+											goto l28
+										}
+										add(rulePegText, position46)
+									}
+								}
+							}
+
+							add(ruleIncludePath, position34)
+						}
+						{
+							add(ruleAction19, position)
+						}
+						{
+							position48, tokenIndex48 := position, tokenIndex
+							if !_rules[ruleMustWhiteSpacing]() {
+								goto l48
+							}
+							if !_rules[ruleParams]() {
+								goto l48
+							}
+							goto l49
+						l48:
+							position, tokenIndex = position48, tokenIndex48
+						}
+					l49:
+						add(ruleIncludeExpr, position29)
+					}
+					goto l20
+				l28:
+					position, tokenIndex = position20, tokenIndex20
+					{
+						position51 := position
+						if buffer[position] != rune('p') {
+							goto l50
+						}
+						position++
+						if buffer[position] != rune('a') {
+							goto l50
+						}
+						position++
+						if buffer[position] != rune('r') {
+							goto l50
+						}
+						position++
+						if buffer[position] != rune('a') {
+							goto l50
+						}
+						position++
+						if buffer[position] != rune('m') {
+							goto l50
+						}
+						position++
+						if !_rules[ruleMustWhiteSpacing]() {
+							goto l50
+						}
+						{
+							position52 := position
+							if !_rules[ruleIdentifier]() {
+								goto l50
+							}
+							add(rulePegText, position52)
+						}
+						{
+							add(ruleAction20, position)
+						}
+						if !_rules[ruleWhiteSpacing]() {
+							goto l50
+						}
+						if buffer[position] != rune(':') {
+							goto l50
+						}
+						position++
+						if !_rules[ruleWhiteSpacing]() {
+							goto l50
+						}
+						{
+							position54 := position
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l50
+							}
+							position++
+						l55:
+							{
+								position56, tokenIndex56 := position, tokenIndex
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l56
+								}
+								position++
+								goto l55
+							l56:
+								position, tokenIndex = position56, tokenIndex56
+							}
+							add(rulePegText, position54)
+						}
+						{
+							add(ruleAction21, position)
+						}
+						{
+							position58, tokenIndex58 := position, tokenIndex
+							if !_rules[ruleMustWhiteSpacing]() {
+								goto l58
+							}
+							if buffer[position] != rune('i') {
+								goto l58
+							}
+							position++
+							if buffer[position] != rune('n') {
+								goto l58
+							}
+							position++
+							if !_rules[ruleMustWhiteSpacing]() {
+								goto l58
+							}
+							if !_rules[ruleCompositeValue]() {
+								goto l58
+							}
+							{
+								add(ruleAction22, position)
+							}
+							goto l59
+						l58:
+							position, tokenIndex = position58, tokenIndex58
+						}
+					l59:
+						{
+							position61, tokenIndex61 := position, tokenIndex
+							if !_rules[ruleWhiteSpacing]() {
+								goto l61
+							}
+							if buffer[position] != rune('=') {
+								goto l61
+							}
+							position++
+							if !_rules[ruleWhiteSpacing]() {
+								goto l61
+							}
+							if !_rules[ruleCompositeValue]() {
+								goto l61
+							}
+							{
+								add(ruleAction23, position)
+							}
+							goto l62
+						l61:
+							position, tokenIndex = position61, tokenIndex61
+						}
+					l62:
+						{
+							position64, tokenIndex64 := position, tokenIndex
+							if !_rules[ruleWhiteSpacing]() {
+								goto l64
+							}
+							if !_rules[ruleDoubleQuote]() {
+								goto l64
+							}
+							{
+								position66 := position
+							l67:
+								{
+									position68, tokenIndex68 := position, tokenIndex
+									{
+										position69, tokenIndex69 := position, tokenIndex
+										if buffer[position] != rune('"') {
+											goto l69
+										}
+										position++
+										goto l68
+									l69:
+										position, tokenIndex = position69, tokenIndex69
+									}
+									if !matchDot() {
+										goto l68
+									}
+									goto l67
+								l68:
+									position, tokenIndex = position68, tokenIndex68
+								}
+								add(rulePegText, position66)
+							}
+							if !_rules[ruleDoubleQuote]() {
+								goto l64
+							}
+							{
+								add(ruleAction24, position)
+							}
+							goto l65
+						l64:
+							position, tokenIndex = position64, tokenIndex64
+						}
+					l65:
+						add(ruleParamDecl, position51)
+					}
+					goto l20
+				l50:
+					position, tokenIndex = position20, tokenIndex20
+					{
+						position72 := position
+						if buffer[position] != rune('o') {
+							goto l71
+						}
+						position++
+						if buffer[position] != rune('u') {
+							goto l71
+						}
+						position++
+						if buffer[position] != rune('t') {
+							goto l71
+						}
+						position++
+						if buffer[position] != rune('p') {
+							goto l71
+						}
+						position++
+						if buffer[position] != rune('u') {
+							goto l71
+						}
+						position++
+						if buffer[position] != rune('t') {
+							goto l71
+						}
+						position++
+						if !_rules[ruleMustWhiteSpacing]() {
+							goto l71
+						}
+						{
+							position73 := position
+							if !_rules[ruleIdentifier]() {
+								goto l71
+							}
+							add(rulePegText, position73)
+						}
+						{
+							add(ruleAction25, position)
+						}
+						if !_rules[ruleEqual]() {
+							goto l71
+						}
+						if !_rules[ruleCompositeValue]() {
+							goto l71
+						}
+						add(ruleOutputDecl, position72)
+					}
+					goto l20
+				l71:
+					position, tokenIndex = position20, tokenIndex20
+					if !_rules[ruleCmdExpr]() {
+						goto l75
+					}
+					goto l20
+				l75:
+					position, tokenIndex = position20, tokenIndex20
+					{
+						position77 := position
+						{
+							position78 := position
+							if !_rules[ruleIdentifier]() {
+								goto l76
+							}
+							add(rulePegText, position78)
+						}
+						{
+							add(ruleAction3, position)
+						}
+						if !_rules[ruleEqual]() {
+							goto l76
+						}
+						{
+							position80, tokenIndex80 := position, tokenIndex
+							if !_rules[ruleCmdExpr]() {
+								goto l81
+							}
+							goto l80
+						l81:
+							position, tokenIndex = position80, tokenIndex80
+							{
+								position82 := position
+								{
+									add(ruleAction4, position)
+								}
+								if !_rules[ruleCompositeValue]() {
+									goto l76
+								}
+								add(ruleValueExpr, position82)
+							}
+						}
+					l80:
+						add(ruleDeclaration, position77)
+					}
+					goto l20
+				l76:
+					position, tokenIndex = position20, tokenIndex20
+					{
+						position84 := position
+						{
+							position85 := position
+							{
+								position86, tokenIndex86 := position, tokenIndex
+								if buffer[position] != rune('#') {
+									goto l87
+								}
+								position++
+								goto l86
+							l87:
+								position, tokenIndex = position86, tokenIndex86
+								if buffer[position] != rune('/') {
+									goto l14
+								}
+								position++
+								if buffer[position] != rune('/') {
+									goto l14
+								}
+								position++
+							}
+						l86:
+						l88:
+							{
+								position89, tokenIndex89 := position, tokenIndex
+								{
+									position90, tokenIndex90 := position, tokenIndex
+									if !_rules[ruleEndOfLine]() {
+										goto l90
+									}
+									goto l89
+								l90:
+									position, tokenIndex = position90, tokenIndex90
+								}
+								if !matchDot() {
+									goto l89
+								}
+								goto l88
+							l89:
+								position, tokenIndex = position89, tokenIndex89
+							}
+							add(rulePegText, position85)
+						}
+						{
+							add(ruleAction58, position)
+						}
+						add(ruleComment, position84)
+					}
+				}
+			l20:
+				if !_rules[ruleWhiteSpacing]() {
+					goto l14
+				}
+				{
+					position92, tokenIndex92 := position, tokenIndex
+					{
+						position94 := position
+						{
+							position95 := position
+							{
+								position96, tokenIndex96 := position, tokenIndex
+								if buffer[position] != rune('#') {
+									goto l97
+								}
+								position++
+								goto l96
+							l97:
+								position, tokenIndex = position96, tokenIndex96
+								if buffer[position] != rune('/') {
+									goto l92
+								}
+								position++
+								if buffer[position] != rune('/') {
+									goto l92
+								}
+								position++
+							}
+						l96:
+						l98:
+							{
+								position99, tokenIndex99 := position, tokenIndex
+								{
+									position100, tokenIndex100 := position, tokenIndex
+									if !_rules[ruleEndOfLine]() {
+										goto l100
+									}
+									goto l99
+								l100:
+									position, tokenIndex = position100, tokenIndex100
+								}
+								if !matchDot() {
+									goto l99
+								}
+								goto l98
+							l99:
+								position, tokenIndex = position99, tokenIndex99
+							}
+							add(rulePegText, position95)
+						}
+						{
+							add(ruleAction59, position)
+						}
+						add(ruleInlineComment, position94)
+					}
+					goto l93
+				l92:
+					position, tokenIndex = position92, tokenIndex92
+				}
+			l93:
+				{
+					position102, tokenIndex102 := position, tokenIndex
+					if !_rules[ruleEndOfLine]() {
+						goto l102
+					}
+					goto l103
+				l102:
+					position, tokenIndex = position102, tokenIndex102
+				}
+			l103:
+				{
+					add(ruleAction2, position)
+				}
+				add(ruleStatement, position15)
+			}
+			return true
+		l14:
+			position, tokenIndex = position14, tokenIndex14
+			return false
+		},
+		/* 2 Action <- <[a-z]+> */
+		nil,
+		/* 3 Entity <- <([a-z] / [0-9])+> */
+		nil,
+		/* 4 Declaration <- <(<Identifier> Action3 Equal (CmdExpr / ValueExpr))> */
+		nil,
+		/* 5 ValueExpr <- <(Action4 CompositeValue)> */
+		nil,
+		/* 6 CmdExpr <- <(<Action> Action5 MustWhiteSpacing <Entity> Action6 (MustWhiteSpacing Params)?)> */
+		func() bool {
+			position109, tokenIndex109 := position, tokenIndex
+			{
+				position110 := position
+				{
+					position111 := position
+					{
+						position112 := position
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l109
+						}
+						position++
+					l113:
+						{
+							position114, tokenIndex114 := position, tokenIndex
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l114
+							}
+							position++
+							goto l113
+						l114:
+							position, tokenIndex = position114, tokenIndex114
+						}
+						add(ruleAction, position112)
+					}
+					add(rulePegText, position111)
+				}
+				{
+					add(ruleAction5, position)
+				}
+				if !_rules[ruleMustWhiteSpacing]() {
+					goto l109
+				}
+				{
+					position116 := position
+					{
+						position117 := position
+						{
+							position120, tokenIndex120 := position, tokenIndex
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l121
+							}
+							position++
+							goto l120
+						l121:
+							position, tokenIndex = position120, tokenIndex120
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l109
+							}
+							position++
+						}
+					l120:
+					l118:
+						{
+							position119, tokenIndex119 := position, tokenIndex
+							{
+								position122, tokenIndex122 := position, tokenIndex
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l123
+								}
+								position++
+								goto l122
+							l123:
+								position, tokenIndex = position122, tokenIndex122
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l119
+								}
+								position++
+							}
+						l122:
+							goto l118
+						l119:
+							position, tokenIndex = position119, tokenIndex119
+						}
+						add(ruleEntity, position117)
+					}
+					add(rulePegText, position116)
+				}
+				{
+					add(ruleAction6, position)
+				}
+				{
+					position125, tokenIndex125 := position, tokenIndex
+					if !_rules[ruleMustWhiteSpacing]() {
+						goto l125
+					}
+					if !_rules[ruleParams]() {
+						goto l125
+					}
+					goto l126
+				l125:
+					position, tokenIndex = position125, tokenIndex125
+				}
+			l126:
+				add(ruleCmdExpr, position110)
+			}
+			return true
+		l109:
+			position, tokenIndex = position109, tokenIndex109
+			return false
+		},
+		/* 7 IfExpr <- <('i' 'f' MustWhiteSpacing Action7 Condition WhiteSpacing '{' Action8 Block '}' (WhiteSpacing ('e' 'l' 's' 'e') ElseExpr)? Action9)> */
+		func() bool {
+			position127, tokenIndex127 := position, tokenIndex
+			{
+				position128 := position
+				if buffer[position] != rune('i') {
+					goto l127
+				}
+				position++
+				if buffer[position] != rune('f') {
+					goto l127
+				}
+				position++
+				if !_rules[ruleMustWhiteSpacing]() {
+					goto l127
+				}
+				{
+					add(ruleAction7, position)
+				}
+				{
+					position130 := position
+					if !_rules[ruleValue]() {
+						goto l127
+					}
+					{
+						position131, tokenIndex131 := position, tokenIndex
+						if !_rules[ruleWhiteSpacing]() {
+							goto l131
+						}
+						{
+							position133 := position
+							{
+								position134 := position
+								{
+									position135, tokenIndex135 := position, tokenIndex
+									if buffer[position] != rune('=') {
+										goto l136
+									}
+									position++
+									if buffer[position] != rune('=') {
+										goto l136
+									}
+									position++
+									goto l135
+								l136:
+									position, tokenIndex = position135, tokenIndex135
+									if buffer[position] != rune('!') {
+										goto l131
+									}
+									position++
+									if buffer[position] != rune('=') {
+										goto l131
+									}
+									position++
+								}
+							l135:
+								add(ruleComparisonOperator, position134)
+							}
+							add(rulePegText, position133)
+						}
+						{
+							add(ruleAction14, position)
+						}
+						if !_rules[ruleWhiteSpacing]() {
+							goto l131
+						}
+						if !_rules[ruleValue]() {
+							goto l131
+						}
+						goto l132
+					l131:
+						position, tokenIndex = position131, tokenIndex131
+					}
+				l132:
+					add(ruleCondition, position130)
+				}
+				if !_rules[ruleWhiteSpacing]() {
+					goto l127
+				}
+				if buffer[position] != rune('{') {
+					goto l127
+				}
+				position++
+				{
+					add(ruleAction8, position)
+				}
+				if !_rules[ruleBlock]() {
+					goto l127
+				}
+				if buffer[position] != rune('}') {
+					goto l127
+				}
+				position++
+				{
+					position139, tokenIndex139 := position, tokenIndex
+					if !_rules[ruleWhiteSpacing]() {
+						goto l139
+					}
+					if buffer[position] != rune('e') {
+						goto l139
+					}
+					position++
+					if buffer[position] != rune('l') {
+						goto l139
+					}
+					position++
+					if buffer[position] != rune('s') {
+						goto l139
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l139
+					}
+					position++
+					{
+						position141 := position
+						{
+							position142, tokenIndex142 := position, tokenIndex
+							if !_rules[ruleMustWhiteSpacing]() {
+								goto l143
 							}
 							{
-								add(ruleAction6, position)
+								add(ruleAction10, position)
 							}
-							if !_rules[ruleEqual]() {
-								goto l83
+							{
+								add(ruleAction11, position)
 							}
-							if !_rules[ruleCompositeValue]() {
-								goto l83
+							if !_rules[ruleIfExpr]() {
+								goto l143
 							}
+							{
+								add(ruleAction12, position)
+							}
+							goto l142
+						l143:
+							position, tokenIndex = position142, tokenIndex142
 							if !_rules[ruleWhiteSpacing]() {
-								goto l83
+								goto l139
 							}
-							add(ruleParam, position88)
-						}
-					l86:
-						{
-							position87, tokenIndex87 := position, tokenIndex
+							if buffer[position] != rune('{') {
+								goto l139
+							}
+							position++
 							{
-								position91 := position
-								{
-									position92 := position
-									if !_rules[ruleIdentifier]() {
-										goto l87
-									}
-									add(rulePegText, position92)
-								}
-								{
-									add(ruleAction6, position)
-								}
-								if !_rules[ruleEqual]() {
-									goto l87
-								}
-								if !_rules[ruleCompositeValue]() {
-									goto l87
-								}
-								if !_rules[ruleWhiteSpacing]() {
-									goto l87
-								}
-								add(ruleParam, position91)
+								add(ruleAction13, position)
+							}
+							if !_rules[ruleBlock]() {
+								goto l139
 							}
-							goto l86
-						l87:
-							position, tokenIndex = position87, tokenIndex87
+							if buffer[position] != rune('}') {
+								goto l139
+							}
+							position++
 						}
-						add(ruleParams, position85)
+					l142:
+						add(ruleElseExpr, position141)
 					}
-					goto l84
-				l83:
-					position, tokenIndex = position83, tokenIndex83
+					goto l140
+				l139:
+					position, tokenIndex = position139, tokenIndex139
+				}
+			l140:
+				{
+					add(ruleAction9, position)
 				}
-			l84:
-				add(ruleCmdExpr, position68)
+				add(ruleIfExpr, position128)
 			}
 			return true
-		l67:
-			position, tokenIndex = position67, tokenIndex67
+		l127:
+			position, tokenIndex = position127, tokenIndex127
 			return false
 		},
-		/* 7 Params <- <Param+> */
+		/* 8 ElseExpr <- <((MustWhiteSpacing Action10 Action11 IfExpr Action12) / (WhiteSpacing '{' Action13 Block '}'))> */
 		nil,
-		/* 8 Param <- <(<Identifier> Action6 Equal CompositeValue WhiteSpacing)> */
+		/* 9 Condition <- <(Value (WhiteSpacing <ComparisonOperator> Action14 WhiteSpacing Value)?)> */
+		nil,
+		/* 10 ComparisonOperator <- <(('=' '=') / ('!' '='))> */
+		nil,
+		/* 11 ForExpr <- <('f' 'o' 'r' MustWhiteSpacing <Identifier> Action15 MustWhiteSpacing ('i' 'n') MustWhiteSpacing CompositeValue WhiteSpacing '{' Action16 Block '}' Action17)> */
+		nil,
+		/* 12 IncludeExpr <- <((<Identifier> Action18 Equal)? ('i' 'n' 'c' 'l' 'u' 'd' 'e') MustWhiteSpacing IncludePath Action19 (MustWhiteSpacing Params)?)> */
+		nil,
+		/* 13 IncludePath <- <((&('\'') (SingleQuote <(!'\'' .)+> SingleQuote)) | (&('"') (DoubleQuote <(!'"' .)+> DoubleQuote)) | (&('*' | '+' | '-' | '.' | '/' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9' | ':' | ';' | '<' | '>' | '@' | 'A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z' | '~') <UnquotedParam>))> */
+		nil,
+		/* 14 ParamDecl <- <('p' 'a' 'r' 'a' 'm' MustWhiteSpacing <Identifier> Action20 WhiteSpacing ':' WhiteSpacing <[a-z]+> Action21 (MustWhiteSpacing ('i' 'n') MustWhiteSpacing CompositeValue Action22)? (WhiteSpacing '=' WhiteSpacing CompositeValue Action23)? (WhiteSpacing DoubleQuote <(!'"' .)*> DoubleQuote Action24)?)> */
+		nil,
+		/* 15 OutputDecl <- <('o' 'u' 't' 'p' 'u' 't' MustWhiteSpacing <Identifier> Action25 Equal CompositeValue)> */
+		nil,
+		/* 16 Block <- <(WhiteSpacing EndOfLine? (BlankLine* Statement BlankLine*)* WhiteSpacing)> */
+		func() bool {
+			position157, tokenIndex157 := position, tokenIndex
+			{
+				position158 := position
+				if !_rules[ruleWhiteSpacing]() {
+					goto l157
+				}
+				{
+					position159, tokenIndex159 := position, tokenIndex
+					if !_rules[ruleEndOfLine]() {
+						goto l159
+					}
+					goto l160
+				l159:
+					position, tokenIndex = position159, tokenIndex159
+				}
+			l160:
+			l161:
+				{
+					position162, tokenIndex162 := position, tokenIndex
+				l163:
+					{
+						position164, tokenIndex164 := position, tokenIndex
+						if !_rules[ruleBlankLine]() {
+							goto l164
+						}
+						goto l163
+					l164:
+						position, tokenIndex = position164, tokenIndex164
+					}
+					if !_rules[ruleStatement]() {
+						goto l162
+					}
+				l165:
+					{
+						position166, tokenIndex166 := position, tokenIndex
+						if !_rules[ruleBlankLine]() {
+							goto l166
+						}
+						goto l165
+					l166:
+						position, tokenIndex = position166, tokenIndex166
+					}
+					goto l161
+				l162:
+					position, tokenIndex = position162, tokenIndex162
+				}
+				if !_rules[ruleWhiteSpacing]() {
+					goto l157
+				}
+				add(ruleBlock, position158)
+			}
+			return true
+		l157:
+			position, tokenIndex = position157, tokenIndex157
+			return false
+		},
+		/* 17 Params <- <Param+> */
+		func() bool {
+			position167, tokenIndex167 := position, tokenIndex
+			{
+				position168 := position
+				{
+					position171 := position
+					{
+						position172 := position
+						if !_rules[ruleIdentifier]() {
+							goto l167
+						}
+						add(rulePegText, position172)
+					}
+					{
+						add(ruleAction26, position)
+					}
+					if !_rules[ruleEqual]() {
+						goto l167
+					}
+					if !_rules[ruleCompositeValue]() {
+						goto l167
+					}
+					if !_rules[ruleWhiteSpacing]() {
+						goto l167
+					}
+					add(ruleParam, position171)
+				}
+			l169:
+				{
+					position170, tokenIndex170 := position, tokenIndex
+					{
+						position174 := position
+						{
+							position175 := position
+							if !_rules[ruleIdentifier]() {
+								goto l170
+							}
+							add(rulePegText, position175)
+						}
+						{
+							add(ruleAction26, position)
+						}
+						if !_rules[ruleEqual]() {
+							goto l170
+						}
+						if !_rules[ruleCompositeValue]() {
+							goto l170
+						}
+						if !_rules[ruleWhiteSpacing]() {
+							goto l170
+						}
+						add(ruleParam, position174)
+					}
+					goto l169
+				l170:
+					position, tokenIndex = position170, tokenIndex170
+				}
+				add(ruleParams, position168)
+			}
+			return true
+		l167:
+			position, tokenIndex = position167, tokenIndex167
+			return false
+		},
+		/* 18 Param <- <(<Identifier> Action26 Equal CompositeValue WhiteSpacing)> */
 		nil,
-		/* 9 Identifier <- <((&('.') '.') | (&('_') '_') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> */
+		/* 19 Identifier <- <((&('.') '.') | (&('_') '_') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> */
 		func() bool {
-			position96, tokenIndex96 := position, tokenIndex
+			position178, tokenIndex178 := position, tokenIndex
 			{
-				position97 := position
+				position179 := position
 				{
 					switch buffer[position] {
 					case '.':
 						if buffer[position] != rune('.') {
-							goto l96
+							goto l178
 						}
 						position++
-						break
 					case '_':
 						if buffer[position] != rune('_') {
-							goto l96
+							goto l178
 						}
 						position++
-						break
 					case '-':
 						if buffer[position] != rune('-') {
-							goto l96
+							goto l178
 						}
 						position++
-						break
 					case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l96
+							goto l178
 						}
 						position++
-						break
 					case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l96
+							goto l178
 						}
 						position++
-						break
 					default:
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l96
+							goto l178
 						}
 						position++
-						break
 					}
 				}
 
-			l98:
+			l180:
 				{
-					position99, tokenIndex99 := position, tokenIndex
+					position181, tokenIndex181 := position, tokenIndex
 					{
 						switch buffer[position] {
 						case '.':
 							if buffer[position] != rune('.') {
-								goto l99
+								goto l181
 							}
 							position++
-							break
 						case '_':
 							if buffer[position] != rune('_') {
-								goto l99
+								goto l181
 							}
 							position++
-							break
 						case '-':
 							if buffer[position] != rune('-') {
-								goto l99
+								goto l181
 							}
 							position++
-							break
 						case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l99
+								goto l181
 							}
 							position++
-							break
 						case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l99
+								goto l181
 							}
 							position++
-							break
 						default:
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l99
+								goto l181
 							}
 							position++
-							break
 						}
 					}
 
-					goto l98
-				l99:
-					position, tokenIndex = position99, tokenIndex99
+					goto l180
+				l181:
+					position, tokenIndex = position181, tokenIndex181
 				}
-				add(ruleIdentifier, position97)
+				add(ruleIdentifier, position179)
 			}
 			return true
-		l96:
-			position, tokenIndex = position96, tokenIndex96
+		l178:
+			position, tokenIndex = position178, tokenIndex178
 			return false
 		},
-		/* 10 CompositeValue <- <(ListValue / ListWithoutSquareBrackets / Value)> */
+		/* 20 CompositeValue <- <(<&.> Action27 (ListValue / MapValue / ListWithoutSquareBrackets / Value))> */
 		func() bool {
-			position102, tokenIndex102 := position, tokenIndex
+			position184, tokenIndex184 := position, tokenIndex
 			{
-				position103 := position
+				position185 := position
+				{
+					position186 := position
+					{
+						position187, tokenIndex187 := position, tokenIndex
+						if !matchDot() {
+							goto l184
+						}
+						position, tokenIndex = position187, tokenIndex187
+					}
+					add(rulePegText, position186)
+				}
+				{
+					add(ruleAction27, position)
+				}
 				{
-					position104, tokenIndex104 := position, tokenIndex
+					position189, tokenIndex189 := position, tokenIndex
+					if !_rules[ruleListValue]() {
+						goto l190
+					}
+					goto l189
+				l190:
+					position, tokenIndex = position189, tokenIndex189
 					{
-						position106 := position
+						position192 := position
 						{
-							add(ruleAction7, position)
+							position193, tokenIndex193 := position, tokenIndex
+							if !_rules[ruleHole]() {
+								goto l193
+							}
+							goto l191
+						l193:
+							position, tokenIndex = position193, tokenIndex193
+						}
+						{
+							add(ruleAction32, position)
+						}
+						if buffer[position] != rune('{') {
+							goto l191
+						}
+						position++
+						if !_rules[ruleWhiteSpacing]() {
+							goto l191
+						}
+						{
+							position195, tokenIndex195 := position, tokenIndex
+							if !_rules[ruleMapEntry]() {
+								goto l195
+							}
+							if !_rules[ruleWhiteSpacing]() {
+								goto l195
+							}
+						l197:
+							{
+								position198, tokenIndex198 := position, tokenIndex
+								if buffer[position] != rune(',') {
+									goto l198
+								}
+								position++
+								if !_rules[ruleWhiteSpacing]() {
+									goto l198
+								}
+								if !_rules[ruleMapEntry]() {
+									goto l198
+								}
+								if !_rules[ruleWhiteSpacing]() {
+									goto l198
+								}
+								goto l197
+							l198:
+								position, tokenIndex = position198, tokenIndex198
+							}
+							goto l196
+						l195:
+							position, tokenIndex = position195, tokenIndex195
+						}
+					l196:
+						if buffer[position] != rune('}') {
+							goto l191
+						}
+						position++
+						{
+							add(ruleAction33, position)
+						}
+						add(ruleMapValue, position192)
+					}
+					goto l189
+				l191:
+					position, tokenIndex = position189, tokenIndex189
+					{
+						position201 := position
+						{
+							add(ruleAction30, position)
+						}
+						if !_rules[ruleWhiteSpacing]() {
+							goto l200
 						}
-						if buffer[position] != rune('[') {
-							goto l105
+						if !_rules[ruleValue]() {
+							goto l200
+						}
+						if !_rules[ruleWhiteSpacing]() {
+							goto l200
+						}
+						if buffer[position] != rune(',') {
+							goto l200
 						}
 						position++
+						if !_rules[ruleWhiteSpacing]() {
+							goto l200
+						}
+						if !_rules[ruleValue]() {
+							goto l200
+						}
+						if !_rules[ruleWhiteSpacing]() {
+							goto l200
+						}
+					l203:
 						{
-							position108, tokenIndex108 := position, tokenIndex
+							position204, tokenIndex204 := position, tokenIndex
+							if buffer[position] != rune(',') {
+								goto l204
+							}
+							position++
 							if !_rules[ruleWhiteSpacing]() {
-								goto l108
+								goto l204
 							}
 							if !_rules[ruleValue]() {
-								goto l108
+								goto l204
 							}
 							if !_rules[ruleWhiteSpacing]() {
-								goto l108
+								goto l204
 							}
-							goto l109
-						l108:
-							position, tokenIndex = position108, tokenIndex108
+							goto l203
+						l204:
+							position, tokenIndex = position204, tokenIndex204
+						}
+						{
+							add(ruleAction31, position)
+						}
+						add(ruleListWithoutSquareBrackets, position201)
+					}
+					goto l189
+				l200:
+					position, tokenIndex = position189, tokenIndex189
+					if !_rules[ruleValue]() {
+						goto l184
+					}
+				}
+			l189:
+				add(ruleCompositeValue, position185)
+			}
+			return true
+		l184:
+			position, tokenIndex = position184, tokenIndex184
+			return false
+		},
+		/* 21 ListValue <- <(Action28 '[' (WhiteSpacing Value WhiteSpacing)? (',' WhiteSpacing Value WhiteSpacing)* ']' Action29)> */
+		func() bool {
+			position206, tokenIndex206 := position, tokenIndex
+			{
+				position207 := position
+				{
+					add(ruleAction28, position)
+				}
+				if buffer[position] != rune('[') {
+					goto l206
+				}
+				position++
+				{
+					position209, tokenIndex209 := position, tokenIndex
+					if !_rules[ruleWhiteSpacing]() {
+						goto l209
+					}
+					if !_rules[ruleValue]() {
+						goto l209
+					}
+					if !_rules[ruleWhiteSpacing]() {
+						goto l209
+					}
+					goto l210
+				l209:
+					position, tokenIndex = position209, tokenIndex209
+				}
+			l210:
+			l211:
+				{
+					position212, tokenIndex212 := position, tokenIndex
+					if buffer[position] != rune(',') {
+						goto l212
+					}
+					position++
+					if !_rules[ruleWhiteSpacing]() {
+						goto l212
+					}
+					if !_rules[ruleValue]() {
+						goto l212
+					}
+					if !_rules[ruleWhiteSpacing]() {
+						goto l212
+					}
+					goto l211
+				l212:
+					position, tokenIndex = position212, tokenIndex212
+				}
+				if buffer[position] != rune(']') {
+					goto l206
+				}
+				position++
+				{
+					add(ruleAction29, position)
+				}
+				add(ruleListValue, position207)
+			}
+			return true
+		l206:
+			position, tokenIndex = position206, tokenIndex206
+			return false
+		},
+		/* 22 ListWithoutSquareBrackets <- <(Action30 (WhiteSpacing Value WhiteSpacing) (',' WhiteSpacing Value WhiteSpacing)+ Action31)> */
+		nil,
+		/* 23 MapValue <- <(!Hole Action32 '{' WhiteSpacing (MapEntry WhiteSpacing (',' WhiteSpacing MapEntry WhiteSpacing)*)? '}' Action33)> */
+		nil,
+		/* 24 MapEntry <- <(MapKey WhiteSpacing ':' WhiteSpacing Value)> */
+		func() bool {
+			position216, tokenIndex216 := position, tokenIndex
+			{
+				position217 := position
+				{
+					position218 := position
+					{
+						position219, tokenIndex219 := position, tokenIndex
+						{
+							position221 := position
+							if !_rules[ruleIdentifier]() {
+								goto l220
+							}
+							add(rulePegText, position221)
+						}
+						{
+							add(ruleAction34, position)
+						}
+						goto l219
+					l220:
+						position, tokenIndex = position219, tokenIndex219
+						if !_rules[ruleQuotedString]() {
+							goto l216
+						}
+						{
+							add(ruleAction35, position)
+						}
+					}
+				l219:
+					add(ruleMapKey, position218)
+				}
+				if !_rules[ruleWhiteSpacing]() {
+					goto l216
+				}
+				if buffer[position] != rune(':') {
+					goto l216
+				}
+				position++
+				if !_rules[ruleWhiteSpacing]() {
+					goto l216
+				}
+				if !_rules[ruleValue]() {
+					goto l216
+				}
+				add(ruleMapEntry, position217)
+			}
+			return true
+		l216:
+			position, tokenIndex = position216, tokenIndex216
+			return false
+		},
+		/* 25 MapKey <- <((<Identifier> Action34) / (QuotedString Action35))> */
+		nil,
+		/* 26 NoRefValue <- <(ConcatenationValue / HoleWithSuffixValue / HoleValue / HolesStringValue / (AliasValue Action36) / (DoubleQuote CustomTypedValue DoubleQuote) / (SingleQuote CustomTypedValue SingleQuote) / CustomTypedValue / QuotedStringValue / HeredocValue / UnquotedParamValue)> */
+		nil,
+		/* 27 Value <- <(<&.> Action37 (FuncValue / (RefValue Action38) / NoRefValue))> */
+		func() bool {
+			position226, tokenIndex226 := position, tokenIndex
+			{
+				position227 := position
+				{
+					position228 := position
+					{
+						position229, tokenIndex229 := position, tokenIndex
+						if !matchDot() {
+							goto l226
 						}
-					l109:
-					l110:
+						position, tokenIndex = position229, tokenIndex229
+					}
+					add(rulePegText, position228)
+				}
+				{
+					add(ruleAction37, position)
+				}
+				{
+					position231, tokenIndex231 := position, tokenIndex
+					{
+						position233 := position
 						{
-							position111, tokenIndex111 := position, tokenIndex
-							if buffer[position] != rune(',') {
-								goto l111
+							position234 := position
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l232
 							}
 							position++
-							if !_rules[ruleWhiteSpacing]() {
-								goto l111
-							}
-							if !_rules[ruleValue]() {
-								goto l111
-							}
-							if !_rules[ruleWhiteSpacing]() {
-								goto l111
+						l235:
+							{
+								position236, tokenIndex236 := position, tokenIndex
+								{
+									position237, tokenIndex237 := position, tokenIndex
+									if c := buffer[position]; c < rune('a') || c > rune('z') {
+										goto l238
+									}
+									position++
+									goto l237
+								l238:
+									position, tokenIndex = position237, tokenIndex237
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l236
+									}
+									position++
+								}
+							l237:
+								goto l235
+							l236:
+								position, tokenIndex = position236, tokenIndex236
 							}
-							goto l110
-						l111:
-							position, tokenIndex = position111, tokenIndex111
-						}
-						if buffer[position] != rune(']') {
-							goto l105
-						}
-						position++
-						{
-							add(ruleAction8, position)
+							add(rulePegText, position234)
 						}
-						add(ruleListValue, position106)
-					}
-					goto l104
-				l105:
-					position, tokenIndex = position104, tokenIndex104
-					{
-						position114 := position
 						{
-							add(ruleAction9, position)
+							add(ruleAction39, position)
 						}
-						if !_rules[ruleWhiteSpacing]() {
-							goto l113
-						}
-						if !_rules[ruleValue]() {
-							goto l113
-						}
-						if !_rules[ruleWhiteSpacing]() {
-							goto l113
-						}
-						if buffer[position] != rune(',') {
-							goto l113
+						if buffer[position] != rune('(') {
+							goto l232
 						}
 						position++
 						if !_rules[ruleWhiteSpacing]() {
-							goto l113
-						}
-						if !_rules[ruleValue]() {
-							goto l113
-						}
-						if !_rules[ruleWhiteSpacing]() {
-							goto l113
+							goto l232
 						}
-					l116:
 						{
-							position117, tokenIndex117 := position, tokenIndex
-							if buffer[position] != rune(',') {
-								goto l117
+							position240, tokenIndex240 := position, tokenIndex
+							if !_rules[ruleFuncArg]() {
+								goto l240
 							}
-							position++
 							if !_rules[ruleWhiteSpacing]() {
-								goto l117
+								goto l240
 							}
-							if !_rules[ruleValue]() {
-								goto l117
-							}
-							if !_rules[ruleWhiteSpacing]() {
-								goto l117
+						l242:
+							{
+								position243, tokenIndex243 := position, tokenIndex
+								if buffer[position] != rune(',') {
+									goto l243
+								}
+								position++
+								if !_rules[ruleWhiteSpacing]() {
+									goto l243
+								}
+								if !_rules[ruleFuncArg]() {
+									goto l243
+								}
+								if !_rules[ruleWhiteSpacing]() {
+									goto l243
+								}
+								goto l242
+							l243:
+								position, tokenIndex = position243, tokenIndex243
 							}
-							goto l116
-						l117:
-							position, tokenIndex = position117, tokenIndex117
+							goto l241
+						l240:
+							position, tokenIndex = position240, tokenIndex240
+						}
+					l241:
+						if buffer[position] != rune(')') {
+							goto l232
 						}
+						position++
 						{
-							add(ruleAction10, position)
+							add(ruleAction40, position)
 						}
-						add(ruleListWithoutSquareBrackets, position114)
-					}
-					goto l104
-				l113:
-					position, tokenIndex = position104, tokenIndex104
-					if !_rules[ruleValue]() {
-						goto l102
+						add(ruleFuncValue, position233)
 					}
-				}
-			l104:
-				add(ruleCompositeValue, position103)
-			}
-			return true
-		l102:
-			position, tokenIndex = position102, tokenIndex102
-			return false
-		},
-		/* 11 ListValue <- <(Action7 '[' (WhiteSpacing Value WhiteSpacing)? (',' WhiteSpacing Value WhiteSpacing)* ']' Action8)> */
-		nil,
-		/* 12 ListWithoutSquareBrackets <- <(Action9 (WhiteSpacing Value WhiteSpacing) (',' WhiteSpacing Value WhiteSpacing)+ Action10)> */
-		nil,
-		/* 13 NoRefValue <- <(ConcatenationValue / HoleWithSuffixValue / HoleValue / HolesStringValue / (AliasValue Action11) / (DoubleQuote CustomTypedValue DoubleQuote) / (SingleQuote CustomTypedValue SingleQuote) / CustomTypedValue / QuotedStringValue / UnquotedParamValue)> */
-		nil,
-		/* 14 Value <- <((RefValue Action12) / NoRefValue)> */
-		func() bool {
-			position122, tokenIndex122 := position, tokenIndex
-			{
-				position123 := position
-				{
-					position124, tokenIndex124 := position, tokenIndex
+					goto l231
+				l232:
+					position, tokenIndex = position231, tokenIndex231
 					{
-						position126 := position
+						position246 := position
 						if buffer[position] != rune('$') {
-							goto l125
+							goto l245
 						}
 						position++
 						{
-							position127 := position
+							position247 := position
 							if !_rules[ruleIdentifier]() {
-								goto l125
+								goto l245
 							}
-							add(rulePegText, position127)
+							add(rulePegText, position247)
 						}
-						add(ruleRefValue, position126)
+						add(ruleRefValue, position246)
 					}
 					{
-						add(ruleAction12, position)
+						add(ruleAction38, position)
 					}
-					goto l124
-				l125:
-					position, tokenIndex = position124, tokenIndex124
+					goto l231
+				l245:
+					position, tokenIndex = position231, tokenIndex231
 					{
-						position129 := position
+						position249 := position
 						{
-							position130, tokenIndex130 := position, tokenIndex
+							position250, tokenIndex250 := position, tokenIndex
 							{
-								position132 := position
+								position252 := position
 								{
-									position133, tokenIndex133 := position, tokenIndex
+									position253, tokenIndex253 := position, tokenIndex
 									{
-										add(ruleAction17, position)
+										add(ruleAction46, position)
 									}
 									if !_rules[ruleHoleValue]() {
-										goto l134
+										goto l254
 									}
 									if !_rules[ruleWhiteSpacing]() {
-										goto l134
+										goto l254
 									}
 									if buffer[position] != rune('+') {
-										goto l134
+										goto l254
 									}
 									position++
 									if !_rules[ruleWhiteSpacing]() {
-										goto l134
+										goto l254
 									}
 									{
-										position138, tokenIndex138 := position, tokenIndex
+										position258, tokenIndex258 := position, tokenIndex
 										if !_rules[ruleQuotedStringValue]() {
-											goto l139
+											goto l259
 										}
-										goto l138
-									l139:
-										position, tokenIndex = position138, tokenIndex138
+										goto l258
+									l259:
+										position, tokenIndex = position258, tokenIndex258
 										if !_rules[ruleHoleValue]() {
-											goto l134
+											goto l254
 										}
 									}
-								l138:
-								l136:
+								l258:
+								l256:
 									{
-										position137, tokenIndex137 := position, tokenIndex
+										position257, tokenIndex257 := position, tokenIndex
 										if !_rules[ruleWhiteSpacing]() {
-											goto l137
+											goto l257
 										}
 										if buffer[position] != rune('+') {
-											goto l137
+											goto l257
 										}
 										position++
 										if !_rules[ruleWhiteSpacing]() {
-											goto l137
+											goto l257
 										}
 										{
-											position140, tokenIndex140 := position, tokenIndex
+											position260, tokenIndex260 := position, tokenIndex
 											if !_rules[ruleQuotedStringValue]() {
-												goto l141
+												goto l261
 											}
-											goto l140
-										l141:
-											position, tokenIndex = position140, tokenIndex140
+											goto l260
+										l261:
+											position, tokenIndex = position260, tokenIndex260
 											if !_rules[ruleHoleValue]() {
-												goto l137
+												goto l257
 											}
 										}
-									l140:
-										goto l136
-									l137:
-										position, tokenIndex = position137, tokenIndex137
+									l260:
+										goto l256
+									l257:
+										position, tokenIndex = position257, tokenIndex257
 									}
 									{
-										add(ruleAction18, position)
+										add(ruleAction47, position)
 									}
-									goto l133
-								l134:
-									position, tokenIndex = position133, tokenIndex133
+									goto l253
+								l254:
+									position, tokenIndex = position253, tokenIndex253
 									{
-										add(ruleAction19, position)
+										add(ruleAction48, position)
 									}
 									if !_rules[ruleQuotedStringValue]() {
-										goto l131
+										goto l251
 									}
 									if !_rules[ruleWhiteSpacing]() {
-										goto l131
+										goto l251
 									}
 									if buffer[position] != rune('+') {
-										goto l131
+										goto l251
 									}
 									position++
 									if !_rules[ruleWhiteSpacing]() {
-										goto l131
+										goto l251
 									}
 									{
-										position146, tokenIndex146 := position, tokenIndex
+										position266, tokenIndex266 := position, tokenIndex
 										if !_rules[ruleQuotedStringValue]() {
-											goto l147
+											goto l267
 										}
-										goto l146
-									l147:
-										position, tokenIndex = position146, tokenIndex146
+										goto l266
+									l267:
+										position, tokenIndex = position266, tokenIndex266
 										if !_rules[ruleHoleValue]() {
-											goto l131
+											goto l251
 										}
 									}
-								l146:
-								l144:
+								l266:
+								l264:
 									{
-										position145, tokenIndex145 := position, tokenIndex
+										position265, tokenIndex265 := position, tokenIndex
 										if !_rules[ruleWhiteSpacing]() {
-											goto l145
+											goto l265
 										}
 										if buffer[position] != rune('+') {
-											goto l145
+											goto l265
 										}
 										position++
 										if !_rules[ruleWhiteSpacing]() {
-											goto l145
+											goto l265
 										}
 										{
-											position148, tokenIndex148 := position, tokenIndex
+											position268, tokenIndex268 := position, tokenIndex
 											if !_rules[ruleQuotedStringValue]() {
-												goto l149
+												goto l269
 											}
-											goto l148
-										l149:
-											position, tokenIndex = position148, tokenIndex148
+											goto l268
+										l269:
+											position, tokenIndex = position268, tokenIndex268
 											if !_rules[ruleHoleValue]() {
-												goto l145
+												goto l265
 											}
 										}
-									l148:
-										goto l144
-									l145:
-										position, tokenIndex = position145, tokenIndex145
+									l268:
+										goto l264
+									l265:
+										position, tokenIndex = position265, tokenIndex265
 									}
 									{
-										add(ruleAction20, position)
+										add(ruleAction49, position)
 									}
 								}
-							l133:
-								add(ruleConcatenationValue, position132)
+							l253:
+								add(ruleConcatenationValue, position252)
 							}
-							goto l130
-						l131:
-							position, tokenIndex = position130, tokenIndex130
+							goto l250
+						l251:
+							position, tokenIndex = position250, tokenIndex250
 							{
-								position152 := position
+								position272 := position
 								{
-									add(ruleAction25, position)
+									add(ruleAction56, position)
 								}
 								{
-									position154 := position
+									position274 := position
 									if !_rules[ruleHoleValue]() {
-										goto l151
+										goto l271
 									}
 									if !_rules[ruleUnquotedParamValue]() {
-										goto l151
+										goto l271
 									}
-								l155:
+								l275:
 									{
-										position156, tokenIndex156 := position, tokenIndex
+										position276, tokenIndex276 := position, tokenIndex
 										if !_rules[ruleUnquotedParamValue]() {
-											goto l156
+											goto l276
 										}
-										goto l155
-									l156:
-										position, tokenIndex = position156, tokenIndex156
+										goto l275
+									l276:
+										position, tokenIndex = position276, tokenIndex276
 									}
-								l157:
+								l277:
 									{
-										position158, tokenIndex158 := position, tokenIndex
+										position278, tokenIndex278 := position, tokenIndex
 										{
-											position159, tokenIndex159 := position, tokenIndex
+											position279, tokenIndex279 := position, tokenIndex
 											if !_rules[ruleUnquotedParamValue]() {
-												goto l159
+												goto l279
 											}
-											goto l160
-										l159:
-											position, tokenIndex = position159, tokenIndex159
+											goto l280
+										l279:
+											position, tokenIndex = position279, tokenIndex279
 										}
-									l160:
+									l280:
 										if !_rules[ruleHoleValue]() {
-											goto l158
+											goto l278
 										}
 										{
-											position161, tokenIndex161 := position, tokenIndex
+											position281, tokenIndex281 := position, tokenIndex
 											if !_rules[ruleUnquotedParamValue]() {
-												goto l161
+												goto l281
 											}
-											goto l162
-										l161:
-											position, tokenIndex = position161, tokenIndex161
+											goto l282
+										l281:
+											position, tokenIndex = position281, tokenIndex281
 										}
-									l162:
-										goto l157
-									l158:
-										position, tokenIndex = position158, tokenIndex158
+									l282:
+										goto l277
+									l278:
+										position, tokenIndex = position278, tokenIndex278
 									}
-									add(rulePegText, position154)
+									add(rulePegText, position274)
 								}
 								{
-									add(ruleAction26, position)
+									add(ruleAction57, position)
 								}
-								add(ruleHoleWithSuffixValue, position152)
+								add(ruleHoleWithSuffixValue, position272)
 							}
-							goto l130
-						l151:
-							position, tokenIndex = position130, tokenIndex130
+							goto l250
+						l271:
+							position, tokenIndex = position250, tokenIndex250
 							if !_rules[ruleHoleValue]() {
-								goto l164
+								goto l284
 							}
-							goto l130
-						l164:
-							position, tokenIndex = position130, tokenIndex130
+							goto l250
+						l284:
+							position, tokenIndex = position250, tokenIndex250
 							{
-								position166 := position
+								position286 := position
 								{
-									add(ruleAction23, position)
+									add(ruleAction54, position)
 								}
 								{
-									position168 := position
+									position288 := position
 									{
-										position171, tokenIndex171 := position, tokenIndex
+										position291, tokenIndex291 := position, tokenIndex
 										if !_rules[ruleUnquotedParamValue]() {
-											goto l171
+											goto l291
 										}
-										goto l172
-									l171:
-										position, tokenIndex = position171, tokenIndex171
+										goto l292
+									l291:
+										position, tokenIndex = position291, tokenIndex291
 									}
-								l172:
+								l292:
 									if !_rules[ruleHoleValue]() {
-										goto l165
+										goto l285
 									}
 									{
-										position173, tokenIndex173 := position, tokenIndex
+										position293, tokenIndex293 := position, tokenIndex
 										if !_rules[ruleUnquotedParamValue]() {
-											goto l173
+											goto l293
 										}
-										goto l174
-									l173:
-										position, tokenIndex = position173, tokenIndex173
+										goto l294
+									l293:
+										position, tokenIndex = position293, tokenIndex293
 									}
-								l174:
-								l169:
+								l294:
+								l289:
 									{
-										position170, tokenIndex170 := position, tokenIndex
+										position290, tokenIndex290 := position, tokenIndex
 										{
-											position175, tokenIndex175 := position, tokenIndex
+											position295, tokenIndex295 := position, tokenIndex
 											if !_rules[ruleUnquotedParamValue]() {
-												goto l175
+												goto l295
 											}
-											goto l176
-										l175:
-											position, tokenIndex = position175, tokenIndex175
+											goto l296
+										l295:
+											position, tokenIndex = position295, tokenIndex295
 										}
-									l176:
+									l296:
 										if !_rules[ruleHoleValue]() {
-											goto l170
+											goto l290
 										}
 										{
-											position177, tokenIndex177 := position, tokenIndex
+											position297, tokenIndex297 := position, tokenIndex
 											if !_rules[ruleUnquotedParamValue]() {
-												goto l177
+												goto l297
 											}
-											goto l178
-										l177:
-											position, tokenIndex = position177, tokenIndex177
+											goto l298
+										l297:
+											position, tokenIndex = position297, tokenIndex297
 										}
-									l178:
-										goto l169
-									l170:
-										position, tokenIndex = position170, tokenIndex170
+									l298:
+										goto l289
+									l290:
+										position, tokenIndex = position290, tokenIndex290
 									}
-									add(rulePegText, position168)
+									add(rulePegText, position288)
 								}
 								{
-									add(ruleAction24, position)
+									add(ruleAction55, position)
 								}
-								add(ruleHolesStringValue, position166)
+								add(ruleHolesStringValue, position286)
 							}
-							goto l130
-						l165:
-							position, tokenIndex = position130, tokenIndex130
+							goto l250
+						l285:
+							position, tokenIndex = position250, tokenIndex250
 							{
-								position181 := position
+								position301 := position
 								{
-									position182, tokenIndex182 := position, tokenIndex
+									position302, tokenIndex302 := position, tokenIndex
 									if buffer[position] != rune('@') {
-										goto l183
+										goto l303
 									}
 									position++
 									{
-										position184 := position
+										position304 := position
 										if !_rules[ruleUnquotedParam]() {
-											goto l183
+											goto l303
 										}
-										add(rulePegText, position184)
+										add(rulePegText, position304)
 									}
-									goto l182
-								l183:
-									position, tokenIndex = position182, tokenIndex182
+									goto l302
+								l303:
+									position, tokenIndex = position302, tokenIndex302
 									if buffer[position] != rune('@') {
-										goto l185
+										goto l305
 									}
 									position++
 									if !_rules[ruleDoubleQuotedValue]() {
-										goto l185
+										goto l305
 									}
-									goto l182
-								l185:
-									position, tokenIndex = position182, tokenIndex182
+									goto l302
+								l305:
+									position, tokenIndex = position302, tokenIndex302
 									if buffer[position] != rune('@') {
-										goto l180
+										goto l300
 									}
 									position++
 									if !_rules[ruleSingleQuotedValue]() {
-										goto l180
+										goto l300
 									}
 								}
-							l182:
-								add(ruleAliasValue, position181)
+							l302:
+								add(ruleAliasValue, position301)
 							}
 							{
-								add(ruleAction11, position)
+								add(ruleAction36, position)
 							}
-							goto l130
-						l180:
-							position, tokenIndex = position130, tokenIndex130
+							goto l250
+						l300:
+							position, tokenIndex = position250, tokenIndex250
 							if !_rules[ruleDoubleQuote]() {
-								goto l187
+								goto l307
 							}
 							if !_rules[ruleCustomTypedValue]() {
-								goto l187
+								goto l307
 							}
 							if !_rules[ruleDoubleQuote]() {
-								goto l187
+								goto l307
 							}
-							goto l130
-						l187:
-							position, tokenIndex = position130, tokenIndex130
+							goto l250
+						l307:
+							position, tokenIndex = position250, tokenIndex250
 							if !_rules[ruleSingleQuote]() {
-								goto l188
+								goto l308
 							}
 							if !_rules[ruleCustomTypedValue]() {
-								goto l188
+								goto l308
 							}
 							if !_rules[ruleSingleQuote]() {
-								goto l188
+								goto l308
 							}
-							goto l130
-						l188:
-							position, tokenIndex = position130, tokenIndex130
+							goto l250
+						l308:
+							position, tokenIndex = position250, tokenIndex250
 							if !_rules[ruleCustomTypedValue]() {
-								goto l189
+								goto l309
 							}
-							goto l130
-						l189:
-							position, tokenIndex = position130, tokenIndex130
+							goto l250
+						l309:
+							position, tokenIndex = position250, tokenIndex250
 							if !_rules[ruleQuotedStringValue]() {
-								goto l190
+								goto l310
 							}
-							goto l130
-						l190:
-							position, tokenIndex = position130, tokenIndex130
+							goto l250
+						l310:
+							position, tokenIndex = position250, tokenIndex250
+							{
+								position312 := position
+								if buffer[position] != rune('<') {
+									goto l311
+								}
+								position++
+								if buffer[position] != rune('<') {
+									goto l311
+								}
+								position++
+								if !(p.beginHeredoc(buffer, position)) {
+									goto l311
+								}
+								if !_rules[ruleIdentifier]() {
+									goto l311
+								}
+								if !_rules[ruleWhiteSpacing]() {
+									goto l311
+								}
+								{
+									position313, tokenIndex313 := position, tokenIndex
+									if !_rules[ruleHeredocEnd]() {
+										goto l314
+									}
+									{
+										add(ruleAction51, position)
+									}
+									goto l313
+								l314:
+									position, tokenIndex = position313, tokenIndex313
+									if !_rules[ruleEndOfLine]() {
+										goto l311
+									}
+									{
+										position316 := position
+									l317:
+										{
+											position318, tokenIndex318 := position, tokenIndex
+											{
+												position319, tokenIndex319 := position, tokenIndex
+												if !_rules[ruleHeredocEnd]() {
+													goto l319
+												}
+												goto l318
+											l319:
+												position, tokenIndex = position319, tokenIndex319
+											}
+											if !matchDot() {
+												goto l318
+											}
+											goto l317
+										l318:
+											position, tokenIndex = position318, tokenIndex318
+										}
+										add(rulePegText, position316)
+									}
+									if !_rules[ruleHeredocEnd]() {
+										goto l311
+									}
+									{
+										add(ruleAction52, position)
+									}
+								}
+							l313:
+								add(ruleHeredocValue, position312)
+							}
+							goto l250
+						l311:
+							position, tokenIndex = position250, tokenIndex250
 							if !_rules[ruleUnquotedParamValue]() {
-								goto l122
+								goto l226
+							}
+						}
+					l250:
+						add(ruleNoRefValue, position249)
+					}
+				}
+			l231:
+				add(ruleValue, position227)
+			}
+			return true
+		l226:
+			position, tokenIndex = position226, tokenIndex226
+			return false
+		},
+		/* 28 FuncValue <- <(<([a-z] ([a-z] / [0-9])*)> Action39 '(' WhiteSpacing (FuncArg WhiteSpacing (',' WhiteSpacing FuncArg WhiteSpacing)*)? ')' Action40)> */
+		nil,
+		/* 29 FuncArg <- <((<&.> Action41 ListValue) / Value)> */
+		func() bool {
+			position322, tokenIndex322 := position, tokenIndex
+			{
+				position323 := position
+				{
+					position324, tokenIndex324 := position, tokenIndex
+					{
+						position326 := position
+						{
+							position327, tokenIndex327 := position, tokenIndex
+							if !matchDot() {
+								goto l325
 							}
+							position, tokenIndex = position327, tokenIndex327
 						}
-					l130:
-						add(ruleNoRefValue, position129)
+						add(rulePegText, position326)
+					}
+					{
+						add(ruleAction41, position)
+					}
+					if !_rules[ruleListValue]() {
+						goto l325
+					}
+					goto l324
+				l325:
+					position, tokenIndex = position324, tokenIndex324
+					if !_rules[ruleValue]() {
+						goto l322
 					}
 				}
-			l124:
-				add(ruleValue, position123)
+			l324:
+				add(ruleFuncArg, position323)
 			}
 			return true
-		l122:
-			position, tokenIndex = position122, tokenIndex122
+		l322:
+			position, tokenIndex = position322, tokenIndex322
 			return false
 		},
-		/* 15 CustomTypedValue <- <((<CidrValue> Action13) / (<IpValue> Action14) / (<IntRangeValue> Action15))> */
+		/* 30 CustomTypedValue <- <((<CidrValue> Action42) / (<IpValue> Action43) / (<IntRangeValue> Action44))> */
 		func() bool {
-			position191, tokenIndex191 := position, tokenIndex
+			position329, tokenIndex329 := position, tokenIndex
 			{
-				position192 := position
+				position330 := position
 				{
-					position193, tokenIndex193 := position, tokenIndex
+					position331, tokenIndex331 := position, tokenIndex
 					{
-						position195 := position
+						position333 := position
 						{
-							position196 := position
+							position334 := position
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l194
+								goto l332
 							}
 							position++
-						l197:
+						l335:
 							{
-								position198, tokenIndex198 := position, tokenIndex
+								position336, tokenIndex336 := position, tokenIndex
 								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l198
+									goto l336
 								}
 								position++
-								goto l197
-							l198:
-								position, tokenIndex = position198, tokenIndex198
+								goto l335
+							l336:
+								position, tokenIndex = position336, tokenIndex336
 							}
 							if buffer[position] != rune('.') {
-								goto l194
+								goto l332
 							}
 							position++
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l194
+								goto l332
 							}
 							position++
-						l199:
+						l337:
 							{
-								position200, tokenIndex200 := position, tokenIndex
+								position338, tokenIndex338 := position, tokenIndex
 								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l200
+									goto l338
 								}
 								position++
-								goto l199
-							l200:
-								position, tokenIndex = position200, tokenIndex200
+								goto l337
+							l338:
+								position, tokenIndex = position338, tokenIndex338
 							}
 							if buffer[position] != rune('.') {
-								goto l194
+								goto l332
 							}
 							position++
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l194
+								goto l332
 							}
 							position++
-						l201:
+						l339:
 							{
-								position202, tokenIndex202 := position, tokenIndex
+								position340, tokenIndex340 := position, tokenIndex
 								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l202
+									goto l340
 								}
 								position++
-								goto l201
-							l202:
-								position, tokenIndex = position202, tokenIndex202
+								goto l339
+							l340:
+								position, tokenIndex = position340, tokenIndex340
 							}
 							if buffer[position] != rune('.') {
-								goto l194
+								goto l332
 							}
 							position++
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l194
+								goto l332
 							}
 							position++
-						l203:
+						l341:
 							{
-								position204, tokenIndex204 := position, tokenIndex
+								position342, tokenIndex342 := position, tokenIndex
 								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l204
+									goto l342
 								}
 								position++
-								goto l203
-							l204:
-								position, tokenIndex = position204, tokenIndex204
+								goto l341
+							l342:
+								position, tokenIndex = position342, tokenIndex342
 							}
 							if buffer[position] != rune('/') {
-								goto l194
+								goto l332
 							}
 							position++
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l194
+								goto l332
 							}
 							position++
-						l205:
+						l343:
 							{
-								position206, tokenIndex206 := position, tokenIndex
+								position344, tokenIndex344 := position, tokenIndex
 								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l206
+									goto l344
 								}
 								position++
-								goto l205
-							l206:
-								position, tokenIndex = position206, tokenIndex206
+								goto l343
+							l344:
+								position, tokenIndex = position344, tokenIndex344
 							}
-							add(ruleCidrValue, position196)
+							add(ruleCidrValue, position334)
 						}
-						add(rulePegText, position195)
+						add(rulePegText, position333)
 					}
 					{
-						add(ruleAction13, position)
+						add(ruleAction42, position)
 					}
-					goto l193
-				l194:
-					position, tokenIndex = position193, tokenIndex193
+					goto l331
+				l332:
+					position, tokenIndex = position331, tokenIndex331
 					{
-						position209 := position
+						position347 := position
 						{
-							position210 := position
+							position348 := position
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l208
+								goto l346
 							}
 							position++
-						l211:
+						l349:
 							{
-								position212, tokenIndex212 := position, tokenIndex
+								position350, tokenIndex350 := position, tokenIndex
 								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l212
+									goto l350
 								}
 								position++
-								goto l211
-							l212:
-								position, tokenIndex = position212, tokenIndex212
+								goto l349
+							l350:
+								position, tokenIndex = position350, tokenIndex350
 							}
 							if buffer[position] != rune('.') {
-								goto l208
+								goto l346
 							}
 							position++
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l208
+								goto l346
 							}
 							position++
-						l213:
+						l351:
 							{
-								position214, tokenIndex214 := position, tokenIndex
+								position352, tokenIndex352 := position, tokenIndex
 								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l214
+									goto l352
 								}
 								position++
-								goto l213
-							l214:
-								position, tokenIndex = position214, tokenIndex214
+								goto l351
+							l352:
+								position, tokenIndex = position352, tokenIndex352
 							}
 							if buffer[position] != rune('.') {
-								goto l208
+								goto l346
 							}
 							position++
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l208
+								goto l346
 							}
 							position++
-						l215:
+						l353:
 							{
-								position216, tokenIndex216 := position, tokenIndex
+								position354, tokenIndex354 := position, tokenIndex
 								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l216
+									goto l354
 								}
 								position++
-								goto l215
-							l216:
-								position, tokenIndex = position216, tokenIndex216
+								goto l353
+							l354:
+								position, tokenIndex = position354, tokenIndex354
 							}
 							if buffer[position] != rune('.') {
-								goto l208
+								goto l346
 							}
 							position++
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l208
+								goto l346
 							}
 							position++
-						l217:
+						l355:
 							{
-								position218, tokenIndex218 := position, tokenIndex
+								position356, tokenIndex356 := position, tokenIndex
 								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l218
+									goto l356
 								}
 								position++
-								goto l217
-							l218:
-								position, tokenIndex = position218, tokenIndex218
+								goto l355
+							l356:
+								position, tokenIndex = position356, tokenIndex356
 							}
-							add(ruleIpValue, position210)
+							add(ruleIpValue, position348)
 						}
-						add(rulePegText, position209)
+						add(rulePegText, position347)
 					}
 					{
-						add(ruleAction14, position)
+						add(ruleAction43, position)
 					}
-					goto l193
-				l208:
-					position, tokenIndex = position193, tokenIndex193
+					goto l331
+				l346:
+					position, tokenIndex = position331, tokenIndex331
 					{
-						position220 := position
+						position358 := position
 						{
-							position221 := position
+							position359 := position
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l191
+								goto l329
 							}
 							position++
-						l222:
+						l360:
 							{
-								position223, tokenIndex223 := position, tokenIndex
+								position361, tokenIndex361 := position, tokenIndex
 								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l223
+									goto l361
 								}
 								position++
-								goto l222
-							l223:
-								position, tokenIndex = position223, tokenIndex223
+								goto l360
+							l361:
+								position, tokenIndex = position361, tokenIndex361
 							}
 							if buffer[position] != rune('-') {
-								goto l191
+								goto l329
 							}
 							position++
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l191
+								goto l329
 							}
 							position++
-						l224:
+						l362:
 							{
-								position225, tokenIndex225 := position, tokenIndex
+								position363, tokenIndex363 := position, tokenIndex
 								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l225
+									goto l363
 								}
 								position++
-								goto l224
-							l225:
-								position, tokenIndex = position225, tokenIndex225
+								goto l362
+							l363:
+								position, tokenIndex = position363, tokenIndex363
 							}
-							add(ruleIntRangeValue, position221)
+							add(ruleIntRangeValue, position359)
 						}
-						add(rulePegText, position220)
+						add(rulePegText, position358)
 					}
 					{
-						add(ruleAction15, position)
+						add(ruleAction44, position)
 					}
 				}
-			l193:
-				add(ruleCustomTypedValue, position192)
+			l331:
+				add(ruleCustomTypedValue, position330)
 			}
 			return true
-		l191:
-			position, tokenIndex = position191, tokenIndex191
+		l329:
+			position, tokenIndex = position329, tokenIndex329
 			return false
 		},
-		/* 16 UnquotedParamValue <- <(<UnquotedParam> Action16)> */
+		/* 31 UnquotedParamValue <- <(<UnquotedParam> Action45)> */
 		func() bool {
-			position227, tokenIndex227 := position, tokenIndex
+			position365, tokenIndex365 := position, tokenIndex
 			{
-				position228 := position
+				position366 := position
 				{
-					position229 := position
+					position367 := position
 					if !_rules[ruleUnquotedParam]() {
-						goto l227
+						goto l365
 					}
-					add(rulePegText, position229)
+					add(rulePegText, position367)
 				}
 				{
-					add(ruleAction16, position)
+					add(ruleAction45, position)
 				}
-				add(ruleUnquotedParamValue, position228)
+				add(ruleUnquotedParamValue, position366)
 			}
 			return true
-		l227:
-			position, tokenIndex = position227, tokenIndex227
+		l365:
+			position, tokenIndex = position365, tokenIndex365
 			return false
 		},
-		/* 17 UnquotedParam <- <((&('*') '*') | (&('>') '>') | (&('<') '<') | (&('@') '@') | (&('~') '~') | (&(';') ';') | (&('+') '+') | (&('/') '/') | (&(':') ':') | (&('_') '_') | (&('.') '.') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> */
+		/* 32 UnquotedParam <- <((&('*') '*') | (&('>') '>') | (&('<') '<') | (&('@') '@') | (&('~') '~') | (&(';') ';') | (&('+') '+') | (&('/') '/') | (&(':') ':') | (&('_') '_') | (&('.') '.') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> */
 		func() bool {
-			position231, tokenIndex231 := position, tokenIndex
+			position369, tokenIndex369 := position, tokenIndex
 			{
-				position232 := position
+				position370 := position
 				{
 					switch buffer[position] {
 					case '*':
 						if buffer[position] != rune('*') {
-							goto l231
+							goto l369
 						}
 						position++
-						break
 					case '>':
 						if buffer[position] != rune('>') {
-							goto l231
+							goto l369
 						}
 						position++
-						break
 					case '<':
 						if buffer[position] != rune('<') {
-							goto l231
+							goto l369
 						}
 						position++
-						break
 					case '@':
 						if buffer[position] != rune('@') {
-							goto l231
+							goto l369
 						}
 						position++
-						break
 					case '~':
 						if buffer[position] != rune('~') {
-							goto l231
+							goto l369
 						}
 						position++
-						break
 					case ';':
 						if buffer[position] != rune(';') {
-							goto l231
+							goto l369
 						}
 						position++
-						break
 					case '+':
 						if buffer[position] != rune('+') {
-							goto l231
+							goto l369
 						}
 						position++
-						break
 					case '/':
 						if buffer[position] != rune('/') {
-							goto l231
+							goto l369
 						}
 						position++
-						break
 					case ':':
 						if buffer[position] != rune(':') {
-							goto l231
+							goto l369
 						}
 						position++
-						break
 					case '_':
 						if buffer[position] != rune('_') {
-							goto l231
+							goto l369
 						}
 						position++
-						break
 					case '.':
 						if buffer[position] != rune('.') {
-							goto l231
+							goto l369
 						}
 						position++
-						break
 					case '-':
 						if buffer[position] != rune('-') {
-							goto l231
+							goto l369
 						}
 						position++
-						break
 					case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l231
+							goto l369
 						}
 						position++
-						break
 					case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l231
+							goto l369
 						}
 						position++
-						break
 					default:
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l231
+							goto l369
 						}
 						position++
-						break
 					}
 				}
 
-			l233:
+			l371:
 				{
-					position234, tokenIndex234 := position, tokenIndex
+					position372, tokenIndex372 := position, tokenIndex
 					{
 						switch buffer[position] {
 						case '*':
 							if buffer[position] != rune('*') {
-								goto l234
+								goto l372
 							}
 							position++
-							break
 						case '>':
 							if buffer[position] != rune('>') {
-								goto l234
+								goto l372
 							}
 							position++
-							break
 						case '<':
 							if buffer[position] != rune('<') {
-								goto l234
+								goto l372
 							}
 							position++
-							break
 						case '@':
 							if buffer[position] != rune('@') {
-								goto l234
+								goto l372
 							}
 							position++
-							break
 						case '~':
 							if buffer[position] != rune('~') {
-								goto l234
+								goto l372
 							}
 							position++
-							break
 						case ';':
 							if buffer[position] != rune(';') {
-								goto l234
+								goto l372
 							}
 							position++
-							break
 						case '+':
 							if buffer[position] != rune('+') {
-								goto l234
+								goto l372
 							}
 							position++
-							break
 						case '/':
 							if buffer[position] != rune('/') {
-								goto l234
+								goto l372
 							}
 							position++
-							break
 						case ':':
 							if buffer[position] != rune(':') {
-								goto l234
+								goto l372
 							}
 							position++
-							break
 						case '_':
 							if buffer[position] != rune('_') {
-								goto l234
+								goto l372
 							}
 							position++
-							break
 						case '.':
 							if buffer[position] != rune('.') {
-								goto l234
+								goto l372
 							}
 							position++
-							break
 						case '-':
 							if buffer[position] != rune('-') {
-								goto l234
+								goto l372
 							}
 							position++
-							break
 						case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l234
+								goto l372
 							}
 							position++
-							break
 						case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l234
+								goto l372
 							}
 							position++
-							break
 						default:
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l234
+								goto l372
 							}
 							position++
-							break
 						}
 					}
 
-					goto l233
-				l234:
-					position, tokenIndex = position234, tokenIndex234
+					goto l371
+				l372:
+					position, tokenIndex = position372, tokenIndex372
 				}
-				add(ruleUnquotedParam, position232)
+				add(ruleUnquotedParam, position370)
 			}
 			return true
-		l231:
-			position, tokenIndex = position231, tokenIndex231
+		l369:
+			position, tokenIndex = position369, tokenIndex369
 			return false
 		},
-		/* 18 ConcatenationValue <- <((Action17 HoleValue (WhiteSpacing '+' WhiteSpacing (QuotedStringValue / HoleValue))+ Action18) / (Action19 QuotedStringValue (WhiteSpacing '+' WhiteSpacing (QuotedStringValue / HoleValue))+ Action20))> */
+		/* 33 ConcatenationValue <- <((Action46 HoleValue (WhiteSpacing '+' WhiteSpacing (QuotedStringValue / HoleValue))+ Action47) / (Action48 QuotedStringValue (WhiteSpacing '+' WhiteSpacing (QuotedStringValue / HoleValue))+ Action49))> */
 		nil,
-		/* 19 QuotedStringValue <- <(QuotedString Action21)> */
+		/* 34 QuotedStringValue <- <(QuotedString Action50)> */
 		func() bool {
-			position238, tokenIndex238 := position, tokenIndex
+			position376, tokenIndex376 := position, tokenIndex
 			{
-				position239 := position
-				{
-					position240 := position
-					{
-						position241, tokenIndex241 := position, tokenIndex
-						if !_rules[ruleDoubleQuotedValue]() {
-							goto l242
-						}
-						goto l241
-					l242:
-						position, tokenIndex = position241, tokenIndex241
-						if !_rules[ruleSingleQuotedValue]() {
-							goto l238
-						}
-					}
-				l241:
-					add(ruleQuotedString, position240)
+				position377 := position
+				if !_rules[ruleQuotedString]() {
+					goto l376
 				}
 				{
-					add(ruleAction21, position)
+					add(ruleAction50, position)
 				}
-				add(ruleQuotedStringValue, position239)
+				add(ruleQuotedStringValue, position377)
 			}
 			return true
-		l238:
-			position, tokenIndex = position238, tokenIndex238
+		l376:
+			position, tokenIndex = position376, tokenIndex376
 			return false
 		},
-		/* 20 QuotedString <- <(DoubleQuotedValue / SingleQuotedValue)> */
+		/* 35 HeredocValue <- <('<' '<' &{ p.beginHeredoc(buffer, position) } Identifier WhiteSpacing ((HeredocEnd Action51) / (EndOfLine <(!HeredocEnd .)*> HeredocEnd Action52)))> */
 		nil,
-		/* 21 DoubleQuotedValue <- <(DoubleQuote <(!'"' .)*> DoubleQuote)> */
+		/* 36 HeredocEnd <- <(EndOfLine WhiteSpacing &{ p.isHeredocEnd(buffer, position) } Identifier)> */
+		func() bool {
+			position380, tokenIndex380 := position, tokenIndex
+			{
+				position381 := position
+				if !_rules[ruleEndOfLine]() {
+					goto l380
+				}
+				if !_rules[ruleWhiteSpacing]() {
+					goto l380
+				}
+				if !(p.isHeredocEnd(buffer, position)) {
+					goto l380
+				}
+				if !_rules[ruleIdentifier]() {
+					goto l380
+				}
+				add(ruleHeredocEnd, position381)
+			}
+			return true
+		l380:
+			position, tokenIndex = position380, tokenIndex380
+			return false
+		},
+		/* 37 QuotedString <- <(DoubleQuotedValue / SingleQuotedValue)> */
+		func() bool {
+			position382, tokenIndex382 := position, tokenIndex
+			{
+				position383 := position
+				{
+					position384, tokenIndex384 := position, tokenIndex
+					if !_rules[ruleDoubleQuotedValue]() {
+						goto l385
+					}
+					goto l384
+				l385:
+					position, tokenIndex = position384, tokenIndex384
+					if !_rules[ruleSingleQuotedValue]() {
+						goto l382
+					}
+				}
+			l384:
+				add(ruleQuotedString, position383)
+			}
+			return true
+		l382:
+			position, tokenIndex = position382, tokenIndex382
+			return false
+		},
+		/* 38 DoubleQuotedValue <- <(DoubleQuote <(!'"' .)*> DoubleQuote)> */
 		func() bool {
-			position245, tokenIndex245 := position, tokenIndex
+			position386, tokenIndex386 := position, tokenIndex
 			{
-				position246 := position
+				position387 := position
 				if !_rules[ruleDoubleQuote]() {
-					goto l245
+					goto l386
 				}
 				{
-					position247 := position
-				l248:
+					position388 := position
+				l389:
 					{
-						position249, tokenIndex249 := position, tokenIndex
+						position390, tokenIndex390 := position, tokenIndex
 						{
-							position250, tokenIndex250 := position, tokenIndex
+							position391, tokenIndex391 := position, tokenIndex
 							if buffer[position] != rune('"') {
-								goto l250
+								goto l391
 							}
 							position++
-							goto l249
-						l250:
-							position, tokenIndex = position250, tokenIndex250
+							goto l390
+						l391:
+							position, tokenIndex = position391, tokenIndex391
 						}
 						if !matchDot() {
-							goto l249
+							goto l390
 						}
-						goto l248
-					l249:
-						position, tokenIndex = position249, tokenIndex249
+						goto l389
+					l390:
+						position, tokenIndex = position390, tokenIndex390
 					}
-					add(rulePegText, position247)
+					add(rulePegText, position388)
 				}
 				if !_rules[ruleDoubleQuote]() {
-					goto l245
+					goto l386
 				}
-				add(ruleDoubleQuotedValue, position246)
+				add(ruleDoubleQuotedValue, position387)
 			}
 			return true
-		l245:
-			position, tokenIndex = position245, tokenIndex245
+		l386:
+			position, tokenIndex = position386, tokenIndex386
 			return false
 		},
-		/* 22 SingleQuotedValue <- <(SingleQuote <(!'\'' .)*> SingleQuote)> */
+		/* 39 SingleQuotedValue <- <(SingleQuote <(!'\'' .)*> SingleQuote)> */
 		func() bool {
-			position251, tokenIndex251 := position, tokenIndex
+			position392, tokenIndex392 := position, tokenIndex
 			{
-				position252 := position
+				position393 := position
 				if !_rules[ruleSingleQuote]() {
-					goto l251
+					goto l392
 				}
 				{
-					position253 := position
-				l254:
+					position394 := position
+				l395:
 					{
-						position255, tokenIndex255 := position, tokenIndex
+						position396, tokenIndex396 := position, tokenIndex
 						{
-							position256, tokenIndex256 := position, tokenIndex
+							position397, tokenIndex397 := position, tokenIndex
 							if buffer[position] != rune('\'') {
-								goto l256
+								goto l397
 							}
 							position++
-							goto l255
-						l256:
-							position, tokenIndex = position256, tokenIndex256
+							goto l396
+						l397:
+							position, tokenIndex = position397, tokenIndex397
 						}
 						if !matchDot() {
-							goto l255
+							goto l396
 						}
-						goto l254
-					l255:
-						position, tokenIndex = position255, tokenIndex255
+						goto l395
+					l396:
+						position, tokenIndex = position396, tokenIndex396
 					}
-					add(rulePegText, position253)
+					add(rulePegText, position394)
 				}
 				if !_rules[ruleSingleQuote]() {
-					goto l251
+					goto l392
 				}
-				add(ruleSingleQuotedValue, position252)
+				add(ruleSingleQuotedValue, position393)
 			}
 			return true
-		l251:
-			position, tokenIndex = position251, tokenIndex251
+		l392:
+			position, tokenIndex = position392, tokenIndex392
 			return false
 		},
-		/* 23 CidrValue <- <([0-9]+ '.' [0-9]+ '.' [0-9]+ '.' [0-9]+ '/' [0-9]+)> */
+		/* 40 CidrValue <- <([0-9]+ '.' [0-9]+ '.' [0-9]+ '.' [0-9]+ '/' [0-9]+)> */
 		nil,
-		/* 24 IpValue <- <([0-9]+ '.' [0-9]+ '.' [0-9]+ '.' [0-9]+)> */
+		/* 41 IpValue <- <([0-9]+ '.' [0-9]+ '.' [0-9]+ '.' [0-9]+)> */
 		nil,
-		/* 25 IntRangeValue <- <([0-9]+ '-' [0-9]+)> */
+		/* 42 IntRangeValue <- <([0-9]+ '-' [0-9]+)> */
 		nil,
-		/* 26 RefValue <- <('$' <Identifier>)> */
+		/* 43 RefValue <- <('$' <Identifier>)> */
 		nil,
-		/* 27 AliasValue <- <(('@' <UnquotedParam>) / ('@' DoubleQuotedValue) / ('@' SingleQuotedValue))> */
+		/* 44 AliasValue <- <(('@' <UnquotedParam>) / ('@' DoubleQuotedValue) / ('@' SingleQuotedValue))> */
 		nil,
-		/* 28 HoleValue <- <(Hole Action22)> */
+		/* 45 HoleValue <- <(Hole Action53)> */
 		func() bool {
-			position262, tokenIndex262 := position, tokenIndex
+			position403, tokenIndex403 := position, tokenIndex
 			{
-				position263 := position
+				position404 := position
+				if !_rules[ruleHole]() {
+					goto l403
+				}
 				{
-					position264 := position
-					if buffer[position] != rune('{') {
-						goto l262
-					}
-					position++
-					if !_rules[ruleWhiteSpacing]() {
-						goto l262
-					}
+					add(ruleAction53, position)
+				}
+				add(ruleHoleValue, position404)
+			}
+			return true
+		l403:
+			position, tokenIndex = position403, tokenIndex403
+			return false
+		},
+		/* 46 Hole <- <('{' WhiteSpacing <(('s' 'e' 'c' 'r' 'e' 't' ':')? Identifier)> WhiteSpacing '}')> */
+		func() bool {
+			position406, tokenIndex406 := position, tokenIndex
+			{
+				position407 := position
+				if buffer[position] != rune('{') {
+					goto l406
+				}
+				position++
+				if !_rules[ruleWhiteSpacing]() {
+					goto l406
+				}
+				{
+					position408 := position
 					{
-						position265 := position
-						if !_rules[ruleIdentifier]() {
-							goto l262
+						position409, tokenIndex409 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l409
 						}
-						add(rulePegText, position265)
-					}
-					if !_rules[ruleWhiteSpacing]() {
-						goto l262
+						position++
+						if buffer[position] != rune('e') {
+							goto l409
+						}
+						position++
+						if buffer[position] != rune('c') {
+							goto l409
+						}
+						position++
+						if buffer[position] != rune('r') {
+							goto l409
+						}
+						position++
+						if buffer[position] != rune('e') {
+							goto l409
+						}
+						position++
+						if buffer[position] != rune('t') {
+							goto l409
+						}
+						position++
+						if buffer[position] != rune(':') {
+							goto l409
+						}
+						position++
+						goto l410
+					l409:
+						position, tokenIndex = position409, tokenIndex409
 					}
-					if buffer[position] != rune('}') {
-						goto l262
+				l410:
+					if !_rules[ruleIdentifier]() {
+						goto l406
 					}
-					position++
-					add(ruleHole, position264)
+					add(rulePegText, position408)
 				}
-				{
-					add(ruleAction22, position)
+				if !_rules[ruleWhiteSpacing]() {
+					goto l406
+				}
+				if buffer[position] != rune('}') {
+					goto l406
 				}
-				add(ruleHoleValue, position263)
+				position++
+				add(ruleHole, position407)
 			}
 			return true
-		l262:
-			position, tokenIndex = position262, tokenIndex262
+		l406:
+			position, tokenIndex = position406, tokenIndex406
 			return false
 		},
-		/* 29 Hole <- <('{' WhiteSpacing <Identifier> WhiteSpacing '}')> */
+		/* 47 HolesStringValue <- <(Action54 <(UnquotedParamValue? HoleValue UnquotedParamValue?)+> Action55)> */
 		nil,
-		/* 30 HolesStringValue <- <(Action23 <(UnquotedParamValue? HoleValue UnquotedParamValue?)+> Action24)> */
+		/* 48 HoleWithSuffixValue <- <(Action56 <(HoleValue UnquotedParamValue+ (UnquotedParamValue? HoleValue UnquotedParamValue?)*)> Action57)> */
 		nil,
-		/* 31 HoleWithSuffixValue <- <(Action25 <(HoleValue UnquotedParamValue+ (UnquotedParamValue? HoleValue UnquotedParamValue?)*)> Action26)> */
+		/* 49 Comment <- <(<(('#' / ('/' '/')) (!EndOfLine .)*)> Action58)> */
 		nil,
-		/* 32 Comment <- <(('#' (!EndOfLine .)*) / ('/' '/' (!EndOfLine .)*))> */
+		/* 50 InlineComment <- <(<(('#' / ('/' '/')) (!EndOfLine .)*)> Action59)> */
 		nil,
-		/* 33 SingleQuote <- <'\''> */
+		/* 51 SingleQuote <- <'\''> */
 		func() bool {
-			position271, tokenIndex271 := position, tokenIndex
+			position415, tokenIndex415 := position, tokenIndex
 			{
-				position272 := position
+				position416 := position
 				if buffer[position] != rune('\'') {
-					goto l271
+					goto l415
 				}
 				position++
-				add(ruleSingleQuote, position272)
+				add(ruleSingleQuote, position416)
 			}
 			return true
-		l271:
-			position, tokenIndex = position271, tokenIndex271
+		l415:
+			position, tokenIndex = position415, tokenIndex415
 			return false
 		},
-		/* 34 DoubleQuote <- <'"'> */
+		/* 52 DoubleQuote <- <'"'> */
 		func() bool {
-			position273, tokenIndex273 := position, tokenIndex
+			position417, tokenIndex417 := position, tokenIndex
 			{
-				position274 := position
+				position418 := position
 				if buffer[position] != rune('"') {
-					goto l273
+					goto l417
 				}
 				position++
-				add(ruleDoubleQuote, position274)
+				add(ruleDoubleQuote, position418)
 			}
 			return true
-		l273:
-			position, tokenIndex = position273, tokenIndex273
+		l417:
+			position, tokenIndex = position417, tokenIndex417
 			return false
 		},
-		/* 35 WhiteSpacing <- <Whitespace*> */
+		/* 53 WhiteSpacing <- <Whitespace*> */
 		func() bool {
 			{
-				position276 := position
-			l277:
+				position420 := position
+			l421:
 				{
-					position278, tokenIndex278 := position, tokenIndex
+					position422, tokenIndex422 := position, tokenIndex
 					if !_rules[ruleWhitespace]() {
-						goto l278
+						goto l422
 					}
-					goto l277
-				l278:
-					position, tokenIndex = position278, tokenIndex278
+					goto l421
+				l422:
+					position, tokenIndex = position422, tokenIndex422
 				}
-				add(ruleWhiteSpacing, position276)
+				add(ruleWhiteSpacing, position420)
 			}
 			return true
 		},
-		/* 36 MustWhiteSpacing <- <Whitespace+> */
+		/* 54 MustWhiteSpacing <- <Whitespace+> */
 		func() bool {
-			position279, tokenIndex279 := position, tokenIndex
+			position423, tokenIndex423 := position, tokenIndex
 			{
-				position280 := position
+				position424 := position
 				if !_rules[ruleWhitespace]() {
-					goto l279
+					goto l423
 				}
-			l281:
+			l425:
 				{
-					position282, tokenIndex282 := position, tokenIndex
+					position426, tokenIndex426 := position, tokenIndex
 					if !_rules[ruleWhitespace]() {
-						goto l282
+						goto l426
 					}
-					goto l281
-				l282:
-					position, tokenIndex = position282, tokenIndex282
+					goto l425
+				l426:
+					position, tokenIndex = position426, tokenIndex426
 				}
-				add(ruleMustWhiteSpacing, position280)
+				add(ruleMustWhiteSpacing, position424)
 			}
 			return true
-		l279:
-			position, tokenIndex = position279, tokenIndex279
+		l423:
+			position, tokenIndex = position423, tokenIndex423
 			return false
 		},
-		/* 37 Equal <- <(WhiteSpacing '=' WhiteSpacing)> */
+		/* 55 Equal <- <(WhiteSpacing '=' WhiteSpacing)> */
 		func() bool {
-			position283, tokenIndex283 := position, tokenIndex
+			position427, tokenIndex427 := position, tokenIndex
 			{
-				position284 := position
+				position428 := position
 				if !_rules[ruleWhiteSpacing]() {
-					goto l283
+					goto l427
 				}
 				if buffer[position] != rune('=') {
-					goto l283
+					goto l427
 				}
 				position++
 				if !_rules[ruleWhiteSpacing]() {
-					goto l283
+					goto l427
 				}
-				add(ruleEqual, position284)
+				add(ruleEqual, position428)
 			}
 			return true
-		l283:
-			position, tokenIndex = position283, tokenIndex283
+		l427:
+			position, tokenIndex = position427, tokenIndex427
 			return false
 		},
-		/* 38 BlankLine <- <(WhiteSpacing EndOfLine)> */
+		/* 56 BlankLine <- <(WhiteSpacing EndOfLine Action60)> */
 		func() bool {
-			position285, tokenIndex285 := position, tokenIndex
+			position429, tokenIndex429 := position, tokenIndex
 			{
-				position286 := position
+				position430 := position
 				if !_rules[ruleWhiteSpacing]() {
-					goto l285
+					goto l429
 				}
 				if !_rules[ruleEndOfLine]() {
-					goto l285
+					goto l429
+				}
+				{
+					add(ruleAction60, position)
 				}
-				add(ruleBlankLine, position286)
+				add(ruleBlankLine, position430)
 			}
 			return true
-		l285:
-			position, tokenIndex = position285, tokenIndex285
+		l429:
+			position, tokenIndex = position429, tokenIndex429
 			return false
 		},
-		/* 39 Whitespace <- <(' ' / '\t')> */
+		/* 57 Whitespace <- <(' ' / '\t')> */
 		func() bool {
-			position287, tokenIndex287 := position, tokenIndex
+			position432, tokenIndex432 := position, tokenIndex
 			{
-				position288 := position
+				position433 := position
 				{
-					position289, tokenIndex289 := position, tokenIndex
+					position434, tokenIndex434 := position, tokenIndex
 					if buffer[position] != rune(' ') {
-						goto l290
+						goto l435
 					}
 					position++
-					goto l289
-				l290:
-					position, tokenIndex = position289, tokenIndex289
+					goto l434
+				l435:
+					position, tokenIndex = position434, tokenIndex434
 					if buffer[position] != rune('\t') {
-						goto l287
+						goto l432
 					}
 					position++
 				}
-			l289:
-				add(ruleWhitespace, position288)
+			l434:
+				add(ruleWhitespace, position433)
 			}
 			return true
-		l287:
-			position, tokenIndex = position287, tokenIndex287
+		l432:
+			position, tokenIndex = position432, tokenIndex432
 			return false
 		},
-		/* 40 EndOfLine <- <(('\r' '\n') / '\n' / '\r')> */
+		/* 58 EndOfLine <- <(('\r' '\n') / '\n' / '\r')> */
 		func() bool {
-			position291, tokenIndex291 := position, tokenIndex
+			position436, tokenIndex436 := position, tokenIndex
 			{
-				position292 := position
+				position437 := position
 				{
-					position293, tokenIndex293 := position, tokenIndex
+					position438, tokenIndex438 := position, tokenIndex
 					if buffer[position] != rune('\r') {
-						goto l294
+						goto l439
 					}
 					position++
 					if buffer[position] != rune('\n') {
-						goto l294
+						goto l439
 					}
 					position++
-					goto l293
-				l294:
-					position, tokenIndex = position293, tokenIndex293
+					goto l438
+				l439:
+					position, tokenIndex = position438, tokenIndex438
 					if buffer[position] != rune('\n') {
-						goto l295
+						goto l440
 					}
 					position++
-					goto l293
-				l295:
-					position, tokenIndex = position293, tokenIndex293
+					goto l438
+				l440:
+					position, tokenIndex = position438, tokenIndex438
 					if buffer[position] != rune('\r') {
-						goto l291
+						goto l436
 					}
 					position++
 				}
-			l293:
-				add(ruleEndOfLine, position292)
+			l438:
+				add(ruleEndOfLine, position437)
 			}
 			return true
-		l291:
-			position, tokenIndex = position291, tokenIndex291
+		l436:
+			position, tokenIndex = position436, tokenIndex436
 			return false
 		},
-		/* 41 EndOfFile <- <!.> */
+		/* 59 EndOfFile <- <!.> */
+		nil,
+		/* 61 Action0 <- <{ p.NewStatement() }> */
+		nil,
+		nil,
+		/* 63 Action1 <- <{ p.addStatementPosition(begin) }> */
 		nil,
-		/* 43 Action0 <- <{ p.NewStatement() }> */
+		/* 64 Action2 <- <{ p.StatementDone() }> */
 		nil,
-		/* 44 Action1 <- <{ p.StatementDone() }> */
+		/* 65 Action3 <- <{ p.addDeclarationIdentifier(text) }> */
 		nil,
+		/* 66 Action4 <- <{ p.addValue() }> */
 		nil,
-		/* 46 Action2 <- <{ p.addDeclarationIdentifier(text) }> */
+		/* 67 Action5 <- <{ p.addAction(text) }> */
 		nil,
-		/* 47 Action3 <- <{ p.addValue() }> */
+		/* 68 Action6 <- <{ p.addEntity(text) }> */
 		nil,
-		/* 48 Action4 <- <{ p.addAction(text) }> */
+		/* 69 Action7 <- <{ p.addCondition() }> */
 		nil,
-		/* 49 Action5 <- <{ p.addEntity(text) }> */
+		/* 70 Action8 <- <{ p.beginIfBlock() }> */
 		nil,
-		/* 50 Action6 <- <{ p.addParamKey(text) }> */
+		/* 71 Action9 <- <{ p.endBlock() }> */
 		nil,
-		/* 51 Action7 <- <{  p.addFirstValueInList() }> */
+		/* 72 Action10 <- <{ p.beginElseBlock() }> */
 		nil,
-		/* 52 Action8 <- <{  p.lastValueInList() }> */
+		/* 73 Action11 <- <{ p.NewStatement() }> */
 		nil,
-		/* 53 Action9 <- <{  p.addFirstValueInList() }> */
+		/* 74 Action12 <- <{ p.StatementDone() }> */
 		nil,
-		/* 54 Action10 <- <{  p.lastValueInList() }> */
+		/* 75 Action13 <- <{ p.beginElseBlock() }> */
 		nil,
-		/* 55 Action11 <- <{  p.addAliasParam(text) }> */
+		/* 76 Action14 <- <{ p.addConditionOperator(text) }> */
 		nil,
-		/* 56 Action12 <- <{  p.addParamRefValue(text) }> */
+		/* 77 Action15 <- <{ p.addLoopVariable(text) }> */
 		nil,
-		/* 57 Action13 <- <{ p.addParamCidrValue(text) }> */
+		/* 78 Action16 <- <{ p.beginForBlock() }> */
 		nil,
-		/* 58 Action14 <- <{ p.addParamIpValue(text) }> */
+		/* 79 Action17 <- <{ p.endBlock() }> */
 		nil,
-		/* 59 Action15 <- <{ p.addParamValue(text) }> */
+		/* 80 Action18 <- <{ p.addIncludePrefix(text) }> */
 		nil,
-		/* 60 Action16 <- <{ p.addParamValue(text) }> */
+		/* 81 Action19 <- <{ p.addIncludePath(text) }> */
 		nil,
-		/* 61 Action17 <- <{ p.addFirstValueInConcatenation() }> */
+		/* 82 Action20 <- <{ p.addParamDeclaration(text) }> */
 		nil,
-		/* 62 Action18 <- <{  p.lastValueInConcatenation() }> */
+		/* 83 Action21 <- <{ p.addParamDeclarationType(text) }> */
 		nil,
-		/* 63 Action19 <- <{ p.addFirstValueInConcatenation() }> */
+		/* 84 Action22 <- <{ p.addParamDeclarationAllowedValues() }> */
 		nil,
-		/* 64 Action20 <- <{  p.lastValueInConcatenation() }> */
+		/* 85 Action23 <- <{ p.addParamDeclarationDefault() }> */
 		nil,
-		/* 65 Action21 <- <{ p.addStringValue(text) }> */
+		/* 86 Action24 <- <{ p.addParamDeclarationDescription(text) }> */
 		nil,
-		/* 66 Action22 <- <{  p.addParamHoleValue(text) }> */
+		/* 87 Action25 <- <{ p.addOutputName(text) }> */
 		nil,
-		/* 67 Action23 <- <{ p.addFirstValueInConcatenation() }> */
+		/* 88 Action26 <- <{ p.addParamKey(text) }> */
 		nil,
-		/* 68 Action24 <- <{  p.lastValueInConcatenation() }> */
+		/* 89 Action27 <- <{ p.addValuePosition(begin) }> */
 		nil,
-		/* 69 Action25 <- <{ p.addFirstValueInConcatenation() }> */
+		/* 90 Action28 <- <{  p.addFirstValueInList() }> */
 		nil,
-		/* 70 Action26 <- <{  p.lastValueInConcatenation() }> */
+		/* 91 Action29 <- <{  p.lastValueInList() }> */
+		nil,
+		/* 92 Action30 <- <{  p.addFirstValueInList() }> */
+		nil,
+		/* 93 Action31 <- <{  p.lastValueInList() }> */
+		nil,
+		/* 94 Action32 <- <{ p.addFirstValueInMap() }> */
+		nil,
+		/* 95 Action33 <- <{ p.lastValueInMap() }> */
+		nil,
+		/* 96 Action34 <- <{ p.addMapKey(text) }> */
+		nil,
+		/* 97 Action35 <- <{ p.addMapKey(text) }> */
+		nil,
+		/* 98 Action36 <- <{  p.addAliasParam(text) }> */
+		nil,
+		/* 99 Action37 <- <{ p.addValuePosition(begin) }> */
+		nil,
+		/* 100 Action38 <- <{  p.addParamRefValue(text) }> */
+		nil,
+		/* 101 Action39 <- <{ p.addFunction(text) }> */
+		nil,
+		/* 102 Action40 <- <{ p.lastValueInFunction() }> */
+		nil,
+		/* 103 Action41 <- <{ p.addValuePosition(begin) }> */
+		nil,
+		/* 104 Action42 <- <{ p.addParamCidrValue(text) }> */
+		nil,
+		/* 105 Action43 <- <{ p.addParamIpValue(text) }> */
+		nil,
+		/* 106 Action44 <- <{ p.addParamValue(text) }> */
+		nil,
+		/* 107 Action45 <- <{ p.addParamValue(text) }> */
+		nil,
+		/* 108 Action46 <- <{ p.addFirstValueInConcatenation() }> */
+		nil,
+		/* 109 Action47 <- <{  p.lastValueInConcatenation() }> */
+		nil,
+		/* 110 Action48 <- <{ p.addFirstValueInConcatenation() }> */
+		nil,
+		/* 111 Action49 <- <{  p.lastValueInConcatenation() }> */
+		nil,
+		/* 112 Action50 <- <{ p.addStringValue(text) }> */
+		nil,
+		/* 113 Action51 <- <{ p.addHeredocValue("") }> */
+		nil,
+		/* 114 Action52 <- <{ p.addHeredocValue(text) }> */
+		nil,
+		/* 115 Action53 <- <{  p.addParamHoleValue(text) }> */
+		nil,
+		/* 116 Action54 <- <{ p.addFirstValueInConcatenation() }> */
+		nil,
+		/* 117 Action55 <- <{  p.lastValueInConcatenation() }> */
+		nil,
+		/* 118 Action56 <- <{ p.addFirstValueInConcatenation() }> */
+		nil,
+		/* 119 Action57 <- <{  p.lastValueInConcatenation() }> */
+		nil,
+		/* 120 Action58 <- <{ p.addComment(text) }> */
+		nil,
+		/* 121 Action59 <- <{ p.addInlineComment(text) }> */
+		nil,
+		/* 122 Action60 <- <{ p.addBlankLine() }> */
 		nil,
 	}
 	p.rules = _rules
+	return nil
 }
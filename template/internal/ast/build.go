@@ -5,6 +5,8 @@ import (
 	"net"
 	"regexp"
 	"strconv"
+	"strings"
+	"unicode"
 )
 
 type parameter struct {
@@ -21,10 +23,42 @@ type statementBuilder struct {
 	currentKey            string
 	currentValue          CompositeValue
 	listBuilder           *listValueBuilder
+	mapBuilder            *mapValueBuilder
 	concatenationBuilder  *concatenationValueBuilder
+	conditionBuilder      *conditionBuilder
+	funcBuilders          []*funcValueBuilder
+	loopVariable          string
+	block                 Node
+	include               *IncludeNode
+	param                 *ParamNode
+	output                string
+	comment               string
+	inlineComment         string
+	blankLineBefore       bool
+	position              Position
+	valuePosition         Position
 }
 
 func (b *statementBuilder) build() *Statement {
+	if b.block != nil {
+		return &Statement{Node: b.block}
+	}
+	if b.include != nil {
+		b.include.Params = make(map[string]CompositeValue)
+		for _, param := range b.params {
+			b.include.Params[param.key] = param.value
+		}
+		return &Statement{Node: b.include}
+	}
+	if b.param != nil {
+		return &Statement{Node: b.param}
+	}
+	if b.comment != "" {
+		return &Statement{Node: &CommentNode{Text: b.comment}}
+	}
+	if b.output != "" {
+		return &Statement{Node: &OutputNode{Name: b.output, ValueNode: ValueNode{Value: b.currentValue}}}
+	}
 	if b.action == "" && b.entity == "" && b.declarationIdentifier == "" && !b.isValue {
 		return nil
 	}
@@ -51,6 +85,9 @@ func (b *statementBuilder) addParamKey(key string) *statementBuilder {
 }
 
 func (b *statementBuilder) addParamValue(val CompositeValue) *statementBuilder {
+	if p, ok := val.(interface{ setPosition(Position) }); ok {
+		p.setPosition(b.valuePosition)
+	}
 	b.currentValue = val
 	if b.concatenationBuilder != nil {
 		b.concatenationBuilder.add(b.currentValue)
@@ -58,6 +95,15 @@ func (b *statementBuilder) addParamValue(val CompositeValue) *statementBuilder {
 	} else if b.listBuilder != nil {
 		b.listBuilder.add(b.currentValue)
 		b.currentValue = nil
+	} else if count := len(b.funcBuilders); count > 0 {
+		b.funcBuilders[count-1].add(b.currentValue)
+		b.currentValue = nil
+	} else if b.mapBuilder != nil {
+		b.mapBuilder.add(b.currentValue)
+		b.currentValue = nil
+	} else if b.conditionBuilder != nil {
+		b.conditionBuilder.add(b.currentValue)
+		b.currentValue = nil
 	} else {
 		if b.currentKey != "" {
 			b.params = append(b.params, &parameter{key: b.currentKey, value: b.currentValue})
@@ -70,7 +116,7 @@ func (b *statementBuilder) addParamValue(val CompositeValue) *statementBuilder {
 }
 
 func (b *statementBuilder) newList() *statementBuilder {
-	b.listBuilder = &listValueBuilder{}
+	b.listBuilder = &listValueBuilder{position: b.valuePosition}
 	return b
 }
 
@@ -106,17 +152,154 @@ func (a *AST) addDeclarationIdentifier(text string) {
 }
 
 func (a *AST) NewStatement() {
-	a.stmtBuilder = &statementBuilder{}
+	a.stmtBuilder = &statementBuilder{blankLineBefore: a.blankLines > 0}
+	a.blankLines = 0
 }
 
 func (a *AST) StatementDone() {
 
 	if stmt := a.stmtBuilder.build(); stmt != nil {
-		a.Statements = append(a.Statements, stmt)
+		stmt.BlankLineBefore = a.stmtBuilder.blankLineBefore
+		pos := a.stmtBuilder.position
+		stmt.Line, stmt.Column, stmt.Source = pos.Line, pos.Column, pos.Source
+		a.addStatement(stmt)
+		if text := a.stmtBuilder.inlineComment; text != "" {
+			a.addStatement(&Statement{Node: &CommentNode{Text: text, Inline: true}, Line: stmt.Line, Source: stmt.Source})
+		}
 	}
 	a.stmtBuilder = nil
 }
 
+// SetSourceFile sets the file of the parsed text, to locate its statements and values
+func (p *Peg) SetSourceFile(file string) {
+	p.source = NewSource(file, p.Buffer)
+}
+
+func (p *Peg) position(offset int) Position {
+	if p.source == nil {
+		p.source = NewSource("", p.Buffer)
+	}
+	return p.source.Position(offset)
+}
+
+// addStatementPosition sets the position of the statement starting at the given offset in the parsed text
+func (p *Peg) addStatementPosition(offset int) {
+	p.stmtBuilder.position = p.position(offset)
+}
+
+// addValuePosition sets the position of the value starting at the given offset in the parsed text
+func (p *Peg) addValuePosition(offset int) {
+	p.stmtBuilder.valuePosition = p.position(offset)
+}
+
+// ParsingPosition returns the position of the statement being built, to locate the errors of the parsing
+func (a *AST) ParsingPosition() Position {
+	if a.stmtBuilder == nil {
+		return Position{}
+	}
+	return a.stmtBuilder.position
+}
+
+func (a *AST) addStatement(stmt *Statement) {
+	if count := len(a.blockBuilders); count > 0 {
+		a.blockBuilders[count-1].add(stmt)
+	} else {
+		a.Statements = append(a.Statements, stmt)
+	}
+}
+
+func (a *AST) addCondition() {
+	a.stmtBuilder.conditionBuilder = &conditionBuilder{}
+}
+
+func (a *AST) addConditionOperator(text string) {
+	a.stmtBuilder.conditionBuilder.operator = text
+}
+
+func (a *AST) beginIfBlock() {
+	cond := a.stmtBuilder.conditionBuilder.build()
+	a.stmtBuilder.conditionBuilder = nil
+	a.blockBuilders = append(a.blockBuilders, &blockBuilder{
+		ifNode:      &IfNode{Condition: cond},
+		stmtBuilder: a.stmtBuilder,
+	})
+}
+
+func (a *AST) beginElseBlock() {
+	a.blockBuilders[len(a.blockBuilders)-1].inElse = true
+}
+
+func (a *AST) addIncludePrefix(text string) {
+	a.stmtBuilder.include = &IncludeNode{Prefix: text}
+}
+
+func (a *AST) addIncludePath(text string) {
+	if a.stmtBuilder.include == nil {
+		a.stmtBuilder.include = &IncludeNode{}
+	}
+	a.stmtBuilder.include.Path = text
+}
+
+func (a *AST) addParamDeclaration(text string) {
+	a.stmtBuilder.param = &ParamNode{Name: text}
+}
+
+func (a *AST) addParamDeclarationType(text string) {
+	a.stmtBuilder.param.Type = text
+}
+
+func (a *AST) addParamDeclarationAllowedValues() {
+	a.stmtBuilder.param.Allowed = a.stmtBuilder.currentValue
+	a.stmtBuilder.currentValue = nil
+}
+
+func (a *AST) addParamDeclarationDefault() {
+	a.stmtBuilder.param.Default = a.stmtBuilder.currentValue
+	a.stmtBuilder.currentValue = nil
+}
+
+func (a *AST) addParamDeclarationDescription(text string) {
+	a.stmtBuilder.param.Description = text
+}
+
+func (a *AST) addOutputName(text string) {
+	a.stmtBuilder.output = text
+}
+
+func (a *AST) addComment(text string) {
+	a.stmtBuilder.comment = strings.TrimRightFunc(text, unicode.IsSpace)
+}
+
+func (a *AST) addInlineComment(text string) {
+	a.stmtBuilder.inlineComment = strings.TrimRightFunc(text, unicode.IsSpace)
+}
+
+func (a *AST) addBlankLine() {
+	a.blankLines++
+}
+
+func (a *AST) addLoopVariable(text string) {
+	a.stmtBuilder.loopVariable = text
+}
+
+func (a *AST) beginForBlock() {
+	list := a.stmtBuilder.currentValue
+	a.stmtBuilder.currentValue = nil
+	a.blockBuilders = append(a.blockBuilders, &blockBuilder{
+		forNode:     &ForNode{Var: a.stmtBuilder.loopVariable, List: list},
+		stmtBuilder: a.stmtBuilder,
+	})
+}
+
+func (a *AST) endBlock() {
+	a.blankLines = 0
+	last := len(a.blockBuilders) - 1
+	block := a.blockBuilders[last]
+	a.blockBuilders = a.blockBuilders[:last]
+	a.stmtBuilder = block.stmtBuilder
+	a.stmtBuilder.block = block.node()
+}
+
 func (a *AST) addParamKey(text string) {
 	a.stmtBuilder.addParamKey(text)
 }
@@ -144,8 +327,24 @@ func (a *AST) lastValueInList() {
 	a.stmtBuilder.buildList()
 }
 
+func (a *AST) addFirstValueInMap() {
+	a.stmtBuilder.mapBuilder = &mapValueBuilder{position: a.stmtBuilder.valuePosition, vals: make(map[string]CompositeValue)}
+}
+
+func (a *AST) addMapKey(text string) {
+	a.stmtBuilder.mapBuilder.addKey(text)
+}
+
+func (a *AST) lastValueInMap() {
+	if a.stmtBuilder.mapBuilder != nil {
+		m := a.stmtBuilder.mapBuilder.build()
+		a.stmtBuilder.mapBuilder = nil
+		a.stmtBuilder.addParamValue(m)
+	}
+}
+
 func (a *AST) addFirstValueInConcatenation() {
-	a.stmtBuilder.concatenationBuilder = &concatenationValueBuilder{}
+	a.stmtBuilder.concatenationBuilder = &concatenationValueBuilder{position: a.stmtBuilder.valuePosition}
 }
 
 func (a *AST) lastValueInConcatenation() {
@@ -156,10 +355,58 @@ func (a *AST) lastValueInConcatenation() {
 	}
 }
 
+func (a *AST) addFunction(text string) {
+	if IsInvalidFunction(text) {
+		panic(fmt.Errorf("unknown function '%s', expected one of %s", text, strings.Join(FunctionNames(), ", ")))
+	}
+	b := a.stmtBuilder
+	b.funcBuilders = append(b.funcBuilders, &funcValueBuilder{
+		name: text, listBuilder: b.listBuilder, concatenationBuilder: b.concatenationBuilder, position: b.valuePosition,
+	})
+	b.listBuilder, b.concatenationBuilder = nil, nil
+}
+
+func (a *AST) lastValueInFunction() {
+	b := a.stmtBuilder
+	last := len(b.funcBuilders) - 1
+	fn := b.funcBuilders[last]
+	b.funcBuilders = b.funcBuilders[:last]
+	b.listBuilder, b.concatenationBuilder = fn.listBuilder, fn.concatenationBuilder
+	b.addParamValue(fn.build())
+}
+
 func (a *AST) addStringValue(text string) {
 	a.stmtBuilder.addParamValue(&interfaceValue{val: text})
 }
 
+func (a *AST) addHeredocValue(text string) {
+	a.stmtBuilder.addParamValue(&interfaceValue{val: Heredoc(text)})
+}
+
+// beginHeredoc records the delimiter of the heredoc value starting at position.
+// Called while parsing, as the closing delimiter can only be matched knowing the opening one.
+func (a *AST) beginHeredoc(buffer []rune, position uint32) bool {
+	end := position
+	for int(end) < len(buffer) && isIdentifierRune(buffer[end]) {
+		end++
+	}
+	a.heredocDelimiter = string(buffer[position:end])
+	return a.heredocDelimiter != ""
+}
+
+// isHeredocEnd returns true when the current heredoc delimiter is at position, as a whole word
+func (a *AST) isHeredocEnd(buffer []rune, position uint32) bool {
+	delim := []rune(a.heredocDelimiter)
+	if len(delim) == 0 || int(position)+len(delim) > len(buffer) {
+		return false
+	}
+	if string(buffer[position:int(position)+len(delim)]) != a.heredocDelimiter {
+		return false
+	}
+	next := int(position) + len(delim)
+	return next == len(buffer) || !isIdentifierRune(buffer[next])
+}
+
 func (a *AST) addParamCidrValue(text string) {
 	_, ipnet, err := net.ParseCIDR(text)
 	if err != nil {
@@ -181,6 +428,10 @@ func (a *AST) addParamRefValue(text string) {
 }
 
 func (a *AST) addParamHoleValue(text string) {
+	if hole := strings.TrimPrefix(text, SecretHolePrefix); hole != text {
+		a.stmtBuilder.addParamValue(&holeValue{hole: hole, secret: true})
+		return
+	}
 	a.stmtBuilder.addParamValue(&holeValue{hole: text})
 }
 
@@ -191,7 +442,8 @@ func (a *AST) addAliasParam(text string) {
 }
 
 type listValueBuilder struct {
-	vals []CompositeValue
+	vals     []CompositeValue
+	position Position
 }
 
 func (c *listValueBuilder) add(v CompositeValue) *listValueBuilder {
@@ -200,11 +452,37 @@ func (c *listValueBuilder) add(v CompositeValue) *listValueBuilder {
 }
 
 func (c *listValueBuilder) build() CompositeValue {
-	return &listValue{c.vals}
+	return &listValue{positioned: positioned{c.position}, vals: c.vals}
+}
+
+type mapValueBuilder struct {
+	keys       []string
+	vals       map[string]CompositeValue
+	currentKey string
+	position   Position
+}
+
+func (c *mapValueBuilder) addKey(key string) *mapValueBuilder {
+	if _, dup := c.vals[key]; dup {
+		panic(fmt.Errorf("duplicated key '%s' in map", key))
+	}
+	c.currentKey = key
+	return c
+}
+
+func (c *mapValueBuilder) add(v CompositeValue) *mapValueBuilder {
+	c.keys = append(c.keys, c.currentKey)
+	c.vals[c.currentKey] = v
+	return c
+}
+
+func (c *mapValueBuilder) build() CompositeValue {
+	return &mapValue{positioned: positioned{c.position}, keys: c.keys, vals: c.vals}
 }
 
 type concatenationValueBuilder struct {
-	vals []CompositeValue
+	vals     []CompositeValue
+	position Position
 }
 
 func (c *concatenationValueBuilder) add(v CompositeValue) *concatenationValueBuilder {
@@ -213,5 +491,72 @@ func (c *concatenationValueBuilder) add(v CompositeValue) *concatenationValueBui
 }
 
 func (c *concatenationValueBuilder) build() CompositeValue {
-	return &concatenationValue{c.vals}
+	return &concatenationValue{positioned: positioned{c.position}, vals: c.vals}
+}
+
+// funcValueBuilder collects the arguments of a function call, saving the
+// list or concatenation being built when the call started
+type funcValueBuilder struct {
+	name                 string
+	args                 []CompositeValue
+	listBuilder          *listValueBuilder
+	concatenationBuilder *concatenationValueBuilder
+	position             Position
+}
+
+func (c *funcValueBuilder) add(v CompositeValue) *funcValueBuilder {
+	c.args = append(c.args, v)
+	return c
+}
+
+func (c *funcValueBuilder) build() CompositeValue {
+	return &funcValue{positioned: positioned{c.position}, name: c.name, args: c.args}
+}
+
+type conditionBuilder struct {
+	vals     []CompositeValue
+	operator string
+}
+
+func (c *conditionBuilder) add(v CompositeValue) *conditionBuilder {
+	c.vals = append(c.vals, v)
+	return c
+}
+
+func (c *conditionBuilder) build() *Condition {
+	cond := &Condition{Operator: c.operator}
+	if len(c.vals) > 0 {
+		cond.Left = c.vals[0]
+	}
+	if len(c.vals) > 1 {
+		cond.Right = c.vals[1]
+	}
+	return cond
+}
+
+// blockBuilder collects the statements nested in a block,
+// keeping track of the builder of the statement owning the block
+type blockBuilder struct {
+	ifNode      *IfNode
+	inElse      bool
+	forNode     *ForNode
+	stmtBuilder *statementBuilder
+}
+
+func (b *blockBuilder) add(stmt *Statement) {
+	switch {
+	case b.forNode != nil:
+		b.forNode.Body = append(b.forNode.Body, stmt)
+	case b.inElse:
+		b.ifNode.Else = append(b.ifNode.Else, stmt)
+	default:
+		b.ifNode.Then = append(b.ifNode.Then, stmt)
+	}
+}
+
+func (b *blockBuilder) node() Node {
+	if b.forNode != nil {
+		return b.forNode
+	}
+	return b.ifNode
 }
@@ -0,0 +1,112 @@
+package ast
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Format returns the canonical text of the template: one statement per line
+// with sorted params, blocks indented with tabs, the '=' of consecutive
+// declarations aligned, comments kept and blank lines collapsed into one
+func (a *AST) Format() string {
+	var buff bytes.Buffer
+	formatStatements(&buff, a.Statements, "")
+	return buff.String()
+}
+
+func formatStatements(buff *bytes.Buffer, stmts []*Statement, indent string) {
+	widths := declarationWidths(stmts)
+	for i := 0; i < len(stmts); i++ {
+		if i > 0 && stmts[i].BlankLineBefore {
+			buff.WriteString("\n")
+		}
+		buff.WriteString(indent)
+		formatStatement(buff, stmts[i], indent, widths[i])
+		if i+1 < len(stmts) {
+			if comment, ok := stmts[i+1].Node.(*CommentNode); ok && comment.Inline {
+				fmt.Fprintf(buff, " %s", comment.Text)
+				i++
+			}
+		}
+		buff.WriteString("\n")
+	}
+}
+
+func formatStatement(buff *bytes.Buffer, st *Statement, indent string, width int) {
+	switch n := st.Node.(type) {
+	case *DeclarationNode:
+		fmt.Fprintf(buff, "%-*s = %s", width, n.Ident, n.Expr)
+	case *IncludeNode:
+		if n.Prefix != "" {
+			fmt.Fprintf(buff, "%-*s = %s", width, n.Prefix, strings.TrimPrefix(n.String(), n.Prefix+" = "))
+		} else {
+			buff.WriteString(n.String())
+		}
+	case *IfNode:
+		formatIf(buff, n, indent)
+	case *ForNode:
+		fmt.Fprintf(buff, "for %s in %s {\n", n.Var, n.List)
+		formatStatements(buff, n.Body, indent+"\t")
+		fmt.Fprintf(buff, "%s}", indent)
+	default:
+		buff.WriteString(st.String())
+	}
+}
+
+func formatIf(buff *bytes.Buffer, n *IfNode, indent string) {
+	fmt.Fprintf(buff, "if %s {\n", n.Condition)
+	formatStatements(buff, n.Then, indent+"\t")
+	fmt.Fprintf(buff, "%s}", indent)
+	if len(n.Else) == 1 {
+		if elseIf, ok := n.Else[0].Node.(*IfNode); ok {
+			buff.WriteString(" else ")
+			formatIf(buff, elseIf, indent)
+			return
+		}
+	}
+	if len(n.Else) > 0 {
+		buff.WriteString(" else {\n")
+		formatStatements(buff, n.Else, indent+"\t")
+		fmt.Fprintf(buff, "%s}", indent)
+	}
+}
+
+// declarationWidths returns for each statement the width of the identifiers
+// declared by its group of consecutive declarations, to align their '='
+func declarationWidths(stmts []*Statement) []int {
+	widths := make([]int, len(stmts))
+	for i := 0; i < len(stmts); {
+		j, width := i, 0
+		for ; j < len(stmts); j++ {
+			if comment, ok := stmts[j].Node.(*CommentNode); ok && comment.Inline && j > i {
+				continue
+			}
+			ident, isDecl := declaredIdentifier(stmts[j])
+			if !isDecl || (j > i && stmts[j].BlankLineBefore) {
+				break
+			}
+			if len(ident) > width {
+				width = len(ident)
+			}
+		}
+		for k := i; k < j; k++ {
+			widths[k] = width
+		}
+		if j == i {
+			j++
+		}
+		i = j
+	}
+	return widths
+}
+
+func declaredIdentifier(st *Statement) (string, bool) {
+	switch n := st.Node.(type) {
+	case *DeclarationNode:
+		return n.Ident, true
+	case *IncludeNode:
+		return n.Prefix, n.Prefix != ""
+	}
+	return "", false
+}
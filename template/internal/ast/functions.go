@@ -0,0 +1,362 @@
+package ast
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type function struct {
+	minArgs, maxArgs int
+	call             func(args ...interface{}) (interface{}, error)
+}
+
+var nowFunc = time.Now
+
+var functions = map[string]*function{
+	"lower": {1, 1, func(args ...interface{}) (interface{}, error) {
+		return strings.ToLower(fmt.Sprint(args[0])), nil
+	}},
+	"upper": {1, 1, func(args ...interface{}) (interface{}, error) {
+		return strings.ToUpper(fmt.Sprint(args[0])), nil
+	}},
+	"join": {1, 2, func(args ...interface{}) (interface{}, error) {
+		var strs []string
+		for _, v := range toList(args[0]) {
+			strs = append(strs, fmt.Sprint(v))
+		}
+		return strings.Join(strs, separatorArg(args)), nil
+	}},
+	"split": {1, 2, func(args ...interface{}) (interface{}, error) {
+		var list []interface{}
+		for _, s := range strings.Split(fmt.Sprint(args[0]), separatorArg(args)) {
+			list = append(list, s)
+		}
+		return list, nil
+	}},
+	"base64": {1, 1, func(args ...interface{}) (interface{}, error) {
+		return base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(args[0]))), nil
+	}},
+	"file": {1, 1, func(args ...interface{}) (interface{}, error) {
+		content, err := ioutil.ReadFile(fmt.Sprint(args[0]))
+		if err != nil {
+			return nil, err
+		}
+		return string(content), nil
+	}},
+	"env": {1, 1, func(args ...interface{}) (interface{}, error) {
+		name := fmt.Sprint(args[0])
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, fmt.Errorf("environment variable '%s' is not set", name)
+		}
+		return value, nil
+	}},
+	"cidrsubnet": {3, 3, func(args ...interface{}) (interface{}, error) {
+		newbits, err := toInt(args[1])
+		if err != nil {
+			return nil, err
+		}
+		index, err := toInt(args[2])
+		if err != nil {
+			return nil, err
+		}
+		return cidrSubnet(fmt.Sprint(args[0]), newbits, index)
+	}},
+	"now": {0, 1, func(args ...interface{}) (interface{}, error) {
+		layout := time.RFC3339
+		if len(args) > 0 {
+			layout = fmt.Sprint(args[0])
+		}
+		return nowFunc().UTC().Format(layout), nil
+	}},
+}
+
+func IsInvalidFunction(name string) bool {
+	_, ok := functions[name]
+	return !ok
+}
+
+func FunctionNames() (names []string) {
+	for name := range functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+func callFunction(name string, args []interface{}) (interface{}, error) {
+	fn, ok := functions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function '%s'", name)
+	}
+	if len(args) < fn.minArgs || len(args) > fn.maxArgs {
+		if fn.minArgs == fn.maxArgs {
+			return nil, fmt.Errorf("expected %d argument(s), got %d", fn.minArgs, len(args))
+		}
+		return nil, fmt.Errorf("expected %d to %d arguments, got %d", fn.minArgs, fn.maxArgs, len(args))
+	}
+	return fn.call(args...)
+}
+
+// cidrSubnet computes the index-th subnet of the cidr extended with newbits, as terraform's cidrsubnet does
+func cidrSubnet(cidr string, newbits, index int) (string, error) {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", fmt.Errorf("invalid cidr '%s'", cidr)
+	}
+	ones, bits := ipnet.Mask.Size()
+	if newbits < 0 || ones+newbits > bits {
+		return "", fmt.Errorf("cannot extend prefix /%d of '%s' by %d bits", ones, cidr, newbits)
+	}
+	if index < 0 || (newbits < 63 && index >= 1<<uint(newbits)) {
+		return "", fmt.Errorf("index %d out of range for %d new bits", index, newbits)
+	}
+	ip := ipnet.IP
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	ip = append(net.IP(nil), ip...)
+	for i := 0; i < newbits; i++ {
+		if index&(1<<uint(newbits-1-i)) != 0 {
+			pos := ones + i
+			ip[pos/8] |= 1 << uint(7-pos%8)
+		}
+	}
+	subnet := &net.IPNet{IP: ip, Mask: net.CIDRMask(ones+newbits, bits)}
+	return subnet.String(), nil
+}
+
+func separatorArg(args []interface{}) string {
+	if len(args) > 1 {
+		return fmt.Sprint(args[1])
+	}
+	return ","
+}
+
+func toList(i interface{}) []interface{} {
+	switch ii := i.(type) {
+	case []interface{}:
+		return ii
+	case []string:
+		var list []interface{}
+		for _, s := range ii {
+			list = append(list, s)
+		}
+		return list
+	default:
+		return []interface{}{ii}
+	}
+}
+
+func toInt(i interface{}) (int, error) {
+	switch ii := i.(type) {
+	case int:
+		return ii, nil
+	default:
+		n, err := strconv.Atoi(fmt.Sprint(ii))
+		if err != nil {
+			return 0, fmt.Errorf("expected an int, got '%v'", ii)
+		}
+		return n, nil
+	}
+}
+
+type funcValue struct {
+	positioned
+	name string
+	args []CompositeValue
+	val  interface{}
+}
+
+func (f *funcValue) resolved() bool {
+	for _, arg := range f.args {
+		if withHoles, ok := arg.(WithHoles); ok && len(withHoles.GetHoles()) > 0 {
+			return false
+		}
+		if withRefs, ok := arg.(WithRefs); ok && len(withRefs.GetRefs()) > 0 {
+			return false
+		}
+		if withAlias, ok := arg.(WithAlias); ok && len(withAlias.GetAliases()) > 0 {
+			return false
+		}
+		if arg.Value() == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluate calls the function once all its arguments are resolved
+func (f *funcValue) evaluate() (bool, error) {
+	if f.val != nil {
+		return true, nil
+	}
+	if !f.resolved() {
+		return false, nil
+	}
+	var args []interface{}
+	for _, arg := range f.args {
+		args = append(args, arg.Value())
+	}
+	val, err := callFunction(f.name, args)
+	if err != nil {
+		return false, err
+	}
+	if val == nil {
+		return false, errors.New("no result")
+	}
+	f.val = val
+	return true, nil
+}
+
+// Value is nil until the call is evaluated, which fails rather than leaving the value unset
+func (f *funcValue) Value() interface{} {
+	return f.val
+}
+
+func (f *funcValue) String() string {
+	if f.val != nil {
+		return printParamValue(f.val)
+	}
+	var buff bytes.Buffer
+	buff.WriteString(f.name)
+	buff.WriteRune('(')
+	for i, arg := range f.args {
+		if i > 0 {
+			buff.WriteString(", ")
+		}
+		buff.WriteString(arg.String())
+	}
+	buff.WriteRune(')')
+	return buff.String()
+}
+
+func (f *funcValue) Clone() CompositeValue {
+	clone := &funcValue{positioned: f.positioned, name: f.name, val: f.val}
+	for _, arg := range f.args {
+		clone.args = append(clone.args, arg.Clone())
+	}
+	return clone
+}
+
+func (f *funcValue) GetHoles() map[string][]string {
+	res := make(map[string][]string)
+	for _, arg := range f.args {
+		if withHoles, ok := arg.(WithHoles); ok {
+			for k, v := range withHoles.GetHoles() {
+				res[k] = v
+			}
+		}
+	}
+	return res
+}
+
+func (f *funcValue) ProcessHoles(fills map[string]interface{}) map[string]interface{} {
+	processed := make(map[string]interface{})
+	for _, arg := range f.args {
+		if withHoles, ok := arg.(WithHoles); ok {
+			for k, v := range withHoles.ProcessHoles(fills) {
+				processed[k] = v
+			}
+		}
+	}
+	return processed
+}
+
+func (f *funcValue) GetRefs() (res []string) {
+	for _, arg := range f.args {
+		if withRefs, ok := arg.(WithRefs); ok {
+			res = append(res, withRefs.GetRefs()...)
+		}
+	}
+	return
+}
+
+func (f *funcValue) ProcessRefs(fills map[string]interface{}) {
+	for _, arg := range f.args {
+		if withRefs, ok := arg.(WithRefs); ok {
+			withRefs.ProcessRefs(fills)
+		}
+	}
+}
+
+func (f *funcValue) ReplaceRef(key string, value CompositeValue) {
+	for i, arg := range f.args {
+		if withRef, ok := arg.(WithRefs); ok {
+			if withRef.IsRef(key) {
+				f.args[i] = value
+			} else {
+				withRef.ReplaceRef(key, value)
+			}
+		}
+	}
+}
+
+func (f *funcValue) IsRef(key string) bool {
+	return false
+}
+
+func (f *funcValue) GetAliases() (res []string) {
+	for _, arg := range f.args {
+		if alias, ok := arg.(WithAlias); ok {
+			res = append(res, alias.GetAliases()...)
+		}
+	}
+	return
+}
+
+func (f *funcValue) ResolveAlias(resolvFunc func(string) (string, bool)) {
+	for _, arg := range f.args {
+		if alias, ok := arg.(WithAlias); ok {
+			alias.ResolveAlias(resolvFunc)
+		}
+	}
+}
+
+// EvaluateFunctions computes the function calls of the statements whose arguments
+// are known. Calls depending on values only known at runtime are left as is.
+func EvaluateFunctions(stmts []*Statement) (err error) {
+	mapValues(stmts, func(v CompositeValue) CompositeValue {
+		if err == nil {
+			err = evaluateFunction(v)
+		}
+		return v
+	})
+	return
+}
+
+// EvaluateValueFunctions computes the function calls of a value,
+// as at runtime once the references of its statement are resolved
+func EvaluateValueFunctions(v CompositeValue) (err error) {
+	transformValue(v, func(v CompositeValue) CompositeValue {
+		if err == nil {
+			err = evaluateFunction(v)
+		}
+		return v
+	})
+	return
+}
+
+func evaluateFunction(v CompositeValue) error {
+	f, ok := v.(*funcValue)
+	if !ok {
+		return nil
+	}
+	if _, err := f.evaluate(); err != nil {
+		err = fmt.Errorf("%s: %s", f, err)
+		if f.pos.IsValid() {
+			err = &PositionError{Pos: f.pos, Err: err}
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package ast
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCallFunctions(t *testing.T) {
+	nowFunc = func() time.Time { return time.Date(2017, 12, 4, 10, 30, 0, 0, time.UTC) }
+	defer func() { nowFunc = time.Now }()
+
+	os.Setenv("AWLESS_TEST_FUNC", "from-env")
+	defer os.Unsetenv("AWLESS_TEST_FUNC")
+
+	dir, err := ioutil.TempDir("", "awless-functions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	userdata := filepath.Join(dir, "userdata.sh")
+	if err = ioutil.WriteFile(userdata, []byte("#!/bin/bash"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tcases := []struct {
+		name   string
+		args   []interface{}
+		expect interface{}
+		expErr string
+	}{
+		{name: "lower", args: []interface{}{"MyVPC"}, expect: "myvpc"},
+		{name: "upper", args: []interface{}{"prod"}, expect: "PROD"},
+		{name: "upper", args: []interface{}{"a", "b"}, expErr: "expected 1 argument(s), got 2"},
+		{name: "join", args: []interface{}{[]interface{}{"a", "b", 3}}, expect: "a,b,3"},
+		{name: "join", args: []interface{}{[]interface{}{"a", "b"}, "-"}, expect: "a-b"},
+		{name: "join", args: []interface{}{"single", "-"}, expect: "single"},
+		{name: "split", args: []interface{}{"a,b"}, expect: []interface{}{"a", "b"}},
+		{name: "split", args: []interface{}{"a b", " "}, expect: []interface{}{"a", "b"}},
+		{name: "base64", args: []interface{}{"hello"}, expect: "aGVsbG8="},
+		{name: "file", args: []interface{}{userdata}, expect: "#!/bin/bash"},
+		{name: "file", args: []interface{}{filepath.Join(dir, "none")}, expErr: "no such file"},
+		{name: "env", args: []interface{}{"AWLESS_TEST_FUNC"}, expect: "from-env"},
+		{name: "env", args: []interface{}{"AWLESS_TEST_FUNC_UNSET"}, expErr: "environment variable 'AWLESS_TEST_FUNC_UNSET' is not set"},
+		{name: "cidrsubnet", args: []interface{}{"10.0.0.0/16", 8, 0}, expect: "10.0.0.0/24"},
+		{name: "cidrsubnet", args: []interface{}{"10.0.0.0/16", 8, 2}, expect: "10.0.2.0/24"},
+		{name: "cidrsubnet", args: []interface{}{"10.0.0.0/16", "4", "15"}, expect: "10.0.240.0/20"},
+		{name: "cidrsubnet", args: []interface{}{"172.16.0.0/12", 4, 1}, expect: "172.17.0.0/16"},
+		{name: "cidrsubnet", args: []interface{}{"10.0.0.0/16", 2, 4}, expErr: "index 4 out of range for 2 new bits"},
+		{name: "cidrsubnet", args: []interface{}{"10.0.0.0/30", 4, 0}, expErr: "cannot extend prefix /30"},
+		{name: "cidrsubnet", args: []interface{}{"10.0.0.0", 4, 0}, expErr: "invalid cidr '10.0.0.0'"},
+		{name: "cidrsubnet", args: []interface{}{"10.0.0.0/16", "eight", 0}, expErr: "expected an int, got 'eight'"},
+		{name: "now", expect: "2017-12-04T10:30:00Z"},
+		{name: "now", args: []interface{}{"2006-01-02"}, expect: "2017-12-04"},
+		{name: "unknown", expErr: "unknown function 'unknown'"},
+	}
+
+	for i, tcase := range tcases {
+		got, err := callFunction(tcase.name, tcase.args)
+		if tcase.expErr != "" {
+			if err == nil {
+				t.Fatalf("%d: expected error, got none", i+1)
+			}
+			if !strings.Contains(err.Error(), tcase.expErr) {
+				t.Fatalf("%d: got %s, want %s", i+1, err, tcase.expErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if want := tcase.expect; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %#v, want %#v", i+1, got, want)
+		}
+	}
+}
+
+func TestFuncValue(t *testing.T) {
+	fn := &funcValue{name: "lower", args: []CompositeValue{&holeValue{hole: "env"}}}
+	if got, want := fn.String(), "lower({env})"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if fn.Value() != nil {
+		t.Fatalf("expected nil value with unresolved args, got %v", fn.Value())
+	}
+	clone := fn.Clone()
+	fn.ProcessHoles(map[string]interface{}{"env": "PROD"})
+	if fn.Value() != nil {
+		t.Fatalf("expected nil value before evaluation, got %v", fn.Value())
+	}
+	if err := EvaluateValueFunctions(fn); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fn.Value(), "prod"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := fn.String(), "prod"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if clone.Value() != nil {
+		t.Fatalf("expected clone to be unresolved, got %v", clone.Value())
+	}
+}
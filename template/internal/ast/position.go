@@ -0,0 +1,135 @@
+package ast
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Source is the text of a parsed template, with the file it was read from if any
+type Source struct {
+	File       string
+	lines      []string
+	lineStarts []int
+}
+
+func NewSource(file, text string) *Source {
+	src := &Source{File: file, lines: strings.Split(text, "\n"), lineStarts: []int{0}}
+	for i, r := range []rune(text) {
+		if r == '\n' {
+			src.lineStarts = append(src.lineStarts, i+1)
+		}
+	}
+	return src
+}
+
+// Position returns the position of the given offset (in runes) of the text
+func (s *Source) Position(offset int) Position {
+	line := sort.Search(len(s.lineStarts), func(i int) bool { return s.lineStarts[i] > offset })
+	return Position{Source: s, Line: line, Column: offset - s.lineStarts[line-1] + 1}
+}
+
+// Position locates a statement or a value in the source of a template.
+// Lines and columns start at 1, a zero line meaning an unknown position.
+type Position struct {
+	Source       *Source
+	Line, Column int
+}
+
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String returns the position as file:line:col, or line:col when the file is unknown
+func (p Position) String() string {
+	if !p.IsValid() {
+		return ""
+	}
+	if p.Source != nil && p.Source.File != "" {
+		return fmt.Sprintf("%s:%d:%d", p.Source.File, p.Line, p.Column)
+	}
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Excerpt returns the line of the position, indented with a tab, with a caret under its column
+func (p Position) Excerpt() string {
+	if !p.IsValid() || p.Source == nil || p.Line > len(p.Source.lines) {
+		return ""
+	}
+	line := []rune(strings.TrimRight(p.Source.lines[p.Line-1], "\r"))
+	var buff bytes.Buffer
+	fmt.Fprintf(&buff, "\t%s\n\t", string(line))
+	for i := 0; i < p.Column-1 && i < len(line); i++ {
+		if line[i] == '\t' {
+			buff.WriteRune('\t')
+		} else {
+			buff.WriteRune(' ')
+		}
+	}
+	buff.WriteRune('^')
+	return buff.String()
+}
+
+// PositionError is an error located in the text of a template,
+// printed as file:line:col followed by an excerpt of the offending line
+type PositionError struct {
+	Pos Position
+	Err error
+}
+
+func (e *PositionError) Error() string {
+	msg := fmt.Sprintf("%s: %s", e.Pos, e.Err)
+	excerpt := e.Pos.Excerpt()
+	if excerpt == "" {
+		return msg
+	}
+	lines := strings.SplitN(msg, "\n", 2)
+	lines = append(lines[:1], append([]string{excerpt}, lines[1:]...)...)
+	return strings.Join(lines, "\n")
+}
+
+// Position returns the line (starting at 1) and the char (starting at 0) of the error
+func (e *PositionError) Position() (line, char int) {
+	return e.Pos.Line, e.Pos.Column - 1
+}
+
+// WithPosition is implemented by the values knowing where they were parsed
+type WithPosition interface {
+	Position() Position
+}
+
+// PositionOf returns the position of a value, invalid when unknown
+func PositionOf(v CompositeValue) Position {
+	if withPos, ok := v.(WithPosition); ok {
+		return withPos.Position()
+	}
+	return Position{}
+}
+
+type positioned struct {
+	pos Position
+}
+
+func (p *positioned) Position() Position {
+	return p.pos
+}
+
+func (p *positioned) setPosition(pos Position) {
+	if !p.pos.IsValid() {
+		p.pos = pos
+	}
+}
+
+func (p *positioned) resetPosition() {
+	p.pos = Position{}
+}
+
+func withoutPosition(v CompositeValue) CompositeValue {
+	return transformValue(v, func(v CompositeValue) CompositeValue {
+		if p, ok := v.(interface{ resetPosition() }); ok {
+			p.resetPosition()
+		}
+		return v
+	})
+}
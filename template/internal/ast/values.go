@@ -24,10 +24,34 @@ type WithAlias interface {
 	ResolveAlias(func(string) (string, bool))
 }
 
+// ListValue is a value listing other values, as in `[a,$b]`
+type ListValue interface {
+	CompositeValue
+	Elems() []CompositeValue
+}
+
+// ConcatenationValue is a value concatenating other values, as in `{prefix}+"-name"`
+type ConcatenationValue interface {
+	CompositeValue
+	Parts() []CompositeValue
+}
+
+// MapValue is a value mapping keys to other values, as in `{Env: prod, Team: $team}`
+type MapValue interface {
+	CompositeValue
+	Keys() []string
+	Elem(string) CompositeValue
+}
+
 type listValue struct {
+	positioned
 	vals []CompositeValue
 }
 
+func (l *listValue) Elems() []CompositeValue {
+	return l.vals
+}
+
 func (l *listValue) GetHoles() map[string][]string {
 	res := make(map[string][]string)
 	for _, val := range l.vals {
@@ -130,14 +154,143 @@ func (l *listValue) ResolveAlias(resolvFunc func(string) (string, bool)) {
 }
 
 func (l *listValue) Clone() CompositeValue {
-	clone := &listValue{}
+	clone := &listValue{positioned: l.positioned}
 	for _, val := range l.vals {
 		clone.vals = append(clone.vals, val.Clone())
 	}
 	return clone
 }
 
+type mapValue struct {
+	positioned
+	keys []string
+	vals map[string]CompositeValue
+}
+
+func (m *mapValue) Keys() []string {
+	return m.keys
+}
+
+func (m *mapValue) Elem(key string) CompositeValue {
+	return m.vals[key]
+}
+
+func (m *mapValue) GetHoles() map[string][]string {
+	res := make(map[string][]string)
+	for _, val := range m.vals {
+		if withHoles, ok := val.(WithHoles); ok {
+			for k, v := range withHoles.GetHoles() {
+				res[k] = v
+			}
+		}
+	}
+	return res
+}
+
+func (m *mapValue) GetRefs() (res []string) {
+	for _, key := range m.keys {
+		if withRefs, ok := m.vals[key].(WithRefs); ok {
+			res = append(res, withRefs.GetRefs()...)
+		}
+	}
+	return
+}
+
+func (m *mapValue) Value() interface{} {
+	res := make(map[string]interface{})
+	for k, val := range m.vals {
+		if v := val.Value(); v != nil {
+			res[k] = v
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
+func (m *mapValue) ProcessHoles(fills map[string]interface{}) map[string]interface{} {
+	processed := make(map[string]interface{})
+	for _, val := range m.vals {
+		if withHoles, ok := val.(WithHoles); ok {
+			for k, v := range withHoles.ProcessHoles(fills) {
+				processed[k] = v
+			}
+		}
+	}
+	return processed
+}
+
+func (m *mapValue) ProcessRefs(fills map[string]interface{}) {
+	for _, val := range m.vals {
+		if withRefs, ok := val.(WithRefs); ok {
+			withRefs.ProcessRefs(fills)
+		}
+	}
+}
+
+func (m *mapValue) ReplaceRef(key string, value CompositeValue) {
+	for k, val := range m.vals {
+		if withRef, ok := val.(WithRefs); ok {
+			if withRef.IsRef(key) {
+				m.vals[k] = value
+			} else {
+				withRef.ReplaceRef(key, value)
+			}
+		}
+	}
+}
+
+func (m *mapValue) IsRef(key string) bool {
+	return false
+}
+
+// String prints a space after the colons, for `{secret: x}` not to be read as a secret hole
+func (m *mapValue) String() string {
+	var buff bytes.Buffer
+	buff.WriteRune('{')
+	for i, key := range m.keys {
+		printed := key
+		if !identifierRegex.MatchString(key) {
+			printed = quoteString(key)
+		}
+		fmt.Fprintf(&buff, "%s: %s", printed, m.vals[key])
+		if i < len(m.keys)-1 {
+			buff.WriteString(", ")
+		}
+	}
+	buff.WriteRune('}')
+	return buff.String()
+}
+
+func (m *mapValue) GetAliases() (res []string) {
+	for _, key := range m.keys {
+		if alias, ok := m.vals[key].(WithAlias); ok {
+			res = append(res, alias.GetAliases()...)
+		}
+	}
+	return
+}
+
+func (m *mapValue) ResolveAlias(resolvFunc func(string) (string, bool)) {
+	for _, val := range m.vals {
+		if alias, ok := val.(WithAlias); ok {
+			alias.ResolveAlias(resolvFunc)
+		}
+	}
+}
+
+func (m *mapValue) Clone() CompositeValue {
+	clone := &mapValue{positioned: m.positioned, vals: make(map[string]CompositeValue)}
+	for _, key := range m.keys {
+		clone.keys = append(clone.keys, key)
+		clone.vals[key] = m.vals[key].Clone()
+	}
+	return clone
+}
+
 type interfaceValue struct {
+	positioned
 	val interface{}
 }
 
@@ -154,13 +307,29 @@ func (i *interfaceValue) String() string {
 }
 
 func (i *interfaceValue) Clone() CompositeValue {
-	return &interfaceValue{val: i.val}
+	return &interfaceValue{positioned: i.positioned, val: i.val}
+}
+
+// Heredoc is the value of a heredoc param. Whatever its number of lines, it is
+// printed back as a heredoc and file params take it as content rather than as a path.
+type Heredoc string
+
+func (h Heredoc) InlineContent() string {
+	return string(h)
 }
 
+// SecretHolePrefix marks a hole whose value is never printed, as in {secret:db.password}
+const SecretHolePrefix = "secret:"
+
+// RedactedValue stands for the value of a secret hole in processed fillers
+const RedactedValue = "******"
+
 type holeValue struct {
-	hole  string
-	val   interface{}
-	alias WithAlias
+	positioned
+	hole   string
+	val    interface{}
+	alias  WithAlias
+	secret bool
 }
 
 func NewHoleValue(hole string) CompositeValue {
@@ -200,13 +369,18 @@ func (h *holeValue) ProcessHoles(fills map[string]interface{}) map[string]interf
 		} else {
 			h.val = fill
 			processed[h.hole] = fill
+			if h.secret {
+				processed[h.hole] = RedactedValue
+			}
 		}
 	}
 	return processed
 }
 
 func (h *holeValue) String() string {
-	if h.val != nil {
+	if h.secret {
+		return fmt.Sprintf("{%s%s}", SecretHolePrefix, h.hole)
+	} else if h.val != nil {
 		return printParamValue(h.val)
 	} else if h.alias != nil {
 		return fmt.Sprint(h.alias)
@@ -230,13 +404,18 @@ func (h *holeValue) ResolveAlias(resolvFunc func(string) (string, bool)) {
 }
 
 func (h *holeValue) Clone() CompositeValue {
-	return &holeValue{val: h.val, hole: h.hole, alias: h.alias}
+	return &holeValue{positioned: h.positioned, val: h.val, hole: h.hole, alias: h.alias, secret: h.secret}
 }
 
 type concatenationValue struct {
+	positioned
 	vals []CompositeValue
 }
 
+func (c *concatenationValue) Parts() []CompositeValue {
+	return c.vals
+}
+
 func (c *concatenationValue) GetHoles() map[string][]string {
 	res := make(map[string][]string)
 	for _, val := range c.vals {
@@ -339,7 +518,7 @@ func (c *concatenationValue) ResolveAlias(resolvFunc func(string) (string, bool)
 }
 
 func (c *concatenationValue) Clone() CompositeValue {
-	clone := &concatenationValue{}
+	clone := &concatenationValue{positioned: c.positioned}
 	for _, val := range c.vals {
 		clone.vals = append(clone.vals, val.Clone())
 	}
@@ -347,6 +526,7 @@ func (c *concatenationValue) Clone() CompositeValue {
 }
 
 type aliasValue struct {
+	positioned
 	alias string
 	val   interface{}
 }
@@ -381,10 +561,11 @@ func (a *aliasValue) ResolveAlias(resolvFunc func(string) (string, bool)) {
 }
 
 func (a *aliasValue) Clone() CompositeValue {
-	return &aliasValue{val: a.val, alias: a.alias}
+	return &aliasValue{positioned: a.positioned, val: a.val, alias: a.alias}
 }
 
 type referenceValue struct {
+	positioned
 	ref   string
 	val   interface{}
 	alias string
@@ -428,7 +609,7 @@ func (r *referenceValue) String() string {
 }
 
 func (r *referenceValue) Clone() CompositeValue {
-	return &referenceValue{val: r.val, ref: r.ref, alias: r.alias}
+	return &referenceValue{positioned: r.positioned, val: r.val, ref: r.ref, alias: r.alias}
 }
 
 func (r *referenceValue) GetAliases() (aliases []string) {
@@ -48,6 +48,23 @@ func TestCompositeValues(t *testing.T) {
 			holesFillers: map[string]interface{}{"myhole": "my-value"},
 			expValue:     []interface{}{"test", 10, "my-value", "refvalue"},
 		},
+		{
+			val: &mapValue{keys: []string{"a", "b", "c", "d"}, vals: map[string]CompositeValue{
+				"a": &interfaceValue{val: "test"}, "b": &holeValue{hole: "myhole"}, "c": &referenceValue{ref: "myref"}, "d": &aliasValue{alias: "myalias"},
+			}},
+			expRefs:    []string{"myref"},
+			expHoles:   map[string][]string{"myhole": nil},
+			expValue:   map[string]interface{}{"a": "test"},
+			expAliases: []string{"myalias"},
+		},
+		{
+			val: &mapValue{keys: []string{"a", "b", "c"}, vals: map[string]CompositeValue{
+				"a": &interfaceValue{val: 10}, "b": &holeValue{hole: "myhole"}, "c": &referenceValue{ref: "myref"},
+			}},
+			refsFillers:  map[string]interface{}{"myref": "refvalue"},
+			holesFillers: map[string]interface{}{"myhole": "my-value"},
+			expValue:     map[string]interface{}{"a": 10, "b": "my-value", "c": "refvalue"},
+		},
 		{
 			val: &concatenationValue{
 				vals: []CompositeValue{&interfaceValue{val: "prefix-"}, &holeValue{hole: "hole1"}, &interfaceValue{val: "middle1-"}, &holeValue{hole: "hole2"}, &interfaceValue{val: "-middle2-"}, &holeValue{hole: "hole3"}, &interfaceValue{val: "suffix"}},
@@ -216,6 +233,10 @@ func TestCloneValues(t *testing.T) {
 			),
 			mutationFn: func(v CompositeValue) { v.(*listValue).ProcessHoles(map[string]interface{}{"myhole": "myvalue"}) },
 		},
+		{
+			from:       &mapValue{keys: []string{"a", "b"}, vals: map[string]CompositeValue{"a": &interfaceValue{val: "test"}, "b": &holeValue{hole: "myhole"}}},
+			mutationFn: func(v CompositeValue) { v.(*mapValue).ProcessHoles(map[string]interface{}{"myhole": "myvalue"}) },
+		},
 		{
 			from: &concatenationValue{
 				vals: []CompositeValue{&interfaceValue{val: "prefix-"}, &holeValue{hole: "hole1"}, &interfaceValue{val: "middle1-"}, &holeValue{hole: "hole2"}, &interfaceValue{val: "-middle2-"}, &holeValue{hole: "hole3"}, &interfaceValue{val: "suffix"}},
@@ -0,0 +1,285 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lsp
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/template"
+)
+
+var (
+	declarationRegex = regexp.MustCompile(`^\s*([a-zA-Z0-9-_.]+)\s*=`)
+	loopRegex        = regexp.MustCompile(`^\s*for\s+([a-zA-Z0-9-_.]+)\s+in\s`)
+)
+
+// Diagnostics returns the syntax error of the template if any,
+// otherwise the findings of its lint rules
+func Diagnostics(text string) (diags []*Diagnostic) {
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	lines := strings.Split(text, "\n")
+	tpl, err := template.Parse(text)
+	if err != nil {
+		diag := &Diagnostic{Severity: SeverityError, Source: "awless", Message: strings.TrimSuffix(strings.SplitN(err.Error(), "\n", 2)[0], ":")}
+		if positioned, ok := err.(interface {
+			Position() (int, int)
+		}); ok {
+			if line, char := positioned.Position(); line > 0 {
+				diag.Range = lineRange(lines, line-1)
+				diag.Range.Start.Character = char
+			}
+		}
+		return append(diags, diag)
+	}
+	for _, f := range tpl.Lint() {
+		diags = append(diags, &Diagnostic{
+			Range:    lineRange(lines, f.Line-1),
+			Severity: lintSeverity(f.Severity),
+			Code:     f.Rule,
+			Source:   "awless",
+			Message:  f.Message,
+		})
+	}
+	return
+}
+
+func lintSeverity(s template.Severity) int {
+	switch s {
+	case template.SeverityError:
+		return SeverityError
+	case template.SeverityWarning:
+		return SeverityWarning
+	default:
+		return SeverityInformation
+	}
+}
+
+// Complete returns the actions, entities, param names or param values
+// which can be typed at the given position of the template
+func (s *Server) Complete(text string, pos Position) (items []*CompletionItem) {
+	line := lineAt(text, pos.Line)
+	if pos.Character < len(line) {
+		line = line[:pos.Character]
+	}
+	fields, typing := commandFields(line)
+	edit := func(newText string) *TextEdit {
+		return &TextEdit{NewText: newText, Range: Range{
+			Start: Position{Line: pos.Line, Character: len(line) - len(typing)},
+			End:   Position{Line: pos.Line, Character: len(line)},
+		}}
+	}
+
+	switch len(fields) {
+	case 0:
+		for _, action := range sortedKeys(s.Actions) {
+			if strings.HasPrefix(action, typing) {
+				items = append(items, &CompletionItem{Label: action, Kind: completionKindKeyword, TextEdit: edit(action)})
+			}
+		}
+	case 1:
+		entities := append([]string{}, s.Actions[fields[0]]...)
+		sort.Strings(entities)
+		for _, entity := range entities {
+			if strings.HasPrefix(entity, typing) {
+				items = append(items, &CompletionItem{Label: entity, Kind: completionKindKeyword, TextEdit: edit(entity)})
+			}
+		}
+	default:
+		action, entity := fields[0], fields[1]
+		if eq := strings.Index(typing, "="); eq >= 0 {
+			param, value := typing[:eq], typing[eq+1:]
+			typing = value
+			for _, item := range s.valueCompletions(text, action, entity, param) {
+				if strings.HasPrefix(item.Label, value) {
+					item.TextEdit = edit(item.Label)
+					items = append(items, item)
+				}
+			}
+			return
+		}
+		if s.Params == nil {
+			return
+		}
+		required, extra, ok := s.Params(action, entity)
+		if !ok {
+			return
+		}
+		used := make(map[string]bool)
+		for _, f := range fields[2:] {
+			used[strings.SplitN(f, "=", 2)[0]] = true
+		}
+		for i, params := range [][]string{required, extra} {
+			for _, param := range params {
+				if used[param] || !strings.HasPrefix(param, typing) {
+					continue
+				}
+				item := &CompletionItem{Label: param, Kind: completionKindProperty, TextEdit: edit(param + "=")}
+				if i == 0 {
+					item.Detail = "required"
+				}
+				if s.ParamDoc != nil {
+					item.Documentation, _ = s.ParamDoc(action, entity, param)
+				}
+				items = append(items, item)
+			}
+		}
+	}
+	return
+}
+
+func (s *Server) valueCompletions(text, action, entity, param string) (items []*CompletionItem) {
+	if s.Enums != nil {
+		for _, enum := range s.Enums(action, entity, param) {
+			items = append(items, &CompletionItem{Label: enum, Kind: completionKindValue})
+		}
+	}
+	if s.Aliases != nil {
+		aliases := s.Aliases(action, entity, param)
+		sort.Strings(aliases)
+		for _, alias := range aliases {
+			items = append(items, &CompletionItem{Label: "@" + alias, Kind: completionKindValue})
+		}
+	}
+	for _, variable := range declaredVariables(text) {
+		items = append(items, &CompletionItem{Label: "$" + variable, Kind: completionKindVariable})
+	}
+	return
+}
+
+// Hover returns the documentation and the possible values of the param under the given position
+func (s *Server) Hover(text string, pos Position) *Hover {
+	line := lineAt(text, pos.Line)
+	start, end := wordBounds(line, pos.Character, func(r byte) bool { return r != ' ' && r != '\t' })
+	if start == end {
+		return nil
+	}
+	fields, _ := commandFields(line + " ")
+	if len(fields) < 2 {
+		return nil
+	}
+	action, entity := fields[0], fields[1]
+	param := strings.SplitN(line[start:end], "=", 2)[0]
+
+	var contents []string
+	if s.ParamDoc != nil {
+		if doc, ok := s.ParamDoc(action, entity, param); ok {
+			contents = append(contents, fmt.Sprintf("**%s**: %s", param, doc))
+		}
+	}
+	if s.Enums != nil {
+		if enums := s.Enums(action, entity, param); len(enums) > 0 {
+			contents = append(contents, fmt.Sprintf("Values: %s", strings.Join(enums, ", ")))
+		}
+	}
+	if len(contents) == 0 {
+		return nil
+	}
+	return &Hover{
+		Contents: markupContent{Kind: "markdown", Value: strings.Join(contents, "\n\n")},
+		Range:    &Range{Start: Position{Line: pos.Line, Character: start}, End: Position{Line: pos.Line, Character: end}},
+	}
+}
+
+// Definition returns the range of the declaration of the $variable under the given position
+func Definition(text string, pos Position) (Range, bool) {
+	start, end := wordBounds(lineAt(text, pos.Line), pos.Character, isIdentifierChar)
+	if start == 0 || start == end || lineAt(text, pos.Line)[start-1] != '$' {
+		return Range{}, false
+	}
+	variable := lineAt(text, pos.Line)[start:end]
+	for i, line := range strings.Split(text, "\n") {
+		for _, regex := range []*regexp.Regexp{declarationRegex, loopRegex} {
+			if match := regex.FindStringSubmatchIndex(line); match != nil && line[match[2]:match[3]] == variable {
+				return Range{Start: Position{Line: i, Character: match[2]}, End: Position{Line: i, Character: match[3]}}, true
+			}
+		}
+	}
+	return Range{}, false
+}
+
+func declaredVariables(text string) (variables []string) {
+	unique := make(map[string]bool)
+	for _, line := range strings.Split(text, "\n") {
+		for _, regex := range []*regexp.Regexp{declarationRegex, loopRegex} {
+			if match := regex.FindStringSubmatch(line); match != nil && !unique[match[1]] {
+				unique[match[1]] = true
+				variables = append(variables, match[1])
+			}
+		}
+	}
+	return
+}
+
+// commandFields returns the complete fields of the command typed on the line,
+// without its declaration if any, and the field being typed
+func commandFields(line string) (fields []string, typing string) {
+	if match := declarationRegex.FindStringIndex(line); match != nil {
+		line = line[match[1]:]
+	}
+	fields = strings.Fields(line)
+	if len(fields) > 0 && !strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\t") {
+		typing = fields[len(fields)-1]
+		fields = fields[:len(fields)-1]
+	}
+	return
+}
+
+func wordBounds(line string, char int, inWord func(byte) bool) (start, end int) {
+	if char > len(line) {
+		char = len(line)
+	}
+	for start = char; start > 0 && inWord(line[start-1]); start-- {
+	}
+	for end = char; end < len(line) && inWord(line[end]); end++ {
+	}
+	return
+}
+
+func isIdentifierChar(r byte) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.'
+}
+
+func lineAt(text string, index int) string {
+	lines := strings.Split(text, "\n")
+	if index < 0 || index >= len(lines) {
+		return ""
+	}
+	return strings.TrimSuffix(lines[index], "\r")
+}
+
+func lineRange(lines []string, index int) Range {
+	var length int
+	if index >= 0 && index < len(lines) {
+		length = len(strings.TrimSuffix(lines[index], "\r"))
+	} else {
+		index = 0
+	}
+	return Range{Start: Position{Line: index}, End: Position{Line: index, Character: length}}
+}
+
+func sortedKeys(m map[string][]string) (keys []string) {
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
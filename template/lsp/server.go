@@ -0,0 +1,207 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lsp implements a Language Server Protocol server for awless templates
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// Server answers LSP requests on templates documents. The cloud specific
+// knowledge (actions, params, docs and aliases) is given by its fields,
+// any of them being optional
+type Server struct {
+	// Actions returns for each action its supported entities
+	Actions map[string][]string
+	// Params returns the required and extra params of a command
+	Params func(action, entity string) (required, extra []string, ok bool)
+	// ParamDoc returns the documentation of a param of a command
+	ParamDoc func(action, entity, param string) (string, bool)
+	// Enums returns the possible values of a param of a command
+	Enums func(action, entity, param string) []string
+	// Aliases returns the known aliases (without '@') for a param of a command
+	Aliases func(action, entity, param string) []string
+
+	docs     map[string]string
+	out      io.Writer
+	shutdown bool
+}
+
+// Run serves the JSON-RPC requests read from in until the client exits
+func (s *Server) Run(in io.Reader, out io.Writer) error {
+	s.out = out
+	if s.docs == nil {
+		s.docs = make(map[string]string)
+	}
+	reader := bufio.NewReader(in)
+	for {
+		body, err := readMessage(reader)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var req request
+		if err := json.Unmarshal(body, &req); err != nil {
+			if err := s.reply(nil, nil, &responseError{Code: codeParseError, Message: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+		if req.Method == "exit" {
+			return nil
+		}
+		result, rerr := s.handle(&req)
+		if req.ID == nil { // notifications have no response
+			continue
+		}
+		if err := s.reply(req.ID, result, rerr); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(req *request) (interface{}, *responseError) {
+	if s.shutdown && req.Method != "exit" {
+		return nil, &responseError{Code: codeInvalidRequest, Message: "server is shut down"}
+	}
+	switch req.Method {
+	case "initialize":
+		res := &initializeResult{}
+		res.ServerInfo.Name = "awless"
+		res.Capabilities.TextDocumentSync = 1 // full content on changes
+		res.Capabilities.HoverProvider = true
+		res.Capabilities.DefinitionProvider = true
+		res.Capabilities.CompletionProvider.TriggerCharacters = []string{"@", "$", "=", " "}
+		return res, nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		s.docs[params.TextDocument.URI] = params.TextDocument.Text
+		return nil, s.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		if l := len(params.ContentChanges); l > 0 {
+			s.docs[params.TextDocument.URI] = params.ContentChanges[l-1].Text
+		}
+		return nil, s.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		delete(s.docs, params.TextDocument.URI)
+		return nil, nil
+	case "textDocument/completion":
+		var params textDocumentPositionParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.Complete(s.docs[params.TextDocument.URI], params.Position), nil
+	case "textDocument/hover":
+		var params textDocumentPositionParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		if hover := s.Hover(s.docs[params.TextDocument.URI], params.Position); hover != nil {
+			return hover, nil
+		}
+		return nil, nil
+	case "textDocument/definition":
+		var params textDocumentPositionParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		if rang, ok := Definition(s.docs[params.TextDocument.URI], params.Position); ok {
+			return &Location{URI: params.TextDocument.URI, Range: rang}, nil
+		}
+		return nil, nil
+	default:
+		if req.ID == nil || strings.HasPrefix(req.Method, "$/") {
+			return nil, nil
+		}
+		return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method '%s' not supported", req.Method)}
+	}
+}
+
+func (s *Server) publishDiagnostics(uri string) *responseError {
+	diags := Diagnostics(s.docs[uri])
+	if diags == nil {
+		diags = []*Diagnostic{}
+	}
+	if err := s.write(&notification{JSONRPC: "2.0", Method: "textDocument/publishDiagnostics", Params: &publishDiagnosticsParams{URI: uri, Diagnostics: diags}}); err != nil {
+		return &responseError{Code: codeInvalidRequest, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) reply(id *json.RawMessage, result interface{}, rerr *responseError) error {
+	if id == nil {
+		null := json.RawMessage("null")
+		id = &null
+	}
+	return s.write(&response{JSONRPC: "2.0", ID: id, Result: result, Error: rerr})
+}
+
+func (s *Server) write(msg interface{}) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(b), b)
+	return err
+}
+
+func invalidParams(err error) *responseError {
+	return &responseError{Code: codeInvalidParams, Message: err.Error()}
+}
+
+func readMessage(r *bufio.Reader) ([]byte, error) {
+	headers, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("reading headers: %s", err)
+	}
+	length, err := strconv.Atoi(headers.Get("Content-Length"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Content-Length header: %s", err)
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, fmt.Errorf("reading content: %s", err)
+	}
+	return body, nil
+}
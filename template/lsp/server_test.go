@@ -0,0 +1,214 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestServer() *Server {
+	return &Server{
+		Actions: map[string][]string{"create": {"subnet", "instance"}, "delete": {"instance"}},
+		Params: func(action, entity string) ([]string, []string, bool) {
+			if action == "create" && entity == "instance" {
+				return []string{"image", "subnet"}, []string{"keypair", "name"}, true
+			}
+			return nil, nil, false
+		},
+		ParamDoc: func(action, entity, param string) (string, bool) {
+			if param == "subnet" {
+				return "The subnet of the instance", true
+			}
+			return "", false
+		},
+		Enums: func(action, entity, param string) []string {
+			if param == "type" {
+				return []string{"t2.micro", "t2.nano"}
+			}
+			return nil
+		},
+		Aliases: func(action, entity, param string) []string {
+			if param == "subnet" {
+				return []string{"public-subnet", "private-subnet"}
+			}
+			return nil
+		},
+	}
+}
+
+func TestComplete(t *testing.T) {
+	s := newTestServer()
+	tcases := []struct {
+		text     string
+		pos      Position
+		expected []string
+	}{
+		{text: "", pos: Position{0, 0}, expected: []string{"create", "delete"}},
+		{text: "cr", pos: Position{0, 2}, expected: []string{"create"}},
+		{text: "create ", pos: Position{0, 7}, expected: []string{"instance", "subnet"}},
+		{text: "inst = create i", pos: Position{0, 15}, expected: []string{"instance"}},
+		{text: "create instance image=ami-123 ", pos: Position{0, 30}, expected: []string{"subnet=", "keypair=", "name="}},
+		{text: "create instance subnet=@p", pos: Position{0, 25}, expected: []string{"@private-subnet", "@public-subnet"}},
+		{text: "sub = create subnet\n\tcreate instance subnet=$", pos: Position{1, 25}, expected: []string{"$sub"}},
+		{text: "create instance type=t2.", pos: Position{0, 24}, expected: []string{"t2.micro", "t2.nano"}},
+	}
+	for i, tcase := range tcases {
+		var labels []string
+		for _, item := range s.Complete(tcase.text, tcase.pos) {
+			labels = append(labels, item.TextEdit.NewText)
+			if got, want := item.TextEdit.Range.End, tcase.pos; got != want {
+				t.Fatalf("%d: got %+v, want %+v", i, got, want)
+			}
+		}
+		if got, want := labels, tcase.expected; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %v, want %v", i, got, want)
+		}
+	}
+
+	items := s.Complete("create instance sub", Position{0, 19})
+	if got, want := len(items), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := items[0].Documentation, "The subnet of the instance"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := items[0].Detail, "required"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := items[0].TextEdit.Range.Start, (Position{0, 16}); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestHover(t *testing.T) {
+	s := newTestServer()
+	hover := s.Hover("create instance subnet=@my-subnet", Position{0, 18})
+	if hover == nil {
+		t.Fatal("expected hover")
+	}
+	if got, want := hover.Contents.Value, "**subnet**: The subnet of the instance"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := *hover.Range, (Range{Position{0, 16}, Position{0, 33}}); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	hover = s.Hover("create instance type=t2.micro", Position{0, 18})
+	if got, want := hover.Contents.Value, "Values: t2.micro, t2.nano"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if hover = s.Hover("create instance name=test", Position{0, 18}); hover != nil {
+		t.Fatalf("got %v, want nil", hover)
+	}
+}
+
+func TestDefinition(t *testing.T) {
+	text := "# comment\nmysubnet = create subnet\nfor inst in [$mysubnet] {\n\tcreate instance subnet=$mysubnet name=$inst\n}"
+	rang, ok := Definition(text, Position{3, 28})
+	if !ok {
+		t.Fatal("expected definition")
+	}
+	if got, want := rang, (Range{Position{1, 0}, Position{1, 8}}); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	rang, ok = Definition(text, Position{3, 42})
+	if !ok {
+		t.Fatal("expected definition")
+	}
+	if got, want := rang, (Range{Position{2, 4}, Position{2, 8}}); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	if _, ok = Definition(text, Position{3, 20}); ok {
+		t.Fatal("expected no definition outside of variables")
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	diags := Diagnostics("create vpc\ncreate subnet cidr=10.0.0.0/24 ==")
+	if got, want := len(diags), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := diags[0].Range.Start, (Position{1, 31}); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	if got, want := diags[0].Severity, SeverityError; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	diags = Diagnostics("create vpc cidr=10.0.0.0/16 name=myvpc\nunused = create subnet cidr=10.0.0.0/24 vpc=@myvpc name=mysubnet")
+	if got, want := len(diags), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := diags[0].Code, "unused-variable"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := diags[0].Range, (Range{Position{1, 0}, Position{1, 64}}); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	if got, want := diags[0].Severity, SeverityWarning; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
+
+func TestServerRun(t *testing.T) {
+	var in bytes.Buffer
+	send := func(id int, method string, params interface{}) {
+		msg := map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+		if id > 0 {
+			msg["id"] = id
+		}
+		b, _ := json.Marshal(msg)
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(b), b)
+	}
+	uri := "file:///tmp/infra.aws"
+	send(1, "initialize", map[string]interface{}{})
+	send(0, "initialized", map[string]interface{}{})
+	send(0, "textDocument/didOpen", map[string]interface{}{"textDocument": map[string]interface{}{"uri": uri, "text": "create vpc =="}})
+	send(2, "textDocument/completion", map[string]interface{}{"textDocument": map[string]interface{}{"uri": uri}, "position": Position{0, 0}})
+	send(3, "unknown/method", nil)
+	send(4, "shutdown", nil)
+	send(0, "exit", nil)
+
+	var out bytes.Buffer
+	if err := newTestServer().Run(&in, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	var messages []map[string]interface{}
+	reader := bufio.NewReader(&out)
+	for {
+		body, err := readMessage(reader)
+		if err != nil {
+			break
+		}
+		var msg map[string]interface{}
+		if err := json.Unmarshal(body, &msg); err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, msg)
+	}
+	if got, want := len(messages), 5; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := messages[0]["result"].(map[string]interface{})["capabilities"].(map[string]interface{})["hoverProvider"], true; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := messages[1]["method"], "textDocument/publishDiagnostics"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := len(messages[1]["params"].(map[string]interface{})["diagnostics"].([]interface{})), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := len(messages[2]["result"].([]interface{})), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := messages[3]["error"].(map[string]interface{})["code"], float64(codeMethodNotFound); got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if !strings.HasSuffix(fmt.Sprint(messages[4]["id"]), "4") {
+		t.Fatalf("got %v, want shutdown response", messages[4])
+	}
+}
@@ -18,6 +18,9 @@ type TemplateExecution struct {
 	Author, Source, Locale string
 	Profile, Path, Message string
 	Fillers                map[string]interface{}
+	Outputs                map[string]interface{}
+	// RollbackOf and RolledBackBy link a failed execution and its automatic rollback
+	RollbackOf, RolledBackBy string
 }
 
 // Date extract the date from the ulid template identifier
@@ -61,11 +64,19 @@ func (t *TemplateExecution) MarshalJSON() ([]byte, error) {
 	if out.Fillers == nil {
 		out.Fillers = make(map[string]interface{}, 0) // friendlier for json, avoiding "fillers": null,
 	}
+	out.Outputs = t.Outputs
+	out.RollbackOf = t.RollbackOf
+	out.RolledBackBy = t.RolledBackBy
 	out.Commands = []command{}
 
-	for _, cmd := range t.CommandNodesIterator() {
+	for _, st := range t.flatStatements() {
+		cmd, ok := extractExpressionNode(st).(*ast.CommandNode)
+		if !ok {
+			continue
+		}
 		newCmd := command{}
 		newCmd.Line = cmd.String()
+		newCmd.Declaration = declaredIdentifier(st)
 		if cmd.CmdErr != nil {
 			newCmd.Errors = append(newCmd.Errors, cmd.CmdErr.Error())
 		}
@@ -74,6 +85,8 @@ func (t *TemplateExecution) MarshalJSON() ([]byte, error) {
 				newCmd.Results = append(newCmd.Results, s)
 			}
 		}
+		newCmd.PriorValues = cmd.CmdPriorValues
+		newCmd.Snapshots = cmd.CmdSnapshots
 		out.Commands = append(out.Commands, newCmd)
 	}
 
@@ -102,6 +115,9 @@ func (t *TemplateExecution) UnmarshalJSON(b []byte) error {
 	t.Path = v.Path
 	t.Author = v.Author
 	t.Fillers = v.Fillers
+	t.Outputs = v.Outputs
+	t.RollbackOf = v.RollbackOf
+	t.RolledBackBy = v.RolledBackBy
 
 	tpl := &Template{ID: v.ID, AST: &ast.AST{
 		Statements: make([]*ast.Statement, 0),
@@ -122,6 +138,12 @@ func (t *TemplateExecution) UnmarshalJSON(b []byte) error {
 			if len(c.Errors) > 0 {
 				n.CmdErr = errors.New(c.Errors[0])
 			}
+			n.CmdPriorValues = c.PriorValues
+			n.CmdSnapshots = c.Snapshots
+			if c.Declaration != "" {
+				tpl.Statements = append(tpl.Statements, &ast.Statement{Node: &ast.DeclarationNode{Ident: c.Declaration, Expr: n}})
+				continue
+			}
 			tpl.Statements = append(tpl.Statements, &ast.Statement{Node: n})
 		}
 	}
@@ -164,19 +186,25 @@ func (t *TemplateExecution) Stats() *TemplateExecutionStats {
 }
 
 type toJSON struct {
-	ID       string                 `json:"id"`
-	Author   string                 `json:"author,omitempty"`
-	Source   string                 `json:"source"`
-	Locale   string                 `json:"locale"`
-	Profile  string                 `json:"profile,omitempty"`
-	Message  string                 `json:"message,omitempty"`
-	Path     string                 `json:"path,omitempty"`
-	Fillers  map[string]interface{} `json:"fillers"`
-	Commands []command              `json:"commands"`
+	ID           string                 `json:"id"`
+	Author       string                 `json:"author,omitempty"`
+	Source       string                 `json:"source"`
+	Locale       string                 `json:"locale"`
+	Profile      string                 `json:"profile,omitempty"`
+	Message      string                 `json:"message,omitempty"`
+	Path         string                 `json:"path,omitempty"`
+	Fillers      map[string]interface{} `json:"fillers"`
+	Outputs      map[string]interface{} `json:"outputs,omitempty"`
+	RollbackOf   string                 `json:"rollbackOf,omitempty"`
+	RolledBackBy string                 `json:"rolledBackBy,omitempty"`
+	Commands     []command              `json:"commands"`
 }
 
 type command struct {
-	Line    string   `json:"line"`
-	Errors  []string `json:"errors,omitempty"`
-	Results []string `json:"results,omitempty"`
+	Line        string                 `json:"line"`
+	Declaration string                 `json:"declaration,omitempty"`
+	Errors      []string               `json:"errors,omitempty"`
+	Results     []string               `json:"results,omitempty"`
+	PriorValues map[string]interface{} `json:"priorValues,omitempty"`
+	Snapshots   []*ast.Snapshot        `json:"snapshots,omitempty"`
 }
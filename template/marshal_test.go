@@ -92,6 +92,8 @@ func TestTemplateExecutionUnmarshalFromJSON(t *testing.T) {
 		"message": "Make the CLI great again",
 		"profile": "admin",
 		"path": "http://gist.com/mytemplate.aws",
+		"rollbackOf": "123455",
+		"rolledBackBy": "123457",
 		"fillers": {
 			"mykey": "myvalue",
 			"mysecondkey": "mysecondvalue"
@@ -136,6 +138,12 @@ func TestTemplateExecutionUnmarshalFromJSON(t *testing.T) {
 	if got, want := tplExec.Path, "http://gist.com/mytemplate.aws"; got != want {
 		t.Fatalf("got %s, want %s", got, want)
 	}
+	if got, want := tplExec.RollbackOf, "123455"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tplExec.RolledBackBy, "123457"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
 
 	if got, want := cmds[0].CmdResult, "vpc-12345"; got != want {
 		t.Fatalf("got %v, want %v", got, want)
@@ -321,3 +329,83 @@ func identJSON(content []byte) string {
 	}
 	return string(ident)
 }
+
+func TestTemplateExecutionWithHeredocRoundTrip(t *testing.T) {
+	text := "create instance name=web userdata=<<EOF\n#!/bin/bash\necho 'hello' > /tmp/out\nEOF\ncreate policy document=<<EOF\n{\"Version\": \"2012-10-17\"}\nEOF name=p"
+	tplExec := &TemplateExecution{Template: MustParse(text), Source: text}
+
+	b, err := json.Marshal(tplExec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unmarshaled := &TemplateExecution{}
+	if err := json.Unmarshal(b, unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := unmarshaled.Template.String(), tplExec.Template.String(); got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+	if got, want := unmarshaled.CommandNodesIterator()[0].ToDriverParams()["userdata"], ast.Heredoc("#!/bin/bash\necho 'hello' > /tmp/out"); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestTemplateExecutionWithPriorValuesRoundTrip(t *testing.T) {
+	tplExec := &TemplateExecution{Template: MustParse("update record name=my.domain.com ttl=60 type=A value=10.0.0.2 zone=my-zone")}
+	cmd := tplExec.CommandNodesIterator()[0]
+	cmd.CmdResult = "change-id"
+	cmd.CmdPriorValues = map[string]interface{}{"ttl": 300, "value": "10.0.0.1"}
+
+	b, err := json.Marshal(tplExec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unmarshaled := &TemplateExecution{}
+	if err := json.Unmarshal(b, unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+	if !IsRevertible(unmarshaled.Template) {
+		t.Fatal("expected update with prior values to be revertible")
+	}
+	reverted, err := unmarshaled.Revert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := reverted.String(), "update record name=my.domain.com ttl=300 type=A value=10.0.0.1 zone=my-zone"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestTemplateExecutionWithSnapshotsRoundTrip(t *testing.T) {
+	tplExec := &TemplateExecution{Template: MustParse("delete queue url=https://sqs.eu-west-1.amazonaws.com/123456789012/jobs")}
+	cmd := tplExec.CommandNodesIterator()[0]
+	cmd.CmdSnapshots = []*ast.Snapshot{{Type: "queue", ID: "https://sqs.eu-west-1.amazonaws.com/123456789012/jobs",
+		Properties:    map[string]interface{}{"Delay": 5},
+		Recreate:      "queue = create queue delay=5 name=jobs",
+		Unrecreatable: []string{"queue https://sqs.eu-west-1.amazonaws.com/123456789012/jobs: its 3 messages"},
+	}}
+
+	b, err := json.Marshal(tplExec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unmarshaled := &TemplateExecution{}
+	if err := json.Unmarshal(b, unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+	snapshots := unmarshaled.CommandNodesIterator()[0].CmdSnapshots
+	if got, want := len(snapshots), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := snapshots[0].Properties["Delay"], float64(5); got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	reverted, err := unmarshaled.Revert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := "queue = create queue delay=5 name=jobs\n# cannot recreate queue https://sqs.eu-west-1.amazonaws.com/123456789012/jobs: its 3 messages"
+	if got, want := reverted.String(), exp; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,181 @@
+package template
+
+import (
+	"sort"
+
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+// canRunInParallel returns whether the statements are flat (i.e. compiled)
+// with only commands, outputs and declarations of commands
+func canRunInParallel(statements []*ast.Statement) bool {
+	for _, st := range statements {
+		switch n := st.Node.(type) {
+		case *ast.CommandNode, *ast.OutputNode, *ast.ParamNode:
+		case *ast.DeclarationNode:
+			if _, isCmd := n.Expr.(*ast.CommandNode); !isCmd {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// statementsDependencies returns for each statement the indexes of the previous statements
+// it must run after: those declaring the variables it references and those sharing with it
+// a literal value, as the ID of a same resource. Checks wait for every previous statement
+// and every next statement waits for them, since what follows expects the state checked.
+func statementsDependencies(statements []*ast.Statement) [][]int {
+	declaredAt := make(map[string]int)
+	usedAt := make(map[string]int)
+	lastCheck := -1
+	deps := make([][]int, len(statements))
+	for i, st := range statements {
+		var refs []string
+		var literals []string
+		cmd, _ := extractExpressionNode(st).(*ast.CommandNode)
+		switch n := st.Node.(type) {
+		case ast.WithRefs:
+			refs = n.GetRefs()
+		case *ast.DeclarationNode:
+			if withRefs, ok := n.Expr.(ast.WithRefs); ok {
+				refs = withRefs.GetRefs()
+			}
+		}
+		if cmd != nil {
+			for _, v := range cmd.ToDriverParams() {
+				literals = append(literals, paramStrings(v)...)
+			}
+			for _, param := range cmd.Params {
+				if withAlias, ok := param.(ast.WithAlias); ok {
+					for _, alias := range withAlias.GetAliases() {
+						literals = append(literals, "@"+alias)
+					}
+				}
+			}
+		}
+
+		depends := make(map[int]bool)
+		if cmd != nil && cmd.Action == "check" {
+			for j := 0; j < i; j++ {
+				depends[j] = true
+			}
+		} else if lastCheck >= 0 {
+			depends[lastCheck] = true
+		}
+		for _, ref := range refs {
+			if j, ok := declaredAt[ref]; ok {
+				depends[j] = true
+			}
+		}
+		for _, lit := range literals {
+			if j, ok := usedAt[lit]; ok {
+				depends[j] = true
+			}
+		}
+		for j := range depends {
+			deps[i] = append(deps[i], j)
+		}
+		sort.Ints(deps[i])
+
+		if decl, ok := st.Node.(*ast.DeclarationNode); ok {
+			declaredAt[decl.Ident] = i
+		}
+		for _, lit := range literals {
+			usedAt[lit] = i
+		}
+		if cmd != nil && cmd.Action == "check" {
+			lastCheck = i
+		}
+	}
+	return deps
+}
+
+type statementResult struct {
+	index int
+	stop  bool
+	err   error
+}
+
+const (
+	pendingStatement = iota
+	runningStatement
+	doneStatement
+)
+
+// runParallel runs concurrently, up to env.Parallelism at a time, the statements
+// whose dependencies are done. As with a sequential run, no more statements are
+// started once one failed. Logs and executed statements are kept in the source order.
+func runParallel(env *Env, statements []*ast.Statement, vars map[string]interface{}, current *Template) (bool, error) {
+	var clones []*ast.Statement
+	for _, st := range statements {
+		if _, isParam := st.Node.(*ast.ParamNode); !isParam {
+			clones = append(clones, st.Clone())
+		}
+	}
+	deps := statementsDependencies(clones)
+	status := make([]int, len(clones))
+	results := make(chan statementResult)
+
+	isReady := func(i int) bool {
+		for _, dep := range deps[i] {
+			if status[dep] != doneStatement {
+				return false
+			}
+		}
+		return true
+	}
+
+	var running, logged int
+	var stop bool
+	var firstErr error
+	for {
+		for i := 0; !stop && i < len(clones) && running < env.Parallelism; i++ {
+			if status[i] != pendingStatement || !isReady(i) {
+				continue
+			}
+			status[i] = runningStatement
+			running++
+			snapshot := make(map[string]interface{}, len(vars))
+			for k, v := range vars {
+				snapshot[k] = v
+			}
+			go func(i int, vars map[string]interface{}) {
+				stop, err := execStatement(env, clones[i], vars)
+				results <- statementResult{index: i, stop: stop, err: err}
+			}(i, snapshot)
+		}
+		if running == 0 {
+			break
+		}
+
+		res := <-results
+		running--
+		status[res.index] = doneStatement
+		if decl, ok := clones[res.index].Node.(*ast.DeclarationNode); ok {
+			vars[decl.Ident] = decl.Expr.Result()
+		}
+		if res.stop || res.err != nil {
+			stop = true
+			if firstErr == nil {
+				firstErr = res.err
+			}
+		}
+		for ; logged < len(clones) && status[logged] == doneStatement; logged++ {
+			logStatement(env, clones[logged])
+		}
+	}
+
+	for i, st := range clones {
+		if status[i] != doneStatement {
+			continue
+		}
+		if i >= logged {
+			logStatement(env, st)
+		}
+		current.Statements = append(current.Statements, st)
+	}
+	return stop, firstErr
+}
@@ -0,0 +1,156 @@
+package template
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/logger"
+)
+
+type concurrentCommand struct {
+	mu                  sync.Mutex
+	running, maxRunning int
+}
+
+func (c *concurrentCommand) Run(ctx, params map[string]interface{}) (interface{}, error) {
+	name := fmt.Sprint(params["name"])
+	if name == "fail" {
+		return nil, errors.New("failed")
+	}
+
+	c.mu.Lock()
+	c.running++
+	if c.running > c.maxRunning {
+		c.maxRunning = c.running
+	}
+	c.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	c.mu.Lock()
+	c.running--
+	c.mu.Unlock()
+
+	return "id-" + name, nil
+}
+
+func (c *concurrentCommand) DryRun(ctx, params map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func TestRunTemplateInParallel(t *testing.T) {
+	text := `vpc1 = create vpc name=vpc1
+vpc2 = create vpc name=vpc2
+sub1 = create subnet name=sub1 vpc=$vpc1
+sub2 = create subnet name=sub2 vpc=$vpc2
+create instance name=inst subnet=$sub1
+create bucket name=bucket
+output subnet = $sub2`
+
+	t.Run("independent statements", func(t *testing.T) {
+		tpl := MustParse(text)
+		cmd := &concurrentCommand{}
+		for _, node := range tpl.CommandNodesIterator() {
+			node.Command = cmd
+		}
+		var buff bytes.Buffer
+		env := NewEnv()
+		env.Log = logger.New("", 0, &buff)
+		env.Parallelism = 3
+
+		executed, err := tpl.Run(env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := cmd.maxRunning, 3; got != want {
+			t.Fatalf("max concurrent commands: got %d, want %d", got, want)
+		}
+		exp := `vpc1 = create vpc name=vpc1
+vpc2 = create vpc name=vpc2
+sub1 = create subnet name=sub1 vpc=id-vpc1
+sub2 = create subnet name=sub2 vpc=id-vpc2
+create instance name=inst subnet=id-sub1
+create bucket name=bucket
+output subnet = id-sub2`
+		if got, want := executed.String(), exp; got != want {
+			t.Fatalf("got\n%s\nwant\n%s", got, want)
+		}
+		var entities []string
+		for _, line := range strings.Split(strings.TrimSpace(buff.String()), "\n") {
+			fields := strings.Fields(line)
+			entities = append(entities, fields[len(fields)-2])
+		}
+		if got, want := strings.Join(entities, ","), "vpc,vpc,subnet,subnet,instance,bucket"; !strings.Contains(buff.String(), "OK") || got != want {
+			t.Fatalf("got logs\n%s", buff.String())
+		}
+		reverted, err := executed.Revert()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expRevert := `delete bucket name=id-bucket
+delete instance id=id-inst
+check instance id=id-inst state=terminated timeout=180
+delete subnet id=id-sub2
+delete subnet id=id-sub1
+delete vpc id=id-vpc2
+delete vpc id=id-vpc1`
+		if got, want := reverted.String(), expRevert; got != want {
+			t.Fatalf("got\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("stop on failure", func(t *testing.T) {
+		tpl := MustParse(strings.Replace(text, "name=vpc1", "name=fail", 1))
+		cmd := &concurrentCommand{}
+		for _, node := range tpl.CommandNodesIterator() {
+			node.Command = cmd
+		}
+		env := NewEnv()
+		env.Parallelism = 2
+
+		executed, err := tpl.Run(env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := `vpc1 = create vpc name=fail
+vpc2 = create vpc name=vpc2`
+		if got, want := executed.String(), exp; got != want {
+			t.Fatalf("got\n%s\nwant\n%s", got, want)
+		}
+		if !executed.HasErrors() {
+			t.Fatal("expected errors in executed template")
+		}
+	})
+}
+
+func TestStatementsDependencies(t *testing.T) {
+	tcases := []struct {
+		text string
+		exp  [][]int
+	}{
+		{
+			text: "vpc = create vpc name=vpc1\nsub = create subnet name=sub1 vpc=$vpc\ncreate bucket name=bucket",
+			exp:  [][]int{nil, {0}, nil},
+		},
+		{
+			text: "create instance name=web\ncreate bucket name=bucket\ncheck instance id=i-1 state=running timeout=180\nattach elasticip id=eip-1 instance=i-2\ncreate queue name=jobs",
+			exp:  [][]int{nil, nil, {0, 1}, {2}, {2}},
+		},
+		{
+			text: "create tag resource=i-1 key=Env value=prod\ncreate bucket name=bucket\ndelete instance id=i-1\nstart database id=@mydb\nstop database id=@mydb",
+			exp:  [][]int{nil, nil, {0}, nil, {3}},
+		},
+	}
+	for i, tcase := range tcases {
+		tpl := MustParse(tcase.text)
+		if got, want := statementsDependencies(tpl.Statements), tcase.exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %v, want %v", i+1, got, want)
+		}
+	}
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package template
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"regexp"
@@ -27,12 +26,24 @@ import (
 	"github.com/wallix/awless/template/internal/ast"
 )
 
-func Parse(text string) (tmpl *Template, err error) {
+func Parse(text string) (*Template, error) {
+	return parse(text, "")
+}
+
+// ParseFile parses the text of a template read from the given file (or url),
+// for its path to be given in the positions of the errors of the template
+func ParseFile(file, text string) (*Template, error) {
+	return parse(text, file)
+}
+
+func parse(text, file string) (tmpl *Template, err error) {
+	p := &ast.Peg{AST: &ast.AST{}, Buffer: string(text)}
+
 	defer func() { // as peg lib does not allow errors in Execute, we use panic to build the AST
 		if rerr := recover(); rerr != nil {
 			switch rerr.(type) {
 			case error:
-				err = fmt.Errorf("template parsing: %s", rerr.(error))
+				err = locatedErr(p.ParsingPosition(), fmt.Errorf("template parsing: %s", rerr.(error)))
 			default:
 				panic(rerr)
 			}
@@ -45,11 +56,11 @@ func Parse(text string) (tmpl *Template, err error) {
 
 	tmpl = &Template{}
 
-	p := &ast.Peg{AST: &ast.AST{}, Buffer: string(text)}
 	p.Init()
+	p.SetSourceFile(file)
 
 	if err = p.Parse(); err != nil {
-		err = newParseError(text, err.Error())
+		err = newParseError(text, file, err.Error())
 		return
 	}
 	p.Execute()
@@ -93,40 +104,28 @@ func parseStatement(text string) (ast.Node, error) {
 
 type parseError struct {
 	origMsg          string
+	file             string
 	lines            []string
 	line, start, end int
 }
 
-func newParseError(templText, pegErrMsg string) (perr *parseError) {
+func newParseError(templText, file, pegErrMsg string) (perr *parseError) {
 	perr = buildParseError(pegErrMsg)
 	perr.origMsg = pegErrMsg
+	perr.file = file
 	perr.lines = strings.Split(templText, "\n")
 	return
 }
 
 func (pe *parseError) Error() string {
 	if pe.invalidIndexes() {
-		return pe.origMsg
-	}
-
-	var buff bytes.Buffer
-	buff.WriteString(fmt.Sprintf("error parsing template at line %d (char %d):\n", pe.line, pe.start))
-
-	for i, l := range pe.lines {
-		buff.WriteByte('\t')
-		if pe.line == i+1 {
-			buff.WriteString("-> ")
-			buff.WriteString(l[0:pe.start])
-		} else {
-			buff.WriteString("   ")
-			buff.WriteString(l)
-		}
-		if i < len(pe.lines)-1 {
-			buff.WriteByte('\n')
+		if pe.file != "" {
+			return fmt.Sprintf("%s: %s", pe.file, pe.origMsg)
 		}
+		return pe.origMsg
 	}
-
-	return buff.String()
+	src := ast.NewSource(pe.file, strings.Join(pe.lines, "\n"))
+	return (&ast.PositionError{Pos: ast.Position{Source: src, Line: pe.line, Column: pe.start}, Err: errors.New("invalid template syntax")}).Error()
 }
 
 func (pe *parseError) invalidIndexes() bool {
@@ -163,3 +162,12 @@ func buildParseError(s string) (perr *parseError) {
 
 	return
 }
+
+// Position returns the line (starting at 1) and the char (starting at 0)
+// where parsing failed, or a 0 line when unknown
+func (pe *parseError) Position() (line, char int) {
+	if pe.invalidIndexes() {
+		return 0, 0
+	}
+	return pe.line, pe.start
+}
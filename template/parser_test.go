@@ -56,6 +56,128 @@ func TestParseTemplatesWithList(t *testing.T) {
 	}
 }
 
+func TestParseTemplatesWithMap(t *testing.T) {
+	tcases := []struct {
+		text, expect string
+		params       map[string]interface{}
+	}{
+		{
+			text:   "create stack name=s tags={Env: prod, Team: \"core infra\"}",
+			expect: "create stack name=s tags={Env: prod, Team: 'core infra'}",
+			params: map[string]interface{}{"name": "s", "tags": map[string]interface{}{"Env": "prod", "Team": "core infra"}},
+		},
+		{
+			text:   "create stack name=s parameters={ 'aws:key':1,Subnet:$subnet , Vpc : {vpc.id} }",
+			expect: "create stack name=s parameters={'aws:key': 1, Subnet: $subnet, Vpc: {vpc.id}}",
+			params: map[string]interface{}{"name": "s", "parameters": map[string]interface{}{"aws:key": 1}},
+		},
+		{
+			text:   "create stack name=s tags={}",
+			expect: "create stack name=s tags={}",
+			params: map[string]interface{}{"name": "s"},
+		},
+		{
+			text:   "create stack name=s tags={secret: x}",
+			expect: "create stack name=s tags={secret: x}",
+			params: map[string]interface{}{"name": "s", "tags": map[string]interface{}{"secret": "x"}},
+		},
+		{
+			text:   "create stack name={name} tags={Name:{name}, Owner:@bob}",
+			expect: "create stack name={name} tags={Name: {name}, Owner: @bob}",
+			params: map[string]interface{}{},
+		},
+	}
+
+	for i, tcase := range tcases {
+		tpl, err := Parse(tcase.text)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := tpl.String(), tcase.expect; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+		if _, err := Parse(tpl.String()); err != nil {
+			t.Fatalf("%d: cannot parse printed template: %s", i+1, err)
+		}
+		if got, want := tpl.CommandNodesIterator()[0].ToDriverParams(), tcase.params; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %#v, want %#v", i+1, got, want)
+		}
+	}
+
+	if _, err := Parse("create stack name=s tags={Env: prod, Env: dev}"); err == nil || !strings.Contains(err.Error(), "duplicated key 'Env'") {
+		t.Fatalf("expected duplicated key error, got %v", err)
+	}
+	if tpl := MustParse("create database password={secret:db.password}"); tpl.String() != "create database password={secret:db.password}" {
+		t.Fatalf("expected a secret hole, got %s", tpl)
+	}
+}
+
+func TestParseHeredocs(t *testing.T) {
+	tcases := []struct {
+		text, expect string
+		params       map[string]interface{}
+	}{
+		{
+			text:   "create instance name=web userdata=<<EOF\n#!/bin/bash\n  echo EOFX > /tmp/out\nEOF type=t2.micro",
+			expect: "create instance name=web type=t2.micro userdata=<<EOF\n#!/bin/bash\n  echo EOFX > /tmp/out\nEOF",
+			params: map[string]interface{}{"name": "web", "type": "t2.micro", "userdata": ast.Heredoc("#!/bin/bash\n  echo EOFX > /tmp/out")},
+		},
+		{
+			text:   "create policy name=p document=<<JSON\n{\"Version\": \"2012-10-17\"}\n  JSON",
+			expect: "create policy document=<<EOF\n{\"Version\": \"2012-10-17\"}\nEOF name=p",
+			params: map[string]interface{}{"name": "p", "document": ast.Heredoc(`{"Version": "2012-10-17"}`)},
+		},
+		{
+			text:   "create instance userdata=<<END\nEOF\n\nEND",
+			expect: "create instance userdata=<<EOF2\nEOF\n\nEOF2",
+			params: map[string]interface{}{"userdata": ast.Heredoc("EOF\n")},
+		},
+		{
+			text:   "create instance userdata=<<EOF\nEOF",
+			expect: "create instance userdata=<<EOF\nEOF",
+			params: map[string]interface{}{"userdata": ast.Heredoc("")},
+		},
+		{
+			text:   "create instance name=<<EOF",
+			expect: "create instance name='<<EOF'",
+			params: map[string]interface{}{"name": "<<EOF"},
+		},
+	}
+
+	for i, tcase := range tcases {
+		tpl, err := Parse(tcase.text)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := tpl.String(), tcase.expect; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+		reparsed, err := Parse(tpl.String())
+		if err != nil {
+			t.Fatalf("%d: cannot parse printed template: %s", i+1, err)
+		}
+		if got, want := reparsed.CommandNodesIterator()[0].ToDriverParams(), tcase.params; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %#v, want %#v", i+1, got, want)
+		}
+	}
+
+	text := "script = <<EOF\necho hello\necho world\nEOF\nif {env} == prod {\n\tcreate instance name=web userdata=<<EOF\n#!/bin/bash\n\techo $HOME\nEOF\n}\ncreate vpc cidr=10.0.0.0/16"
+	tpl := MustParse(text)
+	if got, want := tpl.Statements[2].Line, 11; got != want {
+		t.Fatalf("got line %d, want %d", got, want)
+	}
+	expect := "script = <<EOF\necho hello\necho world\nEOF\nif {env} == prod {\n\tcreate instance name=web userdata=<<EOF\n#!/bin/bash\n\techo $HOME\n\tEOF\n}\ncreate vpc cidr=10.0.0.0/16"
+	if got := tpl.String(); got != expect {
+		t.Fatalf("got\n%s\nwant\n%s", got, expect)
+	}
+	if got := MustParse(tpl.String()).String(); got != expect {
+		t.Fatalf("got\n%s\nwant\n%s", got, expect)
+	}
+	if got := MustParse(tpl.Format()).String(); got != expect {
+		t.Fatalf("got\n%s\nwant\n%s", got, expect)
+	}
+}
+
 func TestParseVariousTemplatesCorrectly(t *testing.T) {
 	tcases := []struct {
 		desc string
@@ -248,7 +370,7 @@ func TestWrapPegParseError(t *testing.T) {
 			t.Fatalf("got %d, want %d", got, want)
 		}
 
-		exp := "error parsing template at line 2 (char 23):\n\t   create subnet\n\t-> create instance type= w\n\t   create vpc"
+		exp := "2:23: invalid template syntax\n\tcreate instance type= wrong=\n\t                      ^"
 		if got, want := err.Error(), exp; got != want {
 			t.Fatalf("got\n\n%s\n\nwant\n\n%s\n", got, want)
 		}
@@ -256,7 +378,7 @@ func TestWrapPegParseError(t *testing.T) {
 
 	t.Run("Fallback on peg msg if cannot find contextual info", func(t *testing.T) {
 		orig := "rubbish"
-		err := newParseError("create vpc\ncreate vpc", orig)
+		err := newParseError("create vpc\ncreate vpc", "", orig)
 		if got, want := err.Error(), orig; got != want {
 			t.Fatalf("got\n\n%q\n\nwant\n\n%q\n", got, want)
 		}
@@ -264,7 +386,7 @@ func TestWrapPegParseError(t *testing.T) {
 
 	t.Run("Fallback on peg msg if out of bounds indexes info", func(t *testing.T) {
 		orig := "line 1 symbol 21 - line 1 symbol 22"
-		err := newParseError("create vpc\ncreate vpc", orig)
+		err := newParseError("create vpc\ncreate vpc", "", orig)
 		if got, want := err.Error(), orig; got != want {
 			t.Fatalf("got\n\n%q\n\nwant\n\n%q\n", got, want)
 		}
@@ -383,7 +505,7 @@ func TestTemplateParsing(t *testing.T) {
 		}
 	})
 
-	t.Run("Allow and ignore comments", func(t *testing.T) {
+	t.Run("Allow and keep comments", func(t *testing.T) {
 		tcases := []struct {
 			input    string
 			verifyFn func(tpl *Template) error
@@ -391,13 +513,16 @@ func TestTemplateParsing(t *testing.T) {
 			{
 				input: "create vpc\n#my comment\ncreate subnet",
 				verifyFn: func(tpl *Template) error {
-					if got, want := len(tpl.Statements), 2; got != want {
+					if got, want := len(tpl.Statements), 3; got != want {
 						t.Fatalf("got %d, want %d", got, want)
 					}
 					if err := isCommandNode(tpl.Statements[0].Node); err != nil {
 						t.Fatal(err)
 					}
-					if err := isCommandNode(tpl.Statements[1].Node); err != nil {
+					if got, want := tpl.Statements[1].Node, (&ast.CommentNode{Text: "#my comment"}); !reflect.DeepEqual(got, want) {
+						t.Fatalf("got %#v, want %#v", got, want)
+					}
+					if err := isCommandNode(tpl.Statements[2].Node); err != nil {
 						t.Fatal(err)
 					}
 					return nil
@@ -406,13 +531,16 @@ func TestTemplateParsing(t *testing.T) {
 			{
 				input: "create vpc \n//my comment\ncreate subnet",
 				verifyFn: func(tpl *Template) error {
-					if got, want := len(tpl.Statements), 2; got != want {
+					if got, want := len(tpl.Statements), 3; got != want {
 						t.Fatalf("got %d, want %d", got, want)
 					}
 					if err := isCommandNode(tpl.Statements[0].Node); err != nil {
 						t.Fatal(err)
 					}
-					if err := isCommandNode(tpl.Statements[1].Node); err != nil {
+					if got, want := tpl.Statements[1].Node, (&ast.CommentNode{Text: "//my comment"}); !reflect.DeepEqual(got, want) {
+						t.Fatalf("got %#v, want %#v", got, want)
+					}
+					if err := isCommandNode(tpl.Statements[2].Node); err != nil {
 						t.Fatal(err)
 					}
 					return nil
@@ -636,12 +764,18 @@ create subnet
 
 `,
 				verifyFn: func(s *Template) error {
-					if err := assertCommandNode(s.Statements[0].Node, "create", "vpc",
+					if got, want := len(s.Statements), 6; got != want {
+						return fmt.Errorf("got %d statements, want %d", got, want)
+					}
+					if err := assertCommandNode(s.Statements[2].Node, "create", "vpc",
 						make(map[string][]string), make(map[string]interface{}), make(map[string][]string), make(map[string][]string),
 					); err != nil {
 						return err
 					}
-					if err := assertCommandNode(s.Statements[1].Node, "create", "subnet",
+					if got, want := s.Statements[3].Node, (&ast.CommentNode{Text: "# inlined comment", Inline: true}); !reflect.DeepEqual(got, want) {
+						return fmt.Errorf("got %#v, want %#v", got, want)
+					}
+					if err := assertCommandNode(s.Statements[4].Node, "create", "subnet",
 						make(map[string][]string), make(map[string]interface{}), make(map[string][]string), make(map[string][]string),
 					); err != nil {
 						return err
@@ -763,6 +897,228 @@ mysecondvar = {var-hole}
 	})
 }
 
+func TestParseConditionalBlocks(t *testing.T) {
+	tcases := []struct {
+		text   string
+		expect string
+	}{
+		{
+			text:   "if {nat} {\ncreate natgateway subnet=sub-1234\n}",
+			expect: "if {nat} {\n\tcreate natgateway subnet=sub-1234\n}",
+		},
+		{
+			text:   "if $nat == true { create natgateway subnet=sub-1234 }",
+			expect: "if $nat == true {\n\tcreate natgateway subnet=sub-1234\n}",
+		},
+		{
+			text: `if {env} != prod {
+  create vpc cidr=10.0.0.0/16
+  # comments are allowed in blocks
+
+  sub = create subnet cidr=10.0.0.0/24
+} else {
+  create vpc cidr=10.1.0.0/16
+}
+create instance name=test`,
+			expect: "if {env} != prod {\n\tcreate vpc cidr=10.0.0.0/16\n\t# comments are allowed in blocks\n\tsub = create subnet cidr=10.0.0.0/24\n} else {\n\tcreate vpc cidr=10.1.0.0/16\n}\ncreate instance name=test",
+		},
+		{
+			text: `if {env} == prod {
+  create vpc cidr=10.0.0.0/16
+} else if {env} == 'staging' {
+  if {nat} {
+    create natgateway subnet=sub-1234
+  }
+} else {
+  create vpc cidr=10.2.0.0/16
+}`,
+			expect: "if {env} == prod {\n\tcreate vpc cidr=10.0.0.0/16\n} else if {env} == staging {\n\tif {nat} {\n\t\tcreate natgateway subnet=sub-1234\n\t}\n} else {\n\tcreate vpc cidr=10.2.0.0/16\n}",
+		},
+	}
+
+	for i, tcase := range tcases {
+		tpl, err := Parse(tcase.text)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := tpl.String(), tcase.expect; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+		reparsed, err := Parse(tpl.String())
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := reparsed.String(), tcase.expect; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+	}
+
+	t.Run("nested statements are iterated", func(t *testing.T) {
+		tpl := MustParse("if {nat} {\ncreate vpc\n} else {\nsub = create subnet\n}\ncreate instance")
+		var all []string
+		for _, cmd := range tpl.CommandNodesIterator() {
+			all = append(all, cmd.Entity)
+		}
+		if got, want := all, []string{"vpc", "subnet", "instance"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := len(tpl.declarationNodesIterator()), 1; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+	})
+}
+
+func TestParseLoopBlocks(t *testing.T) {
+	tcases := []struct {
+		text   string
+		expect string
+	}{
+		{
+			text:   "for az in [eu-west-1a, eu-west-1b] {\nsub = create subnet availabilityzone=$az\n}",
+			expect: "for az in [eu-west-1a,eu-west-1b] {\n\tsub = create subnet availabilityzone=$az\n}",
+		},
+		{
+			text:   "for user in {users} { attach policy arn=arn:aws:iam::aws:policy/ReadOnlyAccess user=$user }",
+			expect: "for user in {users} {\n\tattach policy arn=arn:aws:iam::aws:policy/ReadOnlyAccess user=$user\n}",
+		},
+		{
+			text: `for az in $zones {
+  for name in [a, b] {
+    if $az == eu-west-1a {
+      create subnet availabilityzone=$az name=$name
+    }
+  }
+}`,
+			expect: "for az in $zones {\n\tfor name in [a,b] {\n\t\tif $az == eu-west-1a {\n\t\t\tcreate subnet availabilityzone=$az name=$name\n\t\t}\n\t}\n}",
+		},
+	}
+
+	for i, tcase := range tcases {
+		tpl, err := Parse(tcase.text)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := tpl.String(), tcase.expect; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+		reparsed, err := Parse(tpl.String())
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := reparsed.String(), tcase.expect; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+	}
+}
+
+func TestParseIncludes(t *testing.T) {
+	tcases := []struct {
+		text   string
+		expect string
+	}{
+		{text: "include repo:create_vpc", expect: "include repo:create_vpc"},
+		{text: "include './lib/my vpc.aws'", expect: "include './lib/my vpc.aws'"},
+		{text: `net = include "lib/vpc.aws" vpc.cidr=10.0.0.0/16 name=$name`, expect: "net = include lib/vpc.aws name=$name vpc.cidr=10.0.0.0/16"},
+		{text: "for az in [a, b] {\n  include lib/subnet.aws az=$az\n}", expect: "for az in [a,b] {\n\tinclude lib/subnet.aws az=$az\n}"},
+	}
+
+	for i, tcase := range tcases {
+		tpl, err := Parse(tcase.text)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := tpl.String(), tcase.expect; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+	}
+}
+
+func TestParseParamDeclarations(t *testing.T) {
+	tcases := []struct {
+		text   string
+		expect string
+	}{
+		{text: "param name: string", expect: "param name: string"},
+		{text: `param vpc.cidr: cidr = 10.0.0.0/16 "CIDR of the VPC"`, expect: `param vpc.cidr: cidr = 10.0.0.0/16 "CIDR of the VPC"`},
+		{text: `param instance.type :string in [t2.micro, t2.small]="Type"`, expect: `param instance.type: string in [t2.micro,t2.small] = Type`},
+		{text: `param instance.type: string in t2.micro, t2.small = t2.micro "Type"`, expect: `param instance.type: string in [t2.micro,t2.small] = t2.micro "Type"`},
+		{text: "param count: int = 2\ncreate instance count={count}", expect: "param count: int = 2\ncreate instance count={count}"},
+	}
+
+	for i, tcase := range tcases {
+		tpl, err := Parse(tcase.text)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := tpl.String(), tcase.expect; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+	}
+	tpl := MustParse(`param instance.type: string in [t2.micro, t2.small] = t2.micro "Type of the instance"`)
+	expected := []*ParamDeclaration{{Name: "instance.type", Type: "string", Default: "t2.micro", Description: "Type of the instance", AllowedValues: []string{"t2.micro", "t2.small"}}}
+	if got, want := tpl.ParamDeclarations(), expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got[0], want[0])
+	}
+}
+
+func TestParseOutputs(t *testing.T) {
+	tcases := []struct {
+		text   string
+		expect string
+	}{
+		{text: "output vpc.id = $vpc", expect: "output vpc.id = $vpc"},
+		{text: "output subnets=[$sub1, $sub2]", expect: "output subnets = [$sub1,$sub2]"},
+		{text: "output name = {instance.name}", expect: "output name = {instance.name}"},
+		{text: "output = create vpc", expect: "output = create vpc"},
+	}
+
+	for i, tcase := range tcases {
+		tpl, err := Parse(tcase.text)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := tpl.String(), tcase.expect; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+	}
+}
+
+func TestParseFunctions(t *testing.T) {
+	tcases := []struct {
+		text   string
+		expect string
+		expErr string
+	}{
+		{text: "create subnet cidr=cidrsubnet({vpc.cidr}, 8, 1)", expect: "create subnet cidr=cidrsubnet({vpc.cidr}, 8, 1)"},
+		{text: "name = lower( upper($env) )", expect: "name = lower(upper($env))"},
+		{text: "create subnet name=join([{env}, $name, subnet], '-')", expect: "create subnet name=join([{env},$name,subnet], -)"},
+		{text: "create instance userdata=base64(file('./user data.sh'))", expect: "create instance userdata=base64(file('./user data.sh'))"},
+		{text: "create loadbalancer subnets=[lower($sub1), $sub2]", expect: "create loadbalancer subnets=[lower($sub1),$sub2]"},
+		{text: "create tag key=Date value=now()", expect: "create tag key=Date value=now()"},
+		{text: "if lower({env}) == prod {\ncreate vpc\n}", expect: "if lower({env}) == prod {\n\tcreate vpc\n}"},
+		{text: "create vpc name=unknown(x)", expErr: "unknown function 'unknown'"},
+	}
+
+	for i, tcase := range tcases {
+		tpl, err := Parse(tcase.text)
+		if tcase.expErr != "" {
+			if err == nil {
+				t.Fatalf("%d: expected error, got none", i+1)
+			}
+			if got, want := err.Error(), tcase.expErr; !strings.Contains(got, want) {
+				t.Fatalf("%d: got %s, want %s", i+1, got, want)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := tpl.String(), tcase.expect; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+	}
+}
+
 func TestFixedFuzzingCrashedOutputs(t *testing.T) {
 	tcases := []struct {
 		text      string
@@ -1019,3 +1375,59 @@ func isValueNode(n ast.Node) error {
 	}
 	return nil
 }
+
+func TestFormatTemplate(t *testing.T) {
+	tcases := []struct {
+		text, expect string
+	}{
+		{
+			text:   "create   vpc  name=\"my-vpc\"   cidr=10.0.0.0/16",
+			expect: "create vpc cidr=10.0.0.0/16 name=my-vpc\n",
+		},
+		{
+			text:   "create instance name=\"my instance\" count='1'",
+			expect: "create instance count='1' name='my instance'\n",
+		},
+		{
+			text: `# header
+   
+
+vpc=create vpc cidr=10.0.0.0/16 // the vpc
+mysubnet  =  create subnet vpc=$vpc cidr=10.0.1.0/24
+net = include ./net.aws cidr=10.0.0.0/16
+
+inst = create instance subnet=$mysubnet
+`,
+			expect: "# header\n\nvpc      = create vpc cidr=10.0.0.0/16 // the vpc\nmysubnet = create subnet cidr=10.0.1.0/24 vpc=$vpc\nnet      = include ./net.aws cidr=10.0.0.0/16\n\ninst = create instance subnet=$mysubnet\n",
+		},
+		{
+			text: `if {env} == prod {
+  a = create vpc cidr=10.0.0.0/16
+  abc = create vpc cidr=10.1.0.0/16
+
+  # instances
+  for n in [one, two] { create instance name=$n }
+} else if {env} == staging {
+create vpc cidr=10.2.0.0/16
+}  # end`,
+			expect: "if {env} == prod {\n\ta   = create vpc cidr=10.0.0.0/16\n\tabc = create vpc cidr=10.1.0.0/16\n\n\t# instances\n\tfor n in [one,two] {\n\t\tcreate instance name=$n\n\t}\n} else if {env} == staging {\n\tcreate vpc cidr=10.2.0.0/16\n} # end\n",
+		},
+	}
+
+	for i, tcase := range tcases {
+		tpl, err := Parse(tcase.text)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := tpl.Format(), tcase.expect; got != want {
+			t.Fatalf("%d: got\n%q\nwant\n%q", i+1, got, want)
+		}
+		reparsed, err := Parse(tpl.Format())
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := reparsed.Format(), tcase.expect; got != want {
+			t.Fatalf("%d: formatting is not idempotent, got\n%q\nwant\n%q", i+1, got, want)
+		}
+	}
+}
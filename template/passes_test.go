@@ -398,6 +398,312 @@ func TestInlineVariableWithValue(t *testing.T) {
 	}
 }
 
+func TestExpandBlocksPass(t *testing.T) {
+	tcases := []struct {
+		tpl                 string
+		fillers             map[string]interface{}
+		missing             map[string]interface{}
+		expTpl              string
+		expProcessedFillers map[string]interface{}
+		expErr              string
+	}{
+		{
+			tpl:                 "if {nat} {\ncreate natgateway\n}\ncreate vpc",
+			fillers:             map[string]interface{}{"nat": "true"},
+			expTpl:              "create natgateway\ncreate vpc",
+			expProcessedFillers: map[string]interface{}{"nat": "true"},
+		},
+		{
+			tpl:                 "if {nat} {\ncreate natgateway\n}\ncreate vpc",
+			fillers:             map[string]interface{}{"nat": "false"},
+			expTpl:              "create vpc",
+			expProcessedFillers: map[string]interface{}{"nat": "false"},
+		},
+		{
+			tpl:                 "env = {env}\nif $env == prod {\ncreate vpc cidr=10.0.0.0/16\n} else {\ncreate vpc cidr={vpc.cidr}\n}",
+			fillers:             map[string]interface{}{"env": "dev"},
+			expTpl:              "env = {env}\ncreate vpc cidr={vpc.cidr}",
+			expProcessedFillers: map[string]interface{}{"env": "dev"},
+		},
+		{
+			tpl:                 "if {env} == prod {\ncreate vpc\n} else if {env} != dev {\ncreate subnet\nif {count} == 2 {\ncreate instance\n}\n} else {\ncreate vpc\n}",
+			missing:             map[string]interface{}{"env": "staging", "count": 2},
+			expTpl:              "create subnet\ncreate instance",
+			expProcessedFillers: map[string]interface{}{"env": "staging", "count": 2},
+		},
+		{
+			tpl:                 "vpc = create vpc\nfor az in [eu-west-1a, {other.az}] {\nsub = create subnet vpc=$vpc availabilityzone=$az\nupdate subnet id=$sub public=true\n}",
+			expTpl:              "vpc = create vpc\nsub_1 = create subnet availabilityzone=eu-west-1a vpc=$vpc\nupdate subnet id=$sub_1 public=true\nsub_2 = create subnet availabilityzone={other.az} vpc=$vpc\nupdate subnet id=$sub_2 public=true",
+			expProcessedFillers: map[string]interface{}{},
+		},
+		{
+			tpl:                 "users = {users}\nfor user in $users {\nfor group in [admins, devs] {\nif $group != devs {\nattach user name=$user group=$group\n}\n}\n}",
+			fillers:             map[string]interface{}{"users": []interface{}{"john", "jane"}},
+			expTpl:              "users = {users}\nattach user group=admins name=john\nattach user group=admins name=jane",
+			expProcessedFillers: map[string]interface{}{"users": []interface{}{"john", "jane"}},
+		},
+		{
+			tpl:                 "for az in {azs} {\ncreate subnet availabilityzone=$az\n}",
+			missing:             map[string]interface{}{"azs": "eu-west-1a"},
+			expTpl:              "create subnet availabilityzone=eu-west-1a",
+			expProcessedFillers: map[string]interface{}{"azs": "eu-west-1a"},
+		},
+		{
+			tpl:    "sub = create subnet\nif $sub {\ncreate vpc\n}",
+			expErr: "'$sub' is not a value known at compile time",
+		},
+		{
+			tpl:    "for az in $undefined {\ncreate subnet availabilityzone=$az\n}",
+			expErr: "'$undefined' is not a value known at compile time",
+		},
+		{
+			tpl:     "if {nat} {\ncreate natgateway\n}",
+			fillers: map[string]interface{}{"nat": "maybe"},
+			expErr:  "cannot evaluate 'maybe' as a boolean",
+		},
+		{
+			tpl:                 "param nat: bool = false\nif {nat} {\ncreate natgateway\n}\ncreate vpc",
+			expTpl:              "create vpc",
+			expProcessedFillers: map[string]interface{}{"nat": "false"},
+		},
+		{
+			tpl:     "param vpc.cidr: cidr = 10.0.0.0/16\ncreate vpc cidr={vpc.cidr}",
+			fillers: map[string]interface{}{"vpc.cidr": "10.0.0.300/16"},
+			expErr:  "param vpc.cidr: invalid value for {vpc.cidr}: expected a cidr, got '10.0.0.300/16'",
+		},
+		{
+			tpl:    "param count: int = many\ncreate instance count={count}",
+			expErr: "param count: invalid default value: expected an int, got 'many'",
+		},
+		{
+			tpl:    "param name: text\ncreate instance name={name}",
+			expErr: "param name: unknown type 'text'",
+		},
+	}
+
+	for i, tcase := range tcases {
+		env := NewEnv()
+		env.AddFillers(tcase.fillers)
+		if tcase.missing != nil {
+			env.MissingHolesFunc = func(hole string, paramPaths []string) interface{} {
+				return tcase.missing[hole]
+			}
+		}
+		expanded, _, err := expandBlocksPass(MustParse(tcase.tpl), env)
+		if tcase.expErr != "" {
+			if err == nil {
+				t.Fatalf("%d: expected error, got nil", i+1)
+			}
+			if got, want := err.Error(), tcase.expErr; !strings.Contains(got, want) {
+				t.Fatalf("%d: got %s, want %s", i+1, got, want)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := expanded.String(), tcase.expTpl; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+		if got, want := env.GetProcessedFillers(), tcase.expProcessedFillers; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+func TestDeclaredParamsPass(t *testing.T) {
+	text := `param instance.type: string in [t2.micro, t2.small] = t2.micro "Type of the instances"
+param instance.count: int "Number of instances"
+param vpc.cidr: cidr = 10.0.0.0/16
+create vpc cidr={vpc.cidr}
+create instance type={instance.type} count={instance.count}`
+
+	t.Run("defaults and valid values", func(t *testing.T) {
+		env := NewEnv()
+		env.AddFillers(map[string]interface{}{"vpc.cidr": "172.16.0.0/16"})
+		env.MissingHolesFunc = func(hole string, paramPaths []string) interface{} { return 3 }
+		compiled, _, err := newMultiPass(expandBlocksPass, resolveHolesPass, resolveMissingHolesPass).compile(MustParse(text), env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := compiled.String(), "create vpc cidr=172.16.0.0/16\ncreate instance count=3 type=t2.micro"; got != want {
+			t.Fatalf("got\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("invalid missing value", func(t *testing.T) {
+		env := NewEnv()
+		env.MissingHolesFunc = func(hole string, paramPaths []string) interface{} { return "three" }
+		_, _, err := newMultiPass(expandBlocksPass, resolveHolesPass, resolveMissingHolesPass).compile(MustParse(text), env)
+		if err == nil {
+			t.Fatal("expected error got none")
+		}
+		if got, want := err.Error(), "5:44: invalid value for {instance.count}: expected an int, got 'three'\n\tcreate instance type={instance.type} count={instance.count}\n\t                                           ^"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("value not allowed", func(t *testing.T) {
+		env := NewEnv()
+		env.AddFillers(map[string]interface{}{"instance.type": "m4.large", "instance.count": 1})
+		_, _, err := expandBlocksPass(MustParse(text), env)
+		if err == nil {
+			t.Fatal("expected error got none")
+		}
+		if got, want := err.Error(), "1:1: param instance.type: invalid value for {instance.type}: 'm4.large' is not one of t2.micro, t2.small\n\t"+strings.SplitN(text, "\n", 2)[0]+"\n\t^"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+}
+
+func TestEvaluateFunctionsPass(t *testing.T) {
+	env := NewEnv()
+	env.AddFillers(map[string]interface{}{"vpc.cidr": "10.0.0.0/16"})
+	tpl := MustParse("sub = create subnet cidr=cidrsubnet({vpc.cidr}, 8, 3) vpc=$vpc name=join([sub, lower($sub)], -)")
+	compiled, _, err := newMultiPass(resolveHolesPass, evaluateFunctionsPass).compile(tpl, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := compiled.String(), "sub = create subnet cidr=10.0.3.0/24 name=join([sub,lower($sub)], -) vpc=$vpc"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	_, _, err = newMultiPass(resolveHolesPass, evaluateFunctionsPass).compile(MustParse("create subnet cidr=cidrsubnet({vpc.cidr}, 8, 256)"), env)
+	if err == nil {
+		t.Fatal("expected error got none")
+	}
+	if got, want := err.Error(), "1:20: cidrsubnet(10.0.0.0/16, 8, 256): index 256 out of range for 8 new bits\n\tcreate subnet cidr=cidrsubnet({vpc.cidr}, 8, 256)\n\t                   ^"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+type strictParamsCommand struct{ mockCommandWithResult }
+
+func (c *strictParamsCommand) ValidateParams(params []string) ([]string, error) {
+	for _, p := range params {
+		if !contains([]string{"cidr", "name", "vpc"}, p) {
+			return nil, fmt.Errorf("unexpected '%s' param", p)
+		}
+	}
+	return nil, nil
+}
+
+func TestCompileErrorsArePositioned(t *testing.T) {
+	env := NewEnv()
+	env.Lookuper = func(...string) interface{} { return &strictParamsCommand{} }
+	env.IncludeFunc = func(path, from string) (string, string, error) {
+		return "create subnet\nname = $unknown", "lib/" + path, nil
+	}
+
+	tcases := []struct {
+		tpl    string
+		passes []compileFunc
+		expErr string
+	}{
+		{
+			tpl:    "vpc = create vpc cidr=10.0.0.0/16\n\tcreate subnet vpc=$vpc foo=bar",
+			passes: []compileFunc{validateCommandsParamsPass},
+			expErr: "main.aws:2:29: create subnet: unexpected 'foo' param\n\t\tcreate subnet vpc=$vpc foo=bar\n\t\t                           ^",
+		},
+		{
+			tpl:    "create vpc cidr=10.0.0.0/16\ncreate subnet name=[a, $sub]",
+			passes: []compileFunc{checkInvalidReferenceDeclarationsPass},
+			expErr: "main.aws:2:20: using reference '$sub' but 'sub' is undefined in template\n\tcreate subnet name=[a, $sub]\n\t                   ^",
+		},
+		{
+			tpl:    "create vpc cidr={vpc.cidr}\ncreate subnet name={name} cidr=10.0.0.0/24",
+			passes: []compileFunc{resolveHolesPass, failOnUnresolvedHolesPass},
+			expErr: "main.aws:2:20: template contains unresolved holes: [name vpc.cidr]\n\tcreate subnet name={name} cidr=10.0.0.0/24\n\t                   ^",
+		},
+		{
+			tpl:    "create vpc cidr=10.0.0.0/16\ncreate subnet vpc=@my-vpc",
+			passes: []compileFunc{resolveAliasPass, failOnUnresolvedAliasPass},
+			expErr: "main.aws:2:19: template contains unresolved alias: [my-vpc]\n\tcreate subnet vpc=@my-vpc\n\t                  ^",
+		},
+		{
+			tpl:    "create vpc cidr=10.0.0.0/16\ninclude subnet.aws",
+			passes: []compileFunc{expandBlocksPass, checkInvalidReferenceDeclarationsPass},
+			expErr: "lib/subnet.aws:2:8: using reference '$unknown' but 'unknown' is undefined in template\n\tname = $unknown\n\t       ^",
+		},
+	}
+
+	for i, tcase := range tcases {
+		tpl, err := ParseFile("main.aws", tcase.tpl)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		_, _, err = newMultiPass(tcase.passes...).compile(tpl, env)
+		if err == nil {
+			t.Fatalf("%d: expected error, got none", i+1)
+		}
+		if got, want := err.Error(), tcase.expErr; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+	}
+}
+
+func TestIncludeTemplatesPass(t *testing.T) {
+	library := map[string]string{
+		"lib/vpc.aws": `vpc = create vpc cidr={vpc.cidr}
+public = create subnet vpc=$vpc cidr={public.cidr}
+update subnet id=$public public=true`,
+		"lib/bastion.aws": `keypair = {keypair}
+create instance subnet={subnet} keypair=$keypair`,
+		"lib/loop.aws": "include lib/loop.aws",
+		"lib/stack.aws": `net = include lib/vpc.aws
+include lib/bastion.aws subnet=$net.public`,
+	}
+	env := NewEnv()
+	env.IncludeFunc = func(path, from string) (string, string, error) {
+		text, ok := library[path]
+		if !ok {
+			return "", "", fmt.Errorf("not found")
+		}
+		return text, path, nil
+	}
+
+	tcases := []struct {
+		tpl, expTpl, expErr string
+	}{
+		{
+			tpl: "net = include lib/vpc.aws vpc.cidr=10.0.0.0/16 public.cidr={cidr}\ncreate instance subnet=$net.public",
+			expTpl: `net.vpc = create vpc cidr=10.0.0.0/16
+net.public = create subnet cidr={cidr} vpc=$net.vpc
+update subnet id=$net.public public=true
+create instance subnet=$net.public`,
+		},
+		{
+			tpl: "include lib/stack.aws keypair=my-key",
+			expTpl: `include1.net.vpc = create vpc cidr={vpc.cidr}
+include1.net.public = create subnet cidr={public.cidr} vpc=$include1.net.vpc
+update subnet id=$include1.net.public public=true
+include2.keypair = {keypair}
+create instance keypair=$include2.keypair subnet=$include1.net.public`,
+		},
+		{tpl: "include lib/unknown.aws", expErr: "include lib/unknown.aws: not found"},
+		{tpl: "include lib/loop.aws", expErr: "cycle detected: lib/loop.aws -> lib/loop.aws"},
+	}
+
+	for i, tcase := range tcases {
+		expanded, _, err := expandBlocksPass(MustParse(tcase.tpl), env)
+		if tcase.expErr != "" {
+			if err == nil {
+				t.Fatalf("%d: expected error, got nil", i+1)
+			}
+			if got, want := err.Error(), tcase.expErr; !strings.Contains(got, want) {
+				t.Fatalf("%d: got %s, want %s", i+1, got, want)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := expanded.String(), tcase.expTpl; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+	}
+}
+
 func TestDefaultEnvWithNilFunc(t *testing.T) {
 	text := "create instance name={instance.name} subnet=@mysubnet"
 	env := NewEnv()
@@ -0,0 +1,116 @@
+package template
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+// Plan is a compiled and dry run template, to be reviewed before being applied as is
+type Plan struct {
+	Path       string                 `json:"path,omitempty"`
+	Locale     string                 `json:"locale"`
+	Profile    string                 `json:"profile,omitempty"`
+	Source     string                 `json:"source"`
+	SourceHash string                 `json:"sourceHash"`
+	Fillers    map[string]interface{} `json:"fillers,omitempty"`
+	Compiled   string                 `json:"compiled"`
+	Statements []*PlanStatement       `json:"statements"`
+}
+
+// PlanStatement describes a command of a plan. DependsOn are the indexes
+// of the statements declaring the variables the command references.
+type PlanStatement struct {
+	Index        int               `json:"index"`
+	Line         string            `json:"line"`
+	Declaration  string            `json:"declaration,omitempty"`
+	Action       string            `json:"action"`
+	Entity       string            `json:"entity"`
+	Params       map[string]string `json:"params"`
+	DependsOn    []int             `json:"dependsOn,omitempty"`
+	Revertable   bool              `json:"revertable"`
+	DryRunResult string            `json:"dryRunResult,omitempty"`
+	DryRunError  string            `json:"dryRunError,omitempty"`
+}
+
+// planCompileMode only binds the commands of an already compiled plan,
+// its params being resolved and converted when it was planned,
+// except for secret holes which are never recorded in a plan
+var planCompileMode = []compileFunc{
+	verifyCommandsDefinedPass,
+	resolveHolesPass,
+	resolveMissingHolesPass,
+	failOnUnresolvedHolesPass,
+	validateCommandsPass,
+	injectCommandsPass,
+}
+
+// HashSource returns the hash of the source of a template, used to check
+// a plan is applied against the template it was made from
+func HashSource(source string) string {
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:])
+}
+
+func newPlan(tplExec *TemplateExecution, dryRun *Template) *Plan {
+	plan := &Plan{
+		Path:       tplExec.Path,
+		Locale:     tplExec.Locale,
+		Profile:    tplExec.Profile,
+		Source:     tplExec.Source,
+		SourceHash: HashSource(tplExec.Source),
+		Fillers:    tplExec.Fillers,
+		Compiled:   tplExec.Template.String(),
+		Statements: []*PlanStatement{},
+	}
+
+	var dryRunCmds []*ast.CommandNode
+	if dryRun != nil {
+		dryRunCmds = dryRun.CommandNodesIterator()
+	}
+	deps := statementsDependencies(tplExec.Statements)
+	for i, st := range tplExec.Statements {
+		cmd, ok := extractExpressionNode(st).(*ast.CommandNode)
+		if !ok {
+			continue
+		}
+		planSt := &PlanStatement{
+			Index:       i,
+			Line:        cmd.String(),
+			Declaration: declaredIdentifier(st),
+			Action:      cmd.Action,
+			Entity:      cmd.Entity,
+			Params:      make(map[string]string),
+			DependsOn:   deps[i],
+			Revertable:  isRevertible(cmd),
+		}
+		for k, v := range cmd.Params {
+			planSt.Params[k] = v.String()
+		}
+		if n := len(plan.Statements); n < len(dryRunCmds) {
+			planSt.Revertable = isRevertible(dryRunCmds[n]) // revertibility of creations depends on their result
+			if res := dryRunCmds[n].CmdResult; res != nil {
+				planSt.DryRunResult = fmt.Sprint(res)
+			}
+			if err := dryRunCmds[n].CmdErr; err != nil {
+				planSt.DryRunError = err.Error()
+			}
+		}
+		plan.Statements = append(plan.Statements, planSt)
+	}
+	return plan
+}
+
+// compile returns the template of the plan, checking it has been made from the given source
+func (p *Plan) compile(source *Template, env *Env) (*Template, *Env, error) {
+	if hash := HashSource(source.String()); hash != p.SourceHash {
+		return nil, env, fmt.Errorf("plan: source template has changed since planned (hash %s, planned %s)", hash, p.SourceHash)
+	}
+	tpl, err := Parse(p.Compiled)
+	if err != nil {
+		return nil, env, fmt.Errorf("plan: %s", err)
+	}
+	return Compile(tpl, env, planCompileMode)
+}
@@ -0,0 +1,97 @@
+package template
+
+import (
+	"sort"
+
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+// locatedErr locates an error at the given position, when known and when not already located
+func locatedErr(pos ast.Position, err error) error {
+	if err == nil || !pos.IsValid() || isLocated(err) {
+		return err
+	}
+	return &ast.PositionError{Pos: pos, Err: err}
+}
+
+func isLocated(err error) bool {
+	switch e := err.(type) {
+	case *ast.PositionError:
+		return true
+	case *parseError:
+		return !e.invalidIndexes()
+	}
+	return false
+}
+
+// statementOf returns the statement of an expression node
+func (s *Template) statementOf(node ast.Node) *ast.Statement {
+	for _, st := range s.flatStatements() {
+		if st.Node == node {
+			return st
+		}
+		if decl, ok := st.Node.(*ast.DeclarationNode); ok && decl.Expr == node {
+			return st
+		}
+	}
+	return nil
+}
+
+// positionOf returns the position of a node, invalid when unknown
+func (s *Template) positionOf(node ast.Node) ast.Position {
+	if st := s.statementOf(node); st != nil {
+		return st.Position()
+	}
+	return ast.Position{}
+}
+
+// valuePositionOf returns the position of the first value of a node matching the given func,
+// or the position of the node when the value has no known position
+func (s *Template) valuePositionOf(node ast.Node, match func(ast.CompositeValue) bool) ast.Position {
+	st := s.statementOf(node)
+	if st == nil {
+		return ast.Position{}
+	}
+	var values []ast.CompositeValue
+	switch n := extractExpressionNode(st).(type) {
+	case *ast.CommandNode:
+		var keys []string
+		for k := range n.Params {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			values = append(values, n.Params[k])
+		}
+	case *ast.ValueNode:
+		values = append(values, n.Value)
+	}
+	for _, v := range values {
+		if v != nil && match(v) {
+			if pos := ast.PositionOf(v); pos.IsValid() {
+				return pos
+			}
+			break
+		}
+	}
+	return st.Position()
+}
+
+// holePosition returns the position of the first value with the given hole, invalid when unknown
+func (s *Template) holePosition(hole string) ast.Position {
+	for _, n := range s.expressionNodesIterator() {
+		if h, ok := n.(ast.WithHoles); ok {
+			if _, has := h.GetHoles()[hole]; has {
+				return s.valuePositionOf(n, func(v ast.CompositeValue) bool {
+					vh, ok := v.(ast.WithHoles)
+					if !ok {
+						return false
+					}
+					_, has := vh.GetHoles()[hole]
+					return has
+				})
+			}
+		}
+	}
+	return ast.Position{}
+}
@@ -0,0 +1,78 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+// withoutSecretFillers removes the fillers of the secret holes of a resumed template, as well as
+// the redacted values persisted in place of secrets, for them to be asked again rather than reused
+func withoutSecretFillers(t *Template, fillers []map[string]interface{}) (kept []map[string]interface{}) {
+	secrets := ast.SecretHoles(t.Statements)
+	for _, fills := range fillers {
+		filtered := make(map[string]interface{})
+		for k, v := range fills {
+			if secrets[k] || v == ast.RedactedValue {
+				continue
+			}
+			filtered[k] = v
+		}
+		kept = append(kept, filtered)
+	}
+	return
+}
+
+// succeededStatements returns the command statements of an execution
+// which succeeded before its first failure
+func succeededStatements(t *Template) (done []*ast.Statement) {
+	for _, st := range t.flatStatements() {
+		cmd, ok := extractExpressionNode(st).(*ast.CommandNode)
+		if !ok {
+			continue
+		}
+		if cmd.CmdErr != nil {
+			return
+		}
+		done = append(done, st)
+	}
+	return
+}
+
+// resumeStatement skips a statement already run by the resumed execution,
+// binding its declared variable to the stored result
+func resumeStatement(env *Env, st *ast.Statement, vars map[string]interface{}) (bool, error) {
+	if env.resumeIndex >= len(env.resumed) {
+		return false, nil
+	}
+	cmd, ok := extractExpressionNode(st).(*ast.CommandNode)
+	if !ok {
+		return false, nil
+	}
+	done := env.resumed[env.resumeIndex]
+	doneCmd := extractExpressionNode(done).(*ast.CommandNode)
+	if cmd.Action != doneCmd.Action || cmd.Entity != doneCmd.Entity || declaredIdentifier(st) != declaredIdentifier(done) {
+		return true, fmt.Errorf("cannot resume: expected statement '%s' to match executed '%s'", st, done)
+	}
+	env.resumeIndex++
+
+	if ident := declaredIdentifier(done); ident != "" {
+		vars[ident] = doneCmd.Result()
+	}
+	if !env.IsDryRun {
+		var res string
+		if doneCmd.CmdResult != nil {
+			res = " (" + color.New(color.FgCyan).Sprint(doneCmd.CmdResult) + ")"
+		}
+		env.Log.Infof("%s %s %s%s", color.New(color.FgYellow).Sprint("SKIPPED"), doneCmd.Action, doneCmd.Entity, res)
+	}
+	return true, nil
+}
+
+func declaredIdentifier(st *ast.Statement) string {
+	if decl, ok := st.Node.(*ast.DeclarationNode); ok {
+		return decl.Ident
+	}
+	return ""
+}
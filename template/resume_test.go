@@ -0,0 +1,118 @@
+package template
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/logger"
+)
+
+func TestResumeTemplateExecution(t *testing.T) {
+	var ran []string
+	lookuper := func(tokens ...string) interface{} {
+		return &rollbackCommand{action: tokens[0], ran: &ran}
+	}
+	run := func(t *testing.T, text string, resumed *Template) (*Template, error) {
+		env := NewEnv()
+		env.Lookuper = lookuper
+		env.Log = logger.DiscardLogger
+		if resumed != nil {
+			env.resumed = succeededStatements(resumed)
+		}
+		tpl, env, err := Compile(MustParse(text), env, NewRunnerCompileMode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tpl.Run(env)
+	}
+
+	failed, err := run(t, "vpc = create vpc name=vpc1\nsub = create subnet name=fail vpc=$vpc\ncreate instance name=inst subnet=$sub", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(&TemplateExecution{Template: failed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := &TemplateExecution{}
+	if err = json.Unmarshal(b, loaded); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := loaded.String(), "vpc = create vpc name=vpc1\nsub = create subnet name=fail vpc=id-vpc1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	t.Run("skip succeeded statements", func(t *testing.T) {
+		ran = nil
+		resumed, err := run(t, "vpc = create vpc name=vpc1\nsub = create subnet name=sub1 vpc=$vpc\ncreate instance name=inst subnet=$sub", loaded.Template)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := []string{"createsubnet map[name:sub1 vpc:id-vpc1]", "createinstance map[name:inst subnet:id-sub1]"}
+		if got, want := ran, exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := resumed.String(), "sub = create subnet name=sub1 vpc=id-vpc1\ncreate instance name=inst subnet=id-sub1"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("source not matching execution", func(t *testing.T) {
+		ran = nil
+		_, err := run(t, "myvpc = create vpc name=vpc1\ncreate subnet name=sub1 vpc=$myvpc", loaded.Template)
+		if err == nil {
+			t.Fatal("expected error got none")
+		}
+		if len(ran) > 0 {
+			t.Fatalf("expected no command run, got %v", ran)
+		}
+	})
+}
+
+func TestResumeAsksSecretHolesAgain(t *testing.T) {
+	var ran []string
+	lookuper := func(tokens ...string) interface{} {
+		return &rollbackCommand{action: tokens[0], ran: &ran}
+	}
+	env := NewEnv()
+	env.Lookuper = lookuper
+	env.Log = logger.DiscardLogger
+	env.AddFillers(map[string]interface{}{"db.password": "s3cret"})
+	tpl, env, err := Compile(MustParse("create database name=fail password={secret:db.password}"), env, NewRunnerCompileMode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed := &TemplateExecution{Fillers: env.GetProcessedFillers()}
+	if failed.Template, err = tpl.Run(env); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := failed.Fillers, map[string]interface{}{"db.password": "******"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	ran = nil
+	var asked []string
+	ru := &Runner{
+		Template:    MustParse("create database name=db password={secret:db.password}"),
+		Fillers:     []map[string]interface{}{failed.Fillers},
+		Resumed:     failed.Template,
+		CmdLookuper: lookuper,
+		Log:         logger.DiscardLogger,
+		MissingSecretHolesFunc: func(hole string, _ []string) interface{} {
+			asked = append(asked, hole)
+			return "n3wsecret"
+		},
+		BeforeRun: func(*TemplateExecution) (bool, error) { return true, nil },
+		AfterRun:  func(*TemplateExecution) error { return nil },
+	}
+	if err := ru.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := asked, []string{"db.password"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := ran, []string{"createdatabase map[name:db password:n3wsecret]"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
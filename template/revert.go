@@ -1,38 +1,47 @@
 package template
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/wallix/awless/template/internal/ast"
 )
 
+// revertActions are the actions reverting each action
+var revertActions = map[string]string{
+	"create": "delete",
+	"copy":   "delete",
+	"start":  "stop",
+	"stop":   "start",
+	"detach": "attach",
+	"attach": "detach",
+	"delete": "create",
+	"update": "update",
+}
+
 func (te *Template) Revert() (*Template, error) {
 	var lines []string
+	recreated := newRecreation()
 	cmdsReverseIterator := te.CommandNodesReverseIterator()
 	for i, cmd := range cmdsReverseIterator {
 		notLastCommand := (i != len(cmdsReverseIterator)-1)
+		if cmd.Action == "delete" && !revertsDeleteFromParams(cmd.Entity) && isRevertible(cmd) {
+			for _, snap := range cmd.CmdSnapshots {
+				recreate, err := recreated.statements(snap)
+				if err != nil {
+					return nil, err
+				}
+				lines = append(lines, recreate...)
+			}
+			continue
+		}
 		if isRevertible(cmd) {
-			var revertAction string
+			revertAction := revertActions[cmd.Action]
 			var params []string
 
-			switch cmd.Action {
-			case "create", "copy":
-				revertAction = "delete"
-			case "start":
-				revertAction = "stop"
-			case "stop":
-				revertAction = "start"
-			case "detach":
-				revertAction = "attach"
-			case "attach":
-				revertAction = "detach"
-			case "delete":
-				revertAction = "create"
-			case "update":
-				revertAction = "update"
-			}
-
 			switch cmd.Action {
 			case "attach":
 				switch cmd.Entity {
@@ -173,6 +182,17 @@ func (te *Template) Revert() (*Template, error) {
 						}
 						params = append(params, fmt.Sprintf("%s=%v", k, quoteParamIfNeeded(v)))
 					}
+				default:
+					for k, v := range cmd.Params {
+						if _, hasPrior := cmd.CmdPriorValues[k]; !hasPrior {
+							params = append(params, fmt.Sprintf("%s=%s", k, v))
+						}
+					}
+					for k, v := range cmd.CmdPriorValues {
+						if v != nil {
+							params = append(params, fmt.Sprintf("%s=%s", k, printPriorValue(v)))
+						}
+					}
 				}
 			}
 
@@ -226,6 +246,142 @@ func (te *Template) Revert() (*Template, error) {
 	return tpl, nil
 }
 
+// RevertSelected returns the template reverting only the selected commands, numbered from 1
+// in their execution order. Selections whose revert would break the dependencies between commands
+// are refused: reverting the creation of a resource referenced by a kept command,
+// or recreating a deleted resource which references another one kept deleted.
+func (te *Template) RevertSelected(selected map[int]bool) (*Template, error) {
+	cmds := te.CommandNodesIterator()
+	for num := range selected {
+		if num < 1 || num > len(cmds) {
+			return nil, fmt.Errorf("no statement %d: template has %d statements", num, len(cmds))
+		}
+	}
+
+	for i, cmd := range cmds {
+		if !isRevertible(cmd) {
+			continue
+		}
+		for j, other := range cmds {
+			if i == j || !isRevertible(other) {
+				continue
+			}
+			switch {
+			case (cmd.Action == "create" || cmd.Action == "copy") && selected[i+1] && !selected[j+1] && referencesAny(other, createdBy(cmd)):
+				return nil, fmt.Errorf("reverting statement %d (%s) requires reverting statement %d (%s), which references it", i+1, cmd, j+1, other)
+			case cmd.Action == "delete" && other.Action == "delete" && !selected[i+1] && selected[j+1] && recreationReferencesAny(other, snapshotIDs(cmd)):
+				return nil, fmt.Errorf("reverting statement %d (%s) requires reverting statement %d (%s), which it references", j+1, other, i+1, cmd)
+			}
+		}
+	}
+
+	subset := &Template{ID: te.ID, AST: &ast.AST{}}
+	var num int
+	for _, st := range te.flatStatements() {
+		var cmd *ast.CommandNode
+		switch n := st.Node.(type) {
+		case *ast.CommandNode:
+			cmd = n
+		case *ast.DeclarationNode:
+			cmd, _ = n.Expr.(*ast.CommandNode)
+		}
+		if cmd == nil {
+			continue
+		}
+		num++
+		if selected[num] {
+			subset.Statements = append(subset.Statements, st)
+		}
+	}
+	if !IsRevertible(subset) {
+		return nil, errors.New("none of the selected statements is revertible")
+	}
+	return subset.Revert()
+}
+
+func createdBy(cmd *ast.CommandNode) map[string]bool {
+	created := make(map[string]bool)
+	if v, ok := cmd.CmdResult.(string); ok && v != "" {
+		created[v] = true
+	}
+	return created
+}
+
+func snapshotIDs(cmd *ast.CommandNode) map[string]bool {
+	ids := make(map[string]bool)
+	for _, snap := range cmd.CmdSnapshots {
+		if snap.Recreate != "" {
+			ids[snap.ID] = true
+		}
+	}
+	return ids
+}
+
+func referencesAny(cmd *ast.CommandNode, values map[string]bool) bool {
+	for _, v := range cmd.ToDriverParams() {
+		for _, str := range paramStrings(v) {
+			if values[str] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func recreationReferencesAny(cmd *ast.CommandNode, values map[string]bool) bool {
+	for _, snap := range cmd.CmdSnapshots {
+		tpl, err := Parse(snap.Recreate)
+		if err != nil {
+			continue
+		}
+		for _, recreate := range tpl.CommandNodesIterator() {
+			if referencesAny(recreate, values) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func paramStrings(i interface{}) (strs []string) {
+	switch v := i.(type) {
+	case string:
+		strs = append(strs, v)
+	case []interface{}:
+		for _, e := range v {
+			strs = append(strs, paramStrings(e)...)
+		}
+	case []string:
+		strs = append(strs, v...)
+	}
+	return
+}
+
+// Unrevertible is a statement of a template which will not be revertible once run
+type Unrevertible struct {
+	Statement, Reason string
+}
+
+// RevertibilityReport returns, before a compiled template runs, its statements which will not be revertible.
+// It applies the cases of isRevertible to what the commands will produce: results extracted, values fetched
+// before updating and snapshots of recreatable entities. The reverting commands must also exist. Checks change
+// nothing, and some statements may still turn out unrevertible at run time, as when prior values cannot be fetched.
+func RevertibilityReport(t *Template, lookup LookupFunc, recreatable func(entity string) bool) (report []*Unrevertible) {
+	for _, cmd := range t.CommandNodesIterator() {
+		if cmd.Action == "check" {
+			continue
+		}
+		reason := unrevertibleReason(cmd, &expectedOutcome{cmd: cmd, recreatable: recreatable})
+		if reason == "" && (lookup == nil || lookup(revertActions[cmd.Action], cmd.Entity) == nil) {
+			reason = fmt.Sprintf("there is no %s %s command", revertActions[cmd.Action], cmd.Entity)
+		}
+		if reason != "" {
+			report = append(report, &Unrevertible{Statement: cmd.String(), Reason: reason})
+		}
+	}
+	return
+}
+
 func IsRevertible(t *Template) bool {
 	revertible := false
 	t.visitCommandNodes(func(cmd *ast.CommandNode) {
@@ -240,60 +396,156 @@ func isRevertible(cmd *ast.CommandNode) bool {
 	if cmd.CmdErr != nil {
 		return false
 	}
+	return unrevertibleReason(cmd, &ranOutcome{cmd: cmd}) == ""
+}
 
-	if cmd.Action == "check" {
-		return false
-	}
+// revertOutcome is what a statement produces that its revert depends on,
+// either once run or as expected from its command before running
+type revertOutcome interface {
+	hasResult() bool
+	hasPriorValues() bool
+	hasRecreation() bool
+}
 
-	if cmd.Action == "detach" && cmd.Entity == "routetable" {
-		return false
-	}
+type ranOutcome struct {
+	cmd *ast.CommandNode
+}
 
-	if cmd.Entity == "record" && (cmd.Action == "create" || cmd.Action == "delete") {
-		return true
-	}
+func (o *ranOutcome) hasResult() bool {
+	v, ok := o.cmd.CmdResult.(string)
+	return ok && v != ""
+}
 
-	if cmd.Entity == "instanceprofile" && (cmd.Action == "create" || cmd.Action == "delete") {
-		return true
-	}
+func (o *ranOutcome) hasPriorValues() bool {
+	return len(o.cmd.CmdPriorValues) > 0
+}
 
-	if cmd.Entity == "alarm" && (cmd.Action == "start" || cmd.Action == "stop") {
-		return true
+func (o *ranOutcome) hasRecreation() bool {
+	for _, snap := range o.cmd.CmdSnapshots {
+		if snap.Recreate != "" {
+			return true
+		}
 	}
+	return false
+}
 
-	if cmd.Entity == "database" && (cmd.Action == "start" || cmd.Action == "stop") {
-		return true
-	}
+type expectedOutcome struct {
+	cmd         *ast.CommandNode
+	recreatable func(entity string) bool
+}
 
-	if cmd.Entity == "containertask" && cmd.Action == "start" {
-		t, ok := cmd.ToDriverParams()["type"].(string)
-		return ok && (t == "service" || t == "task")
+func (o *expectedOutcome) hasResult() bool {
+	type R interface {
+		ExtractResult(interface{}) string
 	}
+	_, ok := o.cmd.Command.(R)
+	return ok
+}
 
-	if cmd.Entity == "container" && cmd.Action == "create" {
-		return true
+func (o *expectedOutcome) hasPriorValues() bool {
+	type P interface {
+		PriorValues(map[string]interface{}) (map[string]interface{}, error)
 	}
+	_, ok := o.cmd.Command.(P)
+	return ok
+}
+
+func (o *expectedOutcome) hasRecreation() bool {
+	return o.recreatable != nil && o.recreatable(o.cmd.Entity)
+}
 
-	if cmd.Entity == "appscalingtarget" && cmd.Action == "create" {
-		return true
+// unrevertibleReason returns why a statement is not revertible given its outcome, or an empty string if it is
+func unrevertibleReason(cmd *ast.CommandNode, outcome revertOutcome) string {
+	if _, ok := revertActions[cmd.Action]; !ok {
+		return fmt.Sprintf("%s has no reverting action", cmd.Action)
 	}
 
-	if cmd.Entity == "securitygroup" && cmd.Action == "update" {
-		return true
+	switch {
+	case cmd.Action == "detach" && cmd.Entity == "routetable":
+		return "the association of the route table is not kept"
+	case revertsDeleteFromParams(cmd.Entity) && (cmd.Action == "create" || cmd.Action == "delete"):
+		return ""
+	case cmd.Action == "delete":
+		if !outcome.hasRecreation() {
+			return "the deleted resources cannot be recreated"
+		}
+		return ""
+	case cmd.Action == "update" && cmd.Entity == "securitygroup":
+		return ""
+	case cmd.Action == "update":
+		if !outcome.hasPriorValues() {
+			return "the values it changes are not fetched before running"
+		}
+		return ""
+	case cmd.Action == "start" && cmd.Entity == "containertask":
+		if t, ok := cmd.ToDriverParams()["type"].(string); !ok || (t != "service" && t != "task") {
+			return "only containertask of type service or task can be stopped back"
+		}
+		return ""
+	case cmd.Action == "attach" || cmd.Action == "detach":
+		return ""
+	case (cmd.Action == "start" || cmd.Action == "stop") && (cmd.Entity == "alarm" || cmd.Entity == "database"):
+		return ""
+	case cmd.Action == "create" && (cmd.Entity == "tag" || cmd.Entity == "route" || cmd.Entity == "container" ||
+		cmd.Entity == "appscalingtarget" || cmd.Entity == "appscalingpolicy"):
+		return ""
 	}
 
-	if cmd.Entity == "appscalingpolicy" && cmd.Action == "create" {
-		return true
+	if !outcome.hasResult() {
+		return "it has no result to revert it from"
 	}
+	return ""
+}
 
-	if v, ok := cmd.CmdResult.(string); ok && v != "" {
-		if cmd.Action == "create" || cmd.Action == "start" || cmd.Action == "stop" || cmd.Action == "copy" {
-			return true
+// revertsDeleteFromParams returns true for the entities whose delete is reverted
+// from its own params, without snapshotting them
+func revertsDeleteFromParams(entity string) bool {
+	return entity == "record" || entity == "instanceprofile"
+}
+
+// recreation gathers the statements recreating deleted resources from their snapshots,
+// declaring unique variables and referencing the resources recreated before them
+type recreation struct {
+	declared map[string]bool
+	refs     map[string]string
+}
+
+func newRecreation() *recreation {
+	return &recreation{declared: make(map[string]bool), refs: make(map[string]string)}
+}
+
+func (r *recreation) statements(snap *ast.Snapshot) (lines []string, err error) {
+	if snap.Recreate == "" {
+		for _, reason := range snap.Unrecreatable {
+			lines = append(lines, fmt.Sprintf("# cannot recreate %s", reason))
 		}
+		return
 	}
-
-	return cmd.Action == "attach" || cmd.Action == "detach" || cmd.Action == "check" ||
-		(cmd.Action == "create" && cmd.Entity == "tag") || (cmd.Action == "create" && cmd.Entity == "route")
+	tpl, err := Parse(snap.Recreate)
+	if err != nil {
+		return nil, fmt.Errorf("recreate %s %s: %s", snap.Type, snap.ID, err)
+	}
+	ast.RenameDeclarations(tpl.Statements, func(ident string) string {
+		renamed := ident
+		for i := 2; r.declared[renamed]; i++ {
+			renamed = fmt.Sprintf("%s_%d", ident, i)
+		}
+		r.declared[renamed] = true
+		return renamed
+	})
+	ast.ReferLiterals(tpl.Statements, r.refs)
+	for _, st := range tpl.Statements {
+		if decl, ok := st.Node.(*ast.DeclarationNode); ok {
+			if cmd, ok := decl.Expr.(*ast.CommandNode); ok && cmd.Action == "create" && cmd.Entity == snap.Type {
+				r.refs[snap.ID] = decl.Ident
+			}
+		}
+		lines = append(lines, st.String())
+	}
+	for _, reason := range snap.Unrecreatable {
+		lines = append(lines, fmt.Sprintf("# cannot recreate %s", reason))
+	}
+	return
 }
 
 func quoteParamIfNeeded(param interface{}) string {
@@ -308,3 +560,35 @@ func quoteParamIfNeeded(param interface{}) string {
 		}
 	}
 }
+
+// printPriorValue prints a value fetched before an update, or unmarshalled from JSON, as a template value
+func printPriorValue(i interface{}) string {
+	switch v := i.(type) {
+	case []interface{}:
+		var elems []string
+		for _, e := range v {
+			elems = append(elems, printPriorValue(e))
+		}
+		return "[" + strings.Join(elems, ",") + "]"
+	case []string:
+		var elems []string
+		for _, e := range v {
+			elems = append(elems, quoteParamIfNeeded(e))
+		}
+		return "[" + strings.Join(elems, ",") + "]"
+	case map[string]interface{}:
+		var keys, elems []string
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			elems = append(elems, fmt.Sprintf("%s: %s", quoteParamIfNeeded(k), printPriorValue(v[k])))
+		}
+		return "{" + strings.Join(elems, ", ") + "}"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return quoteParamIfNeeded(v)
+	}
+}
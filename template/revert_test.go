@@ -2,6 +2,7 @@ package template
 
 import (
 	"errors"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -385,15 +386,74 @@ stop containertask cluster=cl deployment-name=dpname type=service`
 	})
 }
 
+func TestRevertUpdateWithPriorValues(t *testing.T) {
+	tcases := []struct {
+		in    string
+		prior map[string]interface{}
+		exp   string
+	}{
+		{in: "update instance id=i-1234 type=m4.large", prior: map[string]interface{}{"type": "t2.micro"}, exp: "update instance id=i-1234 type=t2.micro"},
+		{in: "update subnet id=sub-1234 public=true", prior: map[string]interface{}{"public": false}, exp: "update subnet id=sub-1234 public=false"},
+		{in: "update scalinggroup name=my-group max-size=10 subnets=[sub-1,sub-2]", prior: map[string]interface{}{"max-size": float64(2), "subnets": []interface{}{"sub-1"}}, exp: "update scalinggroup max-size=2 name=my-group subnets=[sub-1]"},
+		{in: "update stack name=my-stack parameters={Env: staging} use-previous-template=true", prior: map[string]interface{}{"parameters": map[string]interface{}{"Env": "prod", "Team": "core infra"}}, exp: "update stack name=my-stack parameters={Env: prod, Team: 'core infra'} use-previous-template=true"},
+		{in: "update bucket name=my-bucket public-website=true redirect-hostname=my.host.com", prior: map[string]interface{}{"public-website": true, "index-suffix": "index.html", "redirect-hostname": nil}, exp: "update bucket index-suffix=index.html name=my-bucket public-website=true"},
+		{in: "update loginprofile password={secret:password} password-reset=true username=jdoe", prior: map[string]interface{}{"password-reset": false}, exp: "update loginprofile password-reset=false password={secret:password} username=jdoe"},
+	}
+
+	for _, tcase := range tcases {
+		tpl := MustParse(tcase.in)
+		tpl.CommandNodesIterator()[0].CmdPriorValues = tcase.prior
+		reverted, err := tpl.Revert()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := reverted.String(), tcase.exp; got != want {
+			t.Fatalf("got: %s\nwant: %s\n", got, want)
+		}
+	}
+}
+
+func TestRevertDeleteWithSnapshots(t *testing.T) {
+	tpl := MustParse("delete securitygroup id=sg-1\ndelete user name=alice\ndelete vpc id=vpc-1\ndelete keypair id=ops")
+	cmds := tpl.CommandNodesIterator()
+	cmds[0].CmdSnapshots = []*ast.Snapshot{{Type: "securitygroup", ID: "sg-1",
+		Recreate:      "web = create securitygroup description='web servers' name=web vpc=vpc-1\nupdate securitygroup cidr=0.0.0.0/0 id=$web inbound=authorize portrange=443 protocol=tcp",
+		Unrecreatable: []string{"securitygroup sg-1 (web): inbound rule for IPv6 range ::/0"}}}
+	cmds[1].CmdSnapshots = []*ast.Snapshot{{Type: "user", ID: "AIDA1", Recreate: "alice = create user name=alice\nattach user group=admins name=alice"}}
+	cmds[2].CmdSnapshots = []*ast.Snapshot{{Type: "vpc", ID: "vpc-1", Recreate: "web = create vpc cidr=10.0.0.0/16 name=web"}}
+	cmds[3].CmdSnapshots = []*ast.Snapshot{{Type: "keypair", ID: "ops", Unrecreatable: []string{"keypair ops: resource type not supported"}}}
+
+	reverted, err := tpl.Revert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `web = create vpc cidr=10.0.0.0/16 name=web
+alice = create user name=alice
+attach user group=admins name=alice
+web_2 = create securitygroup description='web servers' name=web vpc=$web
+update securitygroup cidr=0.0.0.0/0 id=$web_2 inbound=authorize portrange=443 protocol=tcp
+# cannot recreate securitygroup sg-1 (web): inbound rule for IPv6 range ::/0`
+	if got, want := reverted.String(), exp; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
 func TestCmdNodeIsRevertible(t *testing.T) {
 	tcases := []struct {
 		line, result string
 		params       map[string]ast.CompositeValue
+		prior        map[string]interface{}
+		snapshots    []*ast.Snapshot
 		err          error
 		revertible   bool
 	}{
 		{line: "update vpc", result: "any", revertible: false},
+		{line: "update instance", prior: map[string]interface{}{"type": "t2.micro"}, revertible: true},
+		{line: "update instance", prior: map[string]interface{}{"type": "t2.micro"}, err: errors.New("any"), revertible: false},
 		{line: "delete vpc", result: "any", revertible: false},
+		{line: "delete user", snapshots: []*ast.Snapshot{{Type: "user", ID: "AIDA1", Recreate: "create user name=alice"}}, revertible: true},
+		{line: "delete user", snapshots: []*ast.Snapshot{{Type: "user", ID: "AIDA1", Recreate: "create user name=alice"}}, err: errors.New("any"), revertible: false},
+		{line: "delete keypair", snapshots: []*ast.Snapshot{{Type: "keypair", ID: "ops", Unrecreatable: []string{"keypair ops: resource type not supported"}}}, revertible: false},
 		{line: "create vpc", result: "any", err: errors.New("any"), revertible: false},
 		{line: "create vpc", revertible: false},
 		{line: "start instance", revertible: false},
@@ -414,7 +474,7 @@ func TestCmdNodeIsRevertible(t *testing.T) {
 	for _, tc := range tcases {
 		splits := strings.SplitN(tc.line, " ", 2)
 		action, entity := splits[0], splits[1]
-		cmd := &ast.CommandNode{Action: action, Entity: entity, CmdResult: tc.result, CmdErr: tc.err}
+		cmd := &ast.CommandNode{Action: action, Entity: entity, CmdResult: tc.result, CmdErr: tc.err, CmdPriorValues: tc.prior, CmdSnapshots: tc.snapshots}
 		if tc.params != nil {
 			cmd.Params = tc.params
 		}
@@ -423,3 +483,131 @@ func TestCmdNodeIsRevertible(t *testing.T) {
 		}
 	}
 }
+
+func TestRevertSelected(t *testing.T) {
+	tpl := MustParse(`vpc = create vpc cidr=10.0.0.0/16
+subnet = create subnet cidr=10.0.1.0/24 vpc=vpc-1
+sg = create securitygroup name=web vpc=vpc-1 description=web
+update securitygroup id=sg-1 inbound=authorize protocol=tcp portrange=443 cidr=0.0.0.0/0
+check securitygroup id=sg-1 state=unused timeout=0`)
+	results := []string{"vpc-1", "sub-1", "sg-1", "", ""}
+	for i, cmd := range tpl.CommandNodesIterator() {
+		cmd.CmdResult = results[i]
+	}
+
+	tcases := []struct {
+		selected map[int]bool
+		exp      string
+		expErr   string
+	}{
+		{selected: map[int]bool{4: true}, exp: "update securitygroup cidr=0.0.0.0/0 id=sg-1 inbound=revoke portrange=443 protocol=tcp"},
+		{selected: map[int]bool{2: true, 4: true}, exp: "update securitygroup cidr=0.0.0.0/0 id=sg-1 inbound=revoke portrange=443 protocol=tcp\ndelete subnet id=sub-1"},
+		{selected: map[int]bool{3: true, 4: true}, exp: "update securitygroup cidr=0.0.0.0/0 id=sg-1 inbound=revoke portrange=443 protocol=tcp\ncheck securitygroup id=sg-1 state=unused timeout=300\ndelete securitygroup id=sg-1"},
+		{selected: map[int]bool{3: true}, expErr: "reverting statement 3 (create securitygroup description=web name=web vpc=vpc-1) requires reverting statement 4 (update securitygroup cidr=0.0.0.0/0 id=sg-1 inbound=authorize portrange=443 protocol=tcp), which references it"},
+		{selected: map[int]bool{1: true, 2: true}, expErr: "reverting statement 1 (create vpc cidr=10.0.0.0/16) requires reverting statement 3 (create securitygroup description=web name=web vpc=vpc-1), which references it"},
+		{selected: map[int]bool{5: true}, expErr: "none of the selected statements is revertible"},
+		{selected: map[int]bool{6: true}, expErr: "no statement 6: template has 5 statements"},
+	}
+	for i, tcase := range tcases {
+		reverted, err := tpl.RevertSelected(tcase.selected)
+		if tcase.expErr != "" {
+			if err == nil || err.Error() != tcase.expErr {
+				t.Fatalf("%d: got error %v, want %s", i+1, err, tcase.expErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := reverted.String(), tcase.exp; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+	}
+}
+
+func TestRevertSelectedDeletes(t *testing.T) {
+	tpl := MustParse("delete subnet id=sub-1\ndelete vpc id=vpc-1")
+	cmds := tpl.CommandNodesIterator()
+	cmds[0].CmdSnapshots = []*ast.Snapshot{{Type: "subnet", ID: "sub-1", Recreate: "subnet = create subnet cidr=10.0.1.0/24 vpc=vpc-1"}}
+	cmds[1].CmdSnapshots = []*ast.Snapshot{{Type: "vpc", ID: "vpc-1", Recreate: "vpc = create vpc cidr=10.0.0.0/16"}}
+
+	if _, err := tpl.RevertSelected(map[int]bool{1: true}); err == nil || err.Error() != "reverting statement 1 (delete subnet id=sub-1) requires reverting statement 2 (delete vpc id=vpc-1), which it references" {
+		t.Fatalf("got error %v", err)
+	}
+	reverted, err := tpl.RevertSelected(map[int]bool{2: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := reverted.String(), "vpc = create vpc cidr=10.0.0.0/16"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+type resultCommand struct{ mockCommand }
+
+func (c *resultCommand) ExtractResult(interface{}) string { return "" }
+
+type priorValuesCommand struct{ mockCommand }
+
+func (c *priorValuesCommand) PriorValues(map[string]interface{}) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func TestRevertibilityReport(t *testing.T) {
+	tpl := MustParse(`create vpc cidr=10.0.0.0/16
+create tag key=Env value=prod resource=vpc-1
+create queue name=jobs
+update instance id=i-1 type=t2.micro
+update image id=ami-1 description=new
+update securitygroup id=sg-1 inbound=authorize protocol=tcp portrange=22 cidr=10.0.0.0/16
+check instance id=i-1 state=running timeout=180
+delete subnet id=sub-1
+delete keypair id=ops
+delete record name=my.domain.com type=A value=10.0.0.1 zone=my-zone ttl=60
+detach routetable id=rtb-1 association=rtbassoc-1
+import image bucket=my-bucket
+authenticate registry`)
+	commands := map[string]ast.Command{
+		"createvpc":            &resultCommand{},
+		"createtag":            &mockCommand{},
+		"createqueue":          &mockCommand{},
+		"updateinstance":       &priorValuesCommand{},
+		"updateimage":          &mockCommand{},
+		"updatesecuritygroup":  &mockCommand{},
+		"checkinstance":        &mockCommand{},
+		"deletesubnet":         &mockCommand{},
+		"deletekeypair":        &mockCommand{},
+		"deleterecord":         &mockCommand{},
+		"detachroutetable":     &mockCommand{},
+		"importimage":          &mockCommand{},
+		"authenticateregistry": &mockCommand{},
+	}
+	for _, cmd := range tpl.CommandNodesIterator() {
+		cmd.Command = commands[cmd.Action+cmd.Entity]
+	}
+	lookup := func(tokens ...string) interface{} {
+		switch strings.Join(tokens, "") {
+		case "deletevpc", "deletetag", "deletequeue", "updateinstance", "updateimage", "updatesecuritygroup",
+			"createsubnet", "createkeypair", "createrecord", "attachroutetable":
+			return &mockCommand{}
+		}
+		return nil
+	}
+	recreatable := func(entity string) bool { return entity == "subnet" }
+
+	var got []string
+	for _, u := range RevertibilityReport(tpl, lookup, recreatable) {
+		got = append(got, u.Statement+": "+u.Reason)
+	}
+	exp := []string{
+		"create queue name=jobs: it has no result to revert it from",
+		"update image description=new id=ami-1: the values it changes are not fetched before running",
+		"delete keypair id=ops: the deleted resources cannot be recreated",
+		"detach routetable association=rtbassoc-1 id=rtb-1: the association of the route table is not kept",
+		"import image bucket=my-bucket: import has no reverting action",
+		"authenticate registry: authenticate has no reverting action",
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(exp, "\n"))
+	}
+}
@@ -15,37 +15,78 @@ type Runner struct {
 	Fillers                                []map[string]interface{}
 	AliasFunc                              func(entity, key, alias string) string
 	MissingHolesFunc                       func(string, []string) interface{}
+	MissingSecretHolesFunc                 func(string, []string) interface{}
+	IncludeFunc                            func(path, from string) (string, string, error)
+	SnapshotFunc                           func(entity string, params map[string]interface{}) ([]*Snapshot, error)
 	CmdLookuper                            func(tokens ...string) interface{}
 	Validators                             []Validator
+	Parallelism                            int
+	RollbackOnFailure                      bool
+	Resumed                                *Template
+
+	// ReportRevertibility warns before running about the statements which will not be revertible
+	ReportRevertibility bool
+	// RequireRevertible refuses to run templates with statements which will not be revertible
+	RequireRevertible bool
+	// RecreatableFunc tells the entities whose deleted resources can be recreated when reverting
+	RecreatableFunc func(entity string) bool
+
+	// Plan, when set, is run instead of compiling the template it was made from
+	Plan *Plan
+	// PlanFunc, when set, receives the plan of the dry run template, which is then not run
+	PlanFunc func(*Plan) error
 
 	BeforeRun func(*TemplateExecution) (bool, error)
 	AfterRun  func(*TemplateExecution) error
 }
 
 func (ru *Runner) Run() error {
-	tplExec := &TemplateExecution{
-		Template: ru.Template,
-		Path:     ru.TemplatePath,
-		Locale:   ru.Locale,
-		Profile:  ru.Profile,
-		Source:   ru.Template.String(),
-	}
-	tplExec.SetMessage(ru.Message)
-
 	env := NewEnv()
 	env.Log = ru.Log
-	env.AddFillers(ru.Fillers...)
+	fillers := ru.Fillers
+	if ru.Resumed != nil {
+		fillers = withoutSecretFillers(ru.Template, fillers)
+	}
+	env.AddFillers(fillers...)
 	env.AliasFunc = ru.AliasFunc
 	env.MissingHolesFunc = ru.MissingHolesFunc
+	env.MissingSecretHolesFunc = ru.MissingSecretHolesFunc
+	env.IncludeFunc = ru.IncludeFunc
+	env.SnapshotFunc = ru.SnapshotFunc
 	env.Lookuper = ru.CmdLookuper
+	env.Parallelism = ru.Parallelism
+	if ru.Resumed != nil {
+		env.resumed = succeededStatements(ru.Resumed)
+	}
 
-	var err error
-	tplExec.Template, env, err = Compile(tplExec.Template, env, NewRunnerCompileMode)
+	// secret params are marked before the source is recorded, not to persist their literal values
+	_, _, err := Compile(ru.Template, env, []compileFunc{markSecretParamsPass})
 	if err != nil {
 		return err
 	}
 
-	tplExec.Fillers = env.GetProcessedFillers()
+	tplExec := &TemplateExecution{
+		Template: ru.Template,
+		Path:     ru.TemplatePath,
+		Locale:   ru.Locale,
+		Profile:  ru.Profile,
+		Source:   ru.Template.String(),
+	}
+	tplExec.SetMessage(ru.Message)
+
+	if ru.Plan != nil {
+		tplExec.Template, env, err = ru.Plan.compile(ru.Template, env)
+		if err != nil {
+			return err
+		}
+		tplExec.Fillers = ru.Plan.Fillers
+	} else {
+		tplExec.Template, env, err = Compile(tplExec.Template, env, NewRunnerCompileMode)
+		if err != nil {
+			return err
+		}
+		tplExec.Fillers = env.GetProcessedFillers()
+	}
 
 	errs := tplExec.Template.Validate(ru.Validators...)
 	if len(errs) > 0 {
@@ -55,6 +96,18 @@ func (ru *Runner) Run() error {
 		fmt.Fprintln(os.Stderr)
 	}
 
+	if ru.ReportRevertibility {
+		if report := RevertibilityReport(tplExec.Template, env.Lookuper, ru.RecreatableFunc); len(report) > 0 {
+			for _, u := range report {
+				logger.Warningf("will not be revertible: %s (%s)", u.Statement, u.Reason)
+			}
+			if ru.RequireRevertible {
+				return fmt.Errorf("%d statement(s) will not be revertible while revertibility is required", len(report))
+			}
+			fmt.Fprintln(os.Stderr)
+		}
+	}
+
 	if tplExec.IsOneLiner() {
 		logger.Verbose("Dry running template ...")
 	} else {
@@ -62,7 +115,8 @@ func (ru *Runner) Run() error {
 	}
 
 	env.IsDryRun = true
-	if _, err = tplExec.Template.Run(env); err != nil {
+	dryRun, err := tplExec.Template.Run(env)
+	if err != nil {
 		switch t := err.(type) {
 		case *Errors:
 			errs, _ := t.Errors()
@@ -76,6 +130,10 @@ func (ru *Runner) Run() error {
 	}
 	env.IsDryRun = false
 
+	if ru.PlanFunc != nil {
+		return ru.PlanFunc(newPlan(tplExec, dryRun))
+	}
+
 	ok, err := ru.BeforeRun(tplExec)
 	if err != nil {
 		return err
@@ -86,9 +144,23 @@ func (ru *Runner) Run() error {
 		if err != nil {
 			logger.Errorf("Running template error: %s", err)
 		}
+		tplExec.Outputs = tplExec.Template.Outputs()
+
+		var rollbackExec *TemplateExecution
+		if ru.RollbackOnFailure && (err != nil || tplExec.Stats().KOCount > 0) {
+			if rollbackExec, err = ru.rollback(tplExec); err != nil {
+				logger.Errorf("Rollback error: %s", err)
+			}
+		}
+
 		if err := ru.AfterRun(tplExec); err != nil {
 			return err
 		}
+		if rollbackExec != nil {
+			if err := ru.AfterRun(rollbackExec); err != nil {
+				return err
+			}
+		}
 	}
 
 	if tplExec.Stats().KOCount > 0 {
@@ -97,3 +169,54 @@ func (ru *Runner) Run() error {
 
 	return nil
 }
+
+// rollback reverts the commands which succeeded in a failed template execution,
+// linking the failed execution and its rollback
+func (ru *Runner) rollback(failed *TemplateExecution) (*TemplateExecution, error) {
+	if !IsRevertible(failed.Template) {
+		logger.Info("Nothing to rollback")
+		return nil, nil
+	}
+	reverted, err := failed.Template.Revert()
+	if err != nil {
+		return nil, err
+	}
+
+	env := NewEnv()
+	env.Log = ru.Log
+	env.AliasFunc = ru.AliasFunc
+	env.Lookuper = ru.CmdLookuper
+
+	compiled, env, err := Compile(reverted, env, NewRunnerCompileMode)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Rolling back template %s ...", failed.ID)
+	env.IsDryRun = true
+	dryRun, err := compiled.Run(env)
+	if err != nil {
+		return nil, fmt.Errorf("dry run: %s", err)
+	}
+	if dryRun.HasErrors() {
+		for _, cmd := range dryRun.CommandNodesIterator() {
+			if cmd.CmdErr != nil {
+				logger.Error(cmd.CmdErr)
+			}
+		}
+		return nil, errors.New("dry run failed")
+	}
+	env.IsDryRun = false
+
+	rollbackExec := &TemplateExecution{
+		Path:       failed.Path,
+		Locale:     failed.Locale,
+		Profile:    failed.Profile,
+		Source:     reverted.String(),
+		RollbackOf: failed.ID,
+	}
+	rollbackExec.SetMessage(fmt.Sprintf("Rollback of %s", failed.ID))
+	rollbackExec.Template, err = compiled.Run(env)
+	failed.RolledBackBy = rollbackExec.Template.ID
+	return rollbackExec, err
+}
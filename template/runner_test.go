@@ -0,0 +1,385 @@
+package template
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/logger"
+)
+
+type rollbackCommand struct {
+	action string
+	ran    *[]string
+}
+
+func (c *rollbackCommand) Run(ctx, params map[string]interface{}) (interface{}, error) {
+	name := fmt.Sprint(params["name"])
+	if name == "fail" {
+		return nil, errors.New("failed")
+	}
+	*c.ran = append(*c.ran, fmt.Sprintf("%s %v", c.action, params))
+	return "id-" + name, nil
+}
+
+func (c *rollbackCommand) DryRun(ctx, params map[string]interface{}) (interface{}, error) {
+	return fmt.Sprintf("dryrun-%v", params["name"]), nil
+}
+
+func (c *rollbackCommand) ValidateParams([]string) ([]string, error) { return nil, nil }
+
+func (c *rollbackCommand) ExtractResult(i interface{}) string { return fmt.Sprint(i) }
+
+func TestRollbackFailedTemplateExecution(t *testing.T) {
+	var ran []string
+	lookuper := func(tokens ...string) interface{} {
+		for _, action := range []string{"create", "delete"} {
+			if len(tokens) > 0 && len(tokens[0]) > len(action) && tokens[0][:len(action)] == action {
+				return &rollbackCommand{action: action, ran: &ran}
+			}
+		}
+		return nil
+	}
+	ru := &Runner{CmdLookuper: lookuper, Log: logger.DiscardLogger}
+
+	t.Run("revert succeeded commands", func(t *testing.T) {
+		ran = nil
+		env := NewEnv()
+		env.Lookuper = lookuper
+		env.Log = logger.DiscardLogger
+		tpl, env, err := Compile(MustParse("vpc = create vpc name=vpc1\ncreate subnet name=sub1 vpc=$vpc\ncreate subnet name=fail vpc=$vpc"), env, NewRunnerCompileMode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		failed := &TemplateExecution{Locale: "eu-west-1"}
+		failed.Template, _ = tpl.Run(env)
+		ran = nil
+
+		rollbackExec, err := ru.rollback(failed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := ran, []string{"delete map[id:id-sub1]", "delete map[id:id-vpc1]"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := rollbackExec.RollbackOf, failed.ID; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := failed.RolledBackBy, rollbackExec.ID; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := rollbackExec.Locale, "eu-west-1"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := rollbackExec.Message, "Rollback of "+failed.ID; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("nothing to revert", func(t *testing.T) {
+		ran = nil
+		env := NewEnv()
+		env.Lookuper = lookuper
+		env.Log = logger.DiscardLogger
+		tpl, env, err := Compile(MustParse("create subnet name=fail"), env, NewRunnerCompileMode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		failed := &TemplateExecution{}
+		failed.Template, _ = tpl.Run(env)
+
+		rollbackExec, err := ru.rollback(failed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rollbackExec != nil {
+			t.Fatalf("expected no rollback, got %v", rollbackExec)
+		}
+		if got, want := failed.RolledBackBy, ""; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+}
+
+func TestPlanAndApplyTemplate(t *testing.T) {
+	var ran []string
+	lookuper := func(tokens ...string) interface{} {
+		return &rollbackCommand{action: "create", ran: &ran}
+	}
+	source := "vpc = create vpc name={vpc.name}\ncreate subnet name=sub1 vpc=$vpc"
+
+	var plan *Plan
+	ru := &Runner{
+		Template:    MustParse(source),
+		Fillers:     []map[string]interface{}{{"vpc.name": "myvpc"}},
+		CmdLookuper: lookuper,
+		Log:         logger.DiscardLogger,
+		Locale:      "eu-west-1",
+		PlanFunc:    func(p *Plan) error { plan = p; return nil },
+	}
+	if err := ru.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ran) > 0 {
+		t.Fatalf("expected nothing to be run when planning, got %v", ran)
+	}
+	if got, want := len(plan.Statements), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	expected := []*PlanStatement{
+		{Index: 0, Line: "create vpc name=myvpc", Declaration: "vpc", Action: "create", Entity: "vpc", Params: map[string]string{"name": "myvpc"}, Revertable: true, DryRunResult: "dryrun-myvpc"},
+		{Index: 1, Line: "create subnet name=sub1 vpc=$vpc", Action: "create", Entity: "subnet", Params: map[string]string{"name": "sub1", "vpc": "$vpc"}, DependsOn: []int{0}, Revertable: true, DryRunResult: "dryrun-sub1"},
+	}
+	if got, want := plan.Statements, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got[1], want[1])
+	}
+	if got, want := plan.Locale, "eu-west-1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	b, err := json.Marshal(plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded *Plan
+	if err = json.Unmarshal(b, &loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("apply plan", func(t *testing.T) {
+		ran = nil
+		var applied *TemplateExecution
+		ru := &Runner{
+			Template:    MustParse(source),
+			Plan:        loaded,
+			CmdLookuper: lookuper,
+			Log:         logger.DiscardLogger,
+			BeforeRun:   func(*TemplateExecution) (bool, error) { return true, nil },
+			AfterRun:    func(te *TemplateExecution) error { applied = te; return nil },
+		}
+		if err := ru.Run(); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := ran, []string{"create map[name:myvpc]", "create map[name:sub1 vpc:id-myvpc]"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := applied.Fillers, map[string]interface{}{"vpc.name": "myvpc"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("source changed", func(t *testing.T) {
+		ran = nil
+		ru := &Runner{Template: MustParse("create vpc name=other"), Plan: loaded, CmdLookuper: lookuper, Log: logger.DiscardLogger}
+		err := ru.Run()
+		if err == nil || !strings.Contains(err.Error(), "source template has changed") {
+			t.Fatalf("expected source changed error, got %v", err)
+		}
+		if len(ran) > 0 {
+			t.Fatalf("expected nothing to be run, got %v", ran)
+		}
+	})
+}
+
+func TestRevertibilityReportedOnlyWhenRequested(t *testing.T) {
+	var ran []string
+	lookuper := func(tokens ...string) interface{} {
+		return &rollbackCommand{action: "import", ran: &ran}
+	}
+	tcases := []struct {
+		report bool
+		expErr string
+		expRan int
+	}{
+		{report: false, expRan: 1},
+		{report: true, expErr: "1 statement(s) will not be revertible while revertibility is required"},
+	}
+	for i, tcase := range tcases {
+		ran = nil
+		ru := &Runner{
+			Template:            MustParse("import image name=ubuntu"),
+			CmdLookuper:         lookuper,
+			Log:                 logger.DiscardLogger,
+			ReportRevertibility: tcase.report,
+			RequireRevertible:   true,
+			BeforeRun:           func(*TemplateExecution) (bool, error) { return true, nil },
+			AfterRun:            func(*TemplateExecution) error { return nil },
+		}
+		err := ru.Run()
+		if tcase.expErr != "" {
+			if err == nil || err.Error() != tcase.expErr {
+				t.Fatalf("%d: got error %v, want %s", i+1, err, tcase.expErr)
+			}
+		} else if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := len(ran), tcase.expRan; got != want {
+			t.Fatalf("%d: got %d commands run, want %d", i+1, got, want)
+		}
+	}
+}
+
+type secretCommand struct {
+	rollbackCommand
+}
+
+func (c *secretCommand) SecretParams() []string { return []string{"password"} }
+
+func TestSecretsAreNeitherPrintedNorPersisted(t *testing.T) {
+	var ran []string
+	lookuper := func(tokens ...string) interface{} {
+		switch tokens[0] {
+		case "createdatabase", "createloginprofile":
+			return &secretCommand{rollbackCommand{action: "create", ran: &ran}}
+		case "createuser":
+			return &rollbackCommand{action: "create", ran: &ran}
+		default:
+			return &rollbackCommand{action: "delete", ran: &ran}
+		}
+	}
+	os.Setenv("AWLESS_TEST_SECRET", "from-env")
+	defer os.Unsetenv("AWLESS_TEST_SECRET")
+
+	var executed *TemplateExecution
+	ru := &Runner{
+		Template:               MustParse("create database name=db1 password=literal-secret\ncreate database name=db2 password={db2.password}\ncreate user name=bob password={secret:bob.password}"),
+		Fillers:                []map[string]interface{}{{"db2.password": "env:AWLESS_TEST_SECRET"}},
+		CmdLookuper:            lookuper,
+		Log:                    logger.DiscardLogger,
+		MissingHolesFunc:       func(string, []string) interface{} { t.Fatal("secret holes must be prompted as secrets"); return nil },
+		MissingSecretHolesFunc: func(string, []string) interface{} { return "prompted-secret" },
+		BeforeRun:              func(*TemplateExecution) (bool, error) { return true, nil },
+		AfterRun:               func(te *TemplateExecution) error { executed = te; return nil },
+	}
+	if err := ru.Run(); err != nil {
+		t.Fatal(err)
+	}
+	expRan := []string{"create map[name:db1 password:literal-secret]", "create map[name:db2 password:from-env]", "create map[name:bob password:prompted-secret]"}
+	if got, want := ran, expRan; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	expSource := "create database name=db1 password={secret:database.password}\ncreate database name=db2 password={secret:db2.password}\ncreate user name=bob password={secret:bob.password}"
+	if got, want := executed.Source, expSource; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+	if got, want := executed.Fillers, map[string]interface{}{"db2.password": "******", "bob.password": "******"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	b, err := json.Marshal(executed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"literal-secret", "from-env", "prompted-secret", "AWLESS_TEST_SECRET"} {
+		if strings.Contains(string(b), secret) {
+			t.Fatalf("'%s' persisted in %s", secret, b)
+		}
+	}
+
+	var loaded *TemplateExecution
+	if err = json.Unmarshal(b, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	reverted, err := loaded.Revert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := reverted.String(), "delete user name=bob\ndelete database id=id-db2 skip-snapshot=true\ncheck database id=id-db2 state=not-found timeout=900\ndelete database id=id-db1 skip-snapshot=true"; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+
+	t.Run("secret from file", func(t *testing.T) {
+		f, err := ioutil.TempFile("", "awless-secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(f.Name())
+		f.WriteString("from-file\n")
+		f.Close()
+
+		ran = nil
+		ru.Template = MustParse("create database name=db3 password={db3.password}")
+		ru.Fillers = []map[string]interface{}{{"db3.password": "file:" + f.Name()}}
+		if err := ru.Run(); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := ran, []string{"create map[name:db3 password:from-file]"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+	t.Run("secret through variables", func(t *testing.T) {
+		for _, tcase := range []struct{ tpl, expSource string }{
+			{
+				tpl:       "pw = s3cret\ncreate loginprofile username=x password=$pw",
+				expSource: "pw = {secret:loginprofile.password}\ncreate loginprofile password=$pw username=x",
+			},
+			{
+				tpl:       "secret = s3cret\npw = $secret\ncreate loginprofile username=x password=$pw",
+				expSource: "secret = {secret:loginprofile.password}\npw = $secret\ncreate loginprofile password=$pw username=x",
+			},
+		} {
+			ran, executed = nil, nil
+			ru.Template = MustParse(tcase.tpl)
+			ru.Fillers = nil
+			if err := ru.Run(); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := ran, []string{"create map[password:s3cret username:x]"}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+			if got, want := executed.Source, tcase.expSource; got != want {
+				t.Fatalf("got\n%s\nwant\n%s", got, want)
+			}
+			b, err := json.Marshal(executed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(b), "s3cret") {
+				t.Fatalf("secret persisted in %s", b)
+			}
+		}
+	})
+	t.Run("plan", func(t *testing.T) {
+		source := "create database name=db4 password={secret:db4.password}"
+		var plan *Plan
+		ru := &Runner{
+			Template:    MustParse(source),
+			Fillers:     []map[string]interface{}{{"db4.password": "planned-secret"}},
+			CmdLookuper: lookuper,
+			Log:         logger.DiscardLogger,
+			PlanFunc:    func(p *Plan) error { plan = p; return nil },
+		}
+		if err := ru.Run(); err != nil {
+			t.Fatal(err)
+		}
+		b, err := json.Marshal(plan)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(b), "planned-secret") {
+			t.Fatalf("secret persisted in plan %s", b)
+		}
+
+		ran = nil
+		ru = &Runner{
+			Template:               MustParse(source),
+			Plan:                   plan,
+			CmdLookuper:            lookuper,
+			Log:                    logger.DiscardLogger,
+			MissingSecretHolesFunc: func(string, []string) interface{} { return "applied-secret" },
+			BeforeRun:              func(*TemplateExecution) (bool, error) { return true, nil },
+			AfterRun:               func(*TemplateExecution) error { return nil },
+		}
+		if err := ru.Run(); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := ran, []string{"create map[name:db4 password:applied-secret]"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+}
@@ -38,62 +38,214 @@ func (s *Template) Run(env *Env) (*Template, error) {
 	current := &Template{AST: &ast.AST{}}
 	current.ID = ulid.MustNew(ulid.Timestamp(time.Now()), rand.Reader).String()
 
-	for _, sts := range s.Statements {
+	env.resumeIndex = 0
+
+	var err error
+	if env.Parallelism > 1 && !env.IsDryRun && len(env.resumed) == 0 && canRunInParallel(s.Statements) {
+		_, err = runParallel(env, s.Statements, vars, current)
+	} else {
+		_, err = runStatements(env, s.Statements, vars, ast.DeclaredIdentifiers(s.Statements), current)
+	}
+
+	return current, err
+}
+
+func runStatements(env *Env, statements []*ast.Statement, vars map[string]interface{}, declared map[string]bool, current *Template) (bool, error) {
+	for _, sts := range statements {
+		switch n := sts.Node.(type) {
+		case *ast.IfNode:
+			cond, err := n.Condition.Evaluate(runtimeValueResolver(env, vars))
+			if err != nil {
+				return true, fmt.Errorf("if %s: %s", n.Condition, err)
+			}
+			if stop, err := runStatements(env, n.Branch(cond), vars, declared, current); stop || err != nil {
+				return stop, err
+			}
+			continue
+		case *ast.ForNode:
+			items, err := n.Items(runtimeValueResolver(env, vars))
+			if err != nil {
+				return true, fmt.Errorf("for %s in %s: %s", n.Var, n.List, err)
+			}
+			if stop, err := runStatements(env, n.Unroll(items, declared), vars, declared, current); stop || err != nil {
+				return stop, err
+			}
+			continue
+		case *ast.IncludeNode:
+			return true, fmt.Errorf("include %s: included templates are only inlined at compilation", n.Path)
+		case *ast.ParamNode, *ast.CommentNode:
+			continue
+		}
+		if skip, err := resumeStatement(env, sts, vars); skip || err != nil {
+			if err != nil {
+				return true, err
+			}
+			continue
+		}
 		clone := sts.Clone()
 		current.Statements = append(current.Statements, clone)
-		ctx := map[string]interface{}{
-			"Variables":  env.ResolvedVariables,
-			"References": env.ResolvedVariables, // retro-compatibility with v0.1.2
+		stop, err := execStatement(env, clone, vars)
+		logStatement(env, clone)
+		if stop || err != nil {
+			return stop, err
+		}
+	}
+
+	return false, nil
+}
+
+// execStatement runs a flat statement, storing the result of its declaration in vars
+func execStatement(env *Env, st *ast.Statement, vars map[string]interface{}) (bool, error) {
+	ctx := map[string]interface{}{
+		"Variables":  env.ResolvedVariables,
+		"References": env.ResolvedVariables, // retro-compatibility with v0.1.2
+	}
+	switch n := st.Node.(type) {
+	case *ast.CommandNode:
+		if stop := processCmdNode(env, n, vars, ctx); stop {
+			return true, nil
 		}
-		switch n := clone.Node.(type) {
+	case *ast.OutputNode:
+		n.ProcessRefs(env.ResolvedVariables)
+		n.ProcessRefs(vars)
+		if err := ast.EvaluateValueFunctions(n.Value); err != nil {
+			return true, err
+		}
+	case *ast.DeclarationNode:
+		ident := n.Ident
+		expr := n.Expr
+		switch n := expr.(type) {
 		case *ast.CommandNode:
 			if stop := processCmdNode(env, n, vars, ctx); stop {
-				return current, nil
-			}
-		case *ast.DeclarationNode:
-			ident := n.Ident
-			expr := n.Expr
-			switch n := expr.(type) {
-			case *ast.CommandNode:
-				if stop := processCmdNode(env, n, vars, ctx); stop {
-					return current, nil
-				}
-				vars[ident] = n.Result()
-			default:
-				return current, fmt.Errorf("unknown type of node: %T", expr)
+				return true, nil
 			}
+			vars[ident] = n.Result()
 		default:
-			return current, fmt.Errorf("unknown type of node: %T", clone.Node)
+			return true, fmt.Errorf("unknown type of node: %T", expr)
 		}
+	default:
+		return true, fmt.Errorf("unknown type of node: %T", st.Node)
 	}
+	return false, nil
+}
 
-	return current, nil
+func runtimeValueResolver(env *Env, vars map[string]interface{}) func(ast.CompositeValue) (interface{}, error) {
+	return func(v ast.CompositeValue) (interface{}, error) {
+		clone := v.Clone()
+		if withRefs, ok := clone.(ast.WithRefs); ok {
+			withRefs.ProcessRefs(env.ResolvedVariables)
+			withRefs.ProcessRefs(vars)
+			if refs := withRefs.GetRefs(); len(refs) > 0 {
+				return nil, fmt.Errorf("unresolved references %q", refs)
+			}
+		}
+		if withHoles, ok := clone.(ast.WithHoles); ok {
+			if holes := withHoles.GetHoles(); len(holes) > 0 {
+				return nil, fmt.Errorf("unresolved holes in '%s'", clone)
+			}
+		}
+		if err := ast.EvaluateValueFunctions(clone); err != nil {
+			return nil, err
+		}
+		if clone.Value() == nil {
+			return nil, fmt.Errorf("cannot resolve value of '%s'", clone)
+		}
+		return clone.Value(), nil
+	}
 }
 
 func processCmdNode(env *Env, n *ast.CommandNode, vars map[string]interface{}, ctx map[string]interface{}) bool {
 	n.ProcessRefs(vars)
+	if err := n.EvaluateFunctions(); err != nil {
+		n.CmdErr = err
+		if env.IsDryRun {
+			n.CmdErr = prefixError(err, "dry run")
+		}
+		return true
+	}
 	if env.IsDryRun {
 		n.CmdResult, n.CmdErr = n.Command.DryRun(ctx, n.ToDriverParams())
 		n.CmdErr = prefixError(n.CmdErr, "dry run")
 	} else {
+		n.CmdPriorValues = fetchPriorValues(env, n)
+		n.CmdSnapshots = takeSnapshots(env, n)
 		n.CmdResult, n.CmdErr = n.Run(ctx, n.ToDriverParams())
-		var res, status string
-		if n.CmdResult != nil {
-			res = " (" + color.New(color.FgCyan).Sprint(n.CmdResult) + ") "
-		}
-		if n.CmdErr != nil {
-			status = color.New(color.FgRed).Sprint("KO")
-		} else {
-			status = color.New(color.FgGreen).Sprint("OK")
-		}
-		env.Log.Infof("%s %s %s%s", status, n.Action, n.Entity, res)
-		if n.CmdErr != nil {
-			env.Log.MultiLineError(n.CmdErr)
-		}
 	}
 	return n.CmdErr != nil
 }
 
+// fetchPriorValues returns the current values of the params a command is about to change, for it to be reverted.
+// When they cannot be fetched, the command is still run but will not be revertible.
+func fetchPriorValues(env *Env, n *ast.CommandNode) map[string]interface{} {
+	type P interface {
+		PriorValues(map[string]interface{}) (map[string]interface{}, error)
+	}
+	p, ok := n.Command.(P)
+	if !ok {
+		return nil
+	}
+	prior, err := p.PriorValues(n.ToDriverParams())
+	if err != nil {
+		env.Log.Warningf("%s %s will not be revertible: %s", n.Action, n.Entity, err)
+		return nil
+	}
+	return prior
+}
+
+// Snapshot is the state of a resource taken just before it is deleted,
+// with the statements recreating it when the delete is reverted
+type Snapshot ast.Snapshot
+
+// takeSnapshots returns the states of the resources a delete is about to remove, for it to be reverted.
+// What cannot be recreated is reported, the delete being still run.
+func takeSnapshots(env *Env, n *ast.CommandNode) (snapshots []*ast.Snapshot) {
+	if env.SnapshotFunc == nil || n.Action != "delete" || revertsDeleteFromParams(n.Entity) {
+		return nil
+	}
+	taken, err := env.SnapshotFunc(n.Entity, n.ToDriverParams())
+	if err != nil {
+		env.Log.Warningf("%s %s will not be revertible: %s", n.Action, n.Entity, err)
+		return nil
+	}
+	for _, s := range taken {
+		for _, reason := range s.Unrecreatable {
+			env.Log.Warningf("reverting %s %s will not recreate %s", n.Action, n.Entity, reason)
+		}
+		snapshots = append(snapshots, (*ast.Snapshot)(s))
+	}
+	return snapshots
+}
+
+// logStatement prints the OK/KO status of the command of a statement once run
+func logStatement(env *Env, st *ast.Statement) {
+	if env.IsDryRun {
+		return
+	}
+	var n *ast.CommandNode
+	switch nn := st.Node.(type) {
+	case *ast.CommandNode:
+		n = nn
+	case *ast.DeclarationNode:
+		n, _ = nn.Expr.(*ast.CommandNode)
+	}
+	if n == nil {
+		return
+	}
+	var res, status string
+	if n.CmdResult != nil {
+		res = " (" + color.New(color.FgCyan).Sprint(n.CmdResult) + ") "
+	}
+	if n.CmdErr != nil {
+		status = color.New(color.FgRed).Sprint("KO")
+	} else {
+		status = color.New(color.FgGreen).Sprint("OK")
+	}
+	env.Log.Infof("%s %s %s%s", status, n.Action, n.Entity, res)
+	if n.CmdErr != nil {
+		env.Log.MultiLineError(n.CmdErr)
+	}
+}
+
 func prefixError(err error, prefix string) error {
 	if err == nil {
 		return err
@@ -172,7 +324,7 @@ func (s *Template) visitDeclarationNodes(fn func(n *ast.DeclarationNode)) {
 }
 
 func (s *Template) CommandNodesIterator() (nodes []*ast.CommandNode) {
-	for _, sts := range s.Statements {
+	for _, sts := range s.flatStatements() {
 		switch nn := sts.Node.(type) {
 		case *ast.CommandNode:
 			nodes = append(nodes, nn)
@@ -188,7 +340,7 @@ func (s *Template) CommandNodesIterator() (nodes []*ast.CommandNode) {
 }
 
 func (s *Template) WithRefsIterator() (nodes []ast.WithRefs) {
-	for _, sts := range s.Statements {
+	for _, sts := range s.flatStatements() {
 		switch nn := sts.Node.(type) {
 		case ast.WithRefs:
 			nodes = append(nodes, nn)
@@ -204,8 +356,9 @@ func (s *Template) WithRefsIterator() (nodes []ast.WithRefs) {
 }
 
 func (s *Template) CommandNodesReverseIterator() (nodes []*ast.CommandNode) {
-	for i := len(s.Statements) - 1; i >= 0; i-- {
-		sts := s.Statements[i]
+	statements := s.flatStatements()
+	for i := len(statements) - 1; i >= 0; i-- {
+		sts := statements[i]
 		switch sts.Node.(type) {
 		case *ast.CommandNode:
 			nodes = append(nodes, sts.Node.(*ast.CommandNode))
@@ -232,7 +385,7 @@ func (s *Template) commandDeclarationNodesIterator() (nodes []*ast.DeclarationNo
 }
 
 func (s *Template) declarationNodesIterator() (nodes []*ast.DeclarationNode) {
-	for _, sts := range s.Statements {
+	for _, sts := range s.flatStatements() {
 		switch n := sts.Node.(type) {
 		case *ast.DeclarationNode:
 			nodes = append(nodes, n)
@@ -242,7 +395,7 @@ func (s *Template) declarationNodesIterator() (nodes []*ast.DeclarationNode) {
 }
 
 func (s *Template) expressionNodesIterator() (nodes []ast.ExpressionNode) {
-	for _, st := range s.Statements {
+	for _, st := range s.flatStatements() {
 		if expr := extractExpressionNode(st); expr != nil {
 			nodes = append(nodes, expr)
 		}
@@ -250,6 +403,53 @@ func (s *Template) expressionNodesIterator() (nodes []ast.ExpressionNode) {
 	return
 }
 
+// Outputs returns the values exported by the `output` statements of the template
+func (s *Template) Outputs() map[string]interface{} {
+	outputs := make(map[string]interface{})
+	for _, st := range s.flatStatements() {
+		if n, ok := st.Node.(*ast.OutputNode); ok {
+			outputs[n.Name] = n.Result()
+		}
+	}
+	return outputs
+}
+
+// ParamDeclaration documents a hole declared with `param` in a template
+type ParamDeclaration struct {
+	Name, Type, Default, Description string
+	AllowedValues                    []string
+}
+
+func (s *Template) ParamDeclarations() (decls []*ParamDeclaration) {
+	for _, st := range s.flatStatements() {
+		if n, ok := st.Node.(*ast.ParamNode); ok {
+			decl := &ParamDeclaration{Name: n.Name, Type: n.Type, Description: n.Description, AllowedValues: n.AllowedValues()}
+			if n.Default != nil {
+				decl.Default = n.Default.String()
+			}
+			decls = append(decls, decl)
+		}
+	}
+	return
+}
+
+// flatStatements returns the statements of the template
+// with the statements nested in blocks inlined in order
+func (s *Template) flatStatements() []*ast.Statement {
+	return flattenStatements(s.Statements)
+}
+
+func flattenStatements(statements []*ast.Statement) (flat []*ast.Statement) {
+	for _, st := range statements {
+		if block, ok := st.Node.(ast.WithNestedStatements); ok {
+			flat = append(flat, flattenStatements(block.NestedStatements())...)
+			continue
+		}
+		flat = append(flat, st)
+	}
+	return
+}
+
 func extractExpressionNode(st *ast.Statement) ast.ExpressionNode {
 	switch n := st.Node.(type) {
 	case *ast.DeclarationNode:
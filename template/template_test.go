@@ -0,0 +1,191 @@
+package template
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type idCommand struct {
+	prefix string
+	count  int
+}
+
+func (c *idCommand) Run(ctx, params map[string]interface{}) (interface{}, error) {
+	c.count++
+	return fmt.Sprintf("%s-%d", c.prefix, c.count), nil
+}
+func (c *idCommand) DryRun(ctx, params map[string]interface{}) (interface{}, error) { return nil, nil }
+
+func TestRunTemplateWithBlocks(t *testing.T) {
+	tpl := MustParse(`vpc = create vpc cidr=10.0.0.0/16
+for az in [eu-west-1a, eu-west-1b] {
+  sub = create subnet vpc=$vpc availabilityzone=$az
+  if $az == eu-west-1a {
+    create tag resource=$sub key=Public value=true
+  }
+}`)
+	cmd := &idCommand{prefix: "id"}
+	for _, node := range tpl.CommandNodesIterator() {
+		node.Command = cmd
+	}
+
+	executed, err := tpl.Run(NewEnv())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := `vpc = create vpc cidr=10.0.0.0/16
+sub_1 = create subnet availabilityzone=eu-west-1a vpc=id-1
+create tag key=Public resource=id-2 value=true
+sub_2 = create subnet availabilityzone=eu-west-1b vpc=id-1`
+	if got, want := executed.String(), exp; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+
+	b, err := json.Marshal(&TemplateExecution{Template: executed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out toJSON
+	if err = json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, c := range out.Commands {
+		lines = append(lines, c.Line)
+	}
+	expLines := []string{
+		"create vpc cidr=10.0.0.0/16",
+		"create subnet availabilityzone=eu-west-1a vpc=id-1",
+		"create tag key=Public resource=id-2 value=true",
+		"create subnet availabilityzone=eu-west-1b vpc=id-1",
+	}
+	if got, want := lines, expLines; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	reverted, err := executed.Revert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expRevert := `delete subnet id=id-4
+delete tag key=Public resource=id-2 value=true
+delete subnet id=id-2
+delete vpc id=id-1`
+	if got, want := reverted.String(), expRevert; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRunTemplateFailsOnRuntimeFunctionErrors(t *testing.T) {
+	tcases := []struct {
+		tpl    string
+		expErr string
+	}{
+		{tpl: "vpc = create vpc cidr=10.0.0.0/16\ncreate subnet cidr=cidrsubnet($vpc, 8, 1)", expErr: "2:20: cidrsubnet(id-1, 8, 1): invalid cidr 'id-1'\n\tcreate subnet cidr=cidrsubnet($vpc, 8, 1)\n\t                   ^"},
+		{tpl: "vpc = create vpc cidr=10.0.0.0/16\ncreate instance userdata=file($vpc)", expErr: "2:26: file(id-1): open id-1: no such file or directory\n\tcreate instance userdata=file($vpc)\n\t                         ^"},
+	}
+	for i, tcase := range tcases {
+		tpl := MustParse(tcase.tpl)
+		cmd := &idCommand{prefix: "id"}
+		for _, node := range tpl.CommandNodesIterator() {
+			node.Command = cmd
+		}
+		executed, err := tpl.Run(NewEnv())
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := cmd.count, 1; got != want {
+			t.Fatalf("%d: got %d commands run, want %d", i+1, got, want)
+		}
+		failed := executed.CommandNodesIterator()[1]
+		if failed.CmdErr == nil || failed.CmdErr.Error() != tcase.expErr {
+			t.Fatalf("%d: got error %v, want %s", i+1, failed.CmdErr, tcase.expErr)
+		}
+	}
+}
+
+func TestRunTemplateWithOutputs(t *testing.T) {
+	tpl := MustParse(`name = {env}
+vpc = create vpc cidr=10.0.0.0/16
+sub = create subnet vpc=$vpc
+output vpc.id = $vpc
+output subnets = [$sub, subnet-static]
+output env = $name
+output upper = upper($vpc)`)
+
+	env := NewEnv()
+	env.AddFillers(map[string]interface{}{"env": "prod"})
+	compiled, env, err := newMultiPass(expandBlocksPass, resolveHolesPass, inlineVariableValuePass).compile(tpl, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := &idCommand{prefix: "id"}
+	for _, node := range compiled.CommandNodesIterator() {
+		node.Command = cmd
+	}
+
+	executed, err := compiled.Run(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := map[string]interface{}{"vpc.id": "id-1", "subnets": []interface{}{"id-2", "subnet-static"}, "env": "prod", "upper": "ID-1"}
+	if got, want := executed.Outputs(), exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	b, err := json.Marshal(&TemplateExecution{Template: executed, Outputs: executed.Outputs()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tplExec := &TemplateExecution{}
+	if err = json.Unmarshal(b, tplExec); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tplExec.Outputs, exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestRunTemplateSnapshotsDeletedResources(t *testing.T) {
+	tpl := MustParse(`delete user name=alice
+delete keypair id=ops
+delete record name=my.domain.com type=A value=10.0.0.1 zone=my-zone ttl=60`)
+	cmd := &idCommand{prefix: "id"}
+	for _, node := range tpl.CommandNodesIterator() {
+		node.Command = cmd
+	}
+
+	var snapshotted []string
+	env := NewEnv()
+	env.SnapshotFunc = func(entity string, params map[string]interface{}) ([]*Snapshot, error) {
+		snapshotted = append(snapshotted, entity)
+		switch entity {
+		case "user":
+			return []*Snapshot{{Type: "user", ID: "AIDA1", Recreate: fmt.Sprintf("alice = create user name=%s", params["name"])}}, nil
+		default:
+			return nil, errors.New("unsupported")
+		}
+	}
+
+	executed, err := tpl.Run(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := snapshotted, []string{"user", "keypair"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	reverted, err := executed.Revert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `create record name=my.domain.com ttl=60 type=A value=10.0.0.1 zone=my-zone
+alice = create user name=alice`
+	if got, want := reverted.String(), exp; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/template/internal/ast"
 )
 
 type Validator interface {
@@ -60,3 +64,265 @@ func (v *ParamIsSetValidator) Execute(t *Template) (errs []error) {
 	}
 	return
 }
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// LintFinding is an issue found by a lint rule at a line of a template
+type LintFinding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Line     int      `json:"line"`
+	Message  string   `json:"message"`
+}
+
+func (f *LintFinding) String() string {
+	return fmt.Sprintf("line %d: %s: %s (%s)", f.Line, f.Severity, f.Message, f.Rule)
+}
+
+// LintRule statically checks a parsed template, without compiling it
+type LintRule struct {
+	Name        string
+	Description string
+	Severity    Severity
+	Check       func(*Template) []*LintFinding
+}
+
+var lintRules []*LintRule
+
+// RegisterLintRule adds a rule to the ones run by Lint
+func RegisterLintRule(rule *LintRule) {
+	lintRules = append(lintRules, rule)
+}
+
+func LintRules() []*LintRule {
+	return lintRules
+}
+
+// Lint runs the given rules, or all the registered ones if none given,
+// returning their findings sorted by line
+func (t *Template) Lint(rules ...*LintRule) (all []*LintFinding) {
+	if len(rules) == 0 {
+		rules = lintRules
+	}
+	for _, rule := range rules {
+		for _, f := range rule.Check(t) {
+			f.Rule = rule.Name
+			if f.Severity == "" {
+				f.Severity = rule.Severity
+			}
+			all = append(all, f)
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Line < all[j].Line })
+	return
+}
+
+func init() {
+	RegisterLintRule(&LintRule{Name: "unused-variable", Severity: SeverityWarning, Description: "Variables declared but never referenced", Check: lintUnusedVariables})
+	RegisterLintRule(&LintRule{Name: "duplicate-declaration", Severity: SeverityError, Description: "Variables declared twice", Check: lintDuplicateDeclarations})
+	RegisterLintRule(&LintRule{Name: "open-admin-port", Severity: SeverityError, Description: "Ingress from 0.0.0.0/0 on SSH (22) or RDP (3389) ports", Check: lintOpenAdminPorts})
+	RegisterLintRule(&LintRule{Name: "instance-without-keypair", Severity: SeverityWarning, Description: "Instances created without a keypair", Check: lintInstancesWithoutKeypair})
+	RegisterLintRule(&LintRule{Name: "untagged-resource", Severity: SeverityInfo, Description: "Resources created without a name nor tags", Check: lintUntaggedResources})
+	RegisterLintRule(&LintRule{Name: "delete-without-check", Severity: SeverityInfo, Description: "Resources deleted without checking their state beforehand", Check: lintDeletesWithoutCheck})
+}
+
+func lintUnusedVariables(t *Template) (findings []*LintFinding) {
+	used := make(map[string]bool)
+	for _, ref := range referencedVariables(t.Statements) {
+		used[ref] = true
+	}
+	for _, st := range t.flatStatements() {
+		if decl, ok := st.Node.(*ast.DeclarationNode); ok && !used[decl.Ident] {
+			findings = append(findings, &LintFinding{Line: st.Line, Message: fmt.Sprintf("variable '%s' is declared but never used", decl.Ident)})
+		}
+	}
+	return
+}
+
+func lintDuplicateDeclarations(t *Template) (findings []*LintFinding) {
+	var visit func(stmts []*ast.Statement, declared map[string]int)
+	visit = func(stmts []*ast.Statement, declared map[string]int) {
+		for _, st := range stmts {
+			switch n := st.Node.(type) {
+			case *ast.DeclarationNode:
+				if line, ok := declared[n.Ident]; ok {
+					findings = append(findings, &LintFinding{Line: st.Line, Message: fmt.Sprintf("variable '%s' already declared at line %d", n.Ident, line)})
+				}
+				declared[n.Ident] = st.Line
+			case *ast.IfNode:
+				// variables declared in exclusive branches do not conflict with each other
+				then, els := copyDeclared(declared), copyDeclared(declared)
+				visit(n.Then, then)
+				visit(n.Else, els)
+				for _, branch := range []map[string]int{then, els} {
+					for k, v := range branch {
+						if _, ok := declared[k]; !ok {
+							declared[k] = v
+						}
+					}
+				}
+			case *ast.ForNode:
+				// variables declared in loops are scoped to their iteration
+				visit(n.Body, copyDeclared(declared))
+			}
+		}
+	}
+	visit(t.Statements, make(map[string]int))
+	return
+}
+
+func copyDeclared(declared map[string]int) map[string]int {
+	cpy := make(map[string]int)
+	for k, v := range declared {
+		cpy[k] = v
+	}
+	return cpy
+}
+
+var adminPorts = []int{22, 3389}
+
+func lintOpenAdminPorts(t *Template) (findings []*LintFinding) {
+	for _, st := range t.flatStatements() {
+		cmd, ok := extractExpressionNode(st).(*ast.CommandNode)
+		if !ok || cmd.Action != "update" || cmd.Entity != "securitygroup" {
+			continue
+		}
+		if paramString(cmd, "inbound") != "authorize" {
+			continue
+		}
+		if cidr := paramString(cmd, "cidr"); cidr != "0.0.0.0/0" && cidr != "::/0" {
+			continue
+		}
+		for _, port := range adminPorts {
+			if portInRange(port, paramString(cmd, "protocol"), paramString(cmd, "portrange")) {
+				findings = append(findings, &LintFinding{Line: st.Line, Message: fmt.Sprintf("port %d open to the world", port)})
+			}
+		}
+	}
+	return
+}
+
+func portInRange(port int, protocol, portrange string) bool {
+	if protocol == "any" || protocol == "-1" || portrange == "any" {
+		return true
+	}
+	if bounds := strings.SplitN(portrange, "-", 2); len(bounds) == 2 {
+		from, ferr := strconv.Atoi(bounds[0])
+		to, terr := strconv.Atoi(bounds[1])
+		return ferr == nil && terr == nil && from <= port && port <= to
+	}
+	p, err := strconv.Atoi(portrange)
+	return err == nil && p == port
+}
+
+func lintInstancesWithoutKeypair(t *Template) (findings []*LintFinding) {
+	for _, st := range t.flatStatements() {
+		cmd, ok := extractExpressionNode(st).(*ast.CommandNode)
+		if !ok || cmd.Action != "create" || cmd.Entity != "instance" {
+			continue
+		}
+		if _, hasKeypair := cmd.Params["keypair"]; !hasKeypair {
+			findings = append(findings, &LintFinding{Line: st.Line, Message: "instance created without keypair, you might not be able to connect to it"})
+		}
+	}
+	return
+}
+
+// taggableEntities are the entities which can be tagged with `create tag`.
+// The name of the entities in nameTaggedEntities is set as a Name tag.
+var (
+	taggableEntities   = []string{"instance", "vpc", "subnet", "volume", "internetgateway", "routetable", "natgateway", "networkinterface", "securitygroup", "image", "snapshot"}
+	nameTaggedEntities = []string{"instance", "vpc", "subnet"}
+)
+
+func lintUntaggedResources(t *Template) (findings []*LintFinding) {
+	tagged := make(map[string]bool)
+	for _, cmd := range t.CommandNodesIterator() {
+		if cmd.Action == "create" && cmd.Entity == "tag" {
+			if withRefs, ok := cmd.Params["resource"].(ast.WithRefs); ok {
+				for _, ref := range withRefs.GetRefs() {
+					tagged[ref] = true
+				}
+			}
+		}
+	}
+	for _, st := range t.flatStatements() {
+		cmd, ok := extractExpressionNode(st).(*ast.CommandNode)
+		if !ok || cmd.Action != "create" || !contains(taggableEntities, cmd.Entity) {
+			continue
+		}
+		if _, hasName := cmd.Params["name"]; hasName && contains(nameTaggedEntities, cmd.Entity) {
+			continue
+		}
+		if decl, ok := st.Node.(*ast.DeclarationNode); ok && tagged[decl.Ident] {
+			continue
+		}
+		findings = append(findings, &LintFinding{Line: st.Line, Message: fmt.Sprintf("%s created without tags", cmd.Entity)})
+	}
+	return
+}
+
+// checkableEntities are the entities whose state can be waited for with `check`
+var checkableEntities = []string{"certificate", "database", "distribution", "instance", "loadbalancer", "natgateway", "networkinterface", "scalinggroup", "securitygroup", "volume"}
+
+func lintDeletesWithoutCheck(t *Template) (findings []*LintFinding) {
+	checked := make(map[string]bool)
+	for _, st := range t.flatStatements() {
+		cmd, ok := extractExpressionNode(st).(*ast.CommandNode)
+		if !ok {
+			continue
+		}
+		resource := cmd.Entity + " " + paramString(cmd, "id")
+		switch {
+		case cmd.Action == "check":
+			checked[resource] = true
+		case cmd.Action == "delete" && contains(checkableEntities, cmd.Entity) && !checked[resource]:
+			findings = append(findings, &LintFinding{Line: st.Line, Message: fmt.Sprintf("%s deleted without a preceding `check %s`", cmd.Entity, cmd.Entity)})
+		}
+	}
+	return
+}
+
+func paramString(cmd *ast.CommandNode, key string) string {
+	if v, ok := cmd.Params[key]; ok {
+		return v.String()
+	}
+	return ""
+}
+
+// referencedVariables returns the variables referenced in the statements, including nested ones
+func referencedVariables(stmts []*ast.Statement) (refs []string) {
+	addRefs := func(values ...ast.CompositeValue) {
+		for _, v := range values {
+			if withRefs, ok := v.(ast.WithRefs); ok {
+				refs = append(refs, withRefs.GetRefs()...)
+			}
+		}
+	}
+	for _, st := range stmts {
+		switch n := st.Node.(type) {
+		case *ast.IfNode:
+			addRefs(n.Condition.Left, n.Condition.Right)
+			refs = append(refs, referencedVariables(n.Then)...)
+			refs = append(refs, referencedVariables(n.Else)...)
+		case *ast.ForNode:
+			addRefs(n.List)
+			refs = append(refs, referencedVariables(n.Body)...)
+		case *ast.IncludeNode:
+			for _, v := range n.Params {
+				addRefs(v)
+			}
+		default:
+			if withRefs, ok := extractExpressionNode(st).(ast.WithRefs); ok {
+				refs = append(refs, withRefs.GetRefs()...)
+			}
+		}
+	}
+	return
+}
@@ -1,6 +1,7 @@
 package template_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/wallix/awless/graph"
@@ -61,3 +62,98 @@ func TestValidation(t *testing.T) {
 		}
 	})
 }
+
+func TestLint(t *testing.T) {
+	tcases := []struct {
+		name, rule, text string
+		expect           []string
+	}{
+		{
+			name: "unused variables", rule: "unused-variable",
+			text: `vpc = create vpc cidr=10.0.0.0/16
+unused = create subnet vpc=$vpc cidr=10.0.0.0/24
+cidr = 10.0.1.0/24
+if $vpc != "" {
+	create subnet vpc=$vpc cidr=$cidr
+}`,
+			expect: []string{"line 2: warning: variable 'unused' is declared but never used (unused-variable)"},
+		},
+		{
+			name: "duplicate declarations", rule: "duplicate-declaration",
+			text: `sub = create subnet cidr=10.0.0.0/24
+if {env} == prod {
+	other = create subnet cidr=10.0.1.0/24
+} else {
+	other = create subnet cidr=10.0.2.0/24
+}
+for n in [a, b] {
+	inst = create instance name=$n
+}
+sub = create subnet cidr=10.0.3.0/24
+other = create subnet cidr=10.0.4.0/24`,
+			expect: []string{
+				"line 10: error: variable 'sub' already declared at line 1 (duplicate-declaration)",
+				"line 11: error: variable 'other' already declared at line 3 (duplicate-declaration)",
+			},
+		},
+		{
+			name: "open admin ports", rule: "open-admin-port",
+			text: `update securitygroup id=sg-1 inbound=authorize protocol=tcp cidr=0.0.0.0/0 portrange=22
+update securitygroup id=sg-1 inbound=authorize protocol=tcp cidr=10.0.0.0/16 portrange=22
+update securitygroup id=sg-1 inbound=authorize protocol=tcp cidr=0.0.0.0/0 portrange=443
+update securitygroup id=sg-1 inbound=authorize protocol=tcp cidr=0.0.0.0/0 portrange=3000-4000
+update securitygroup id=sg-1 inbound=revoke protocol=tcp cidr=0.0.0.0/0 portrange=22
+update securitygroup id=sg-1 inbound=authorize protocol=any cidr=0.0.0.0/0`,
+			expect: []string{
+				"line 1: error: port 22 open to the world (open-admin-port)",
+				"line 4: error: port 3389 open to the world (open-admin-port)",
+				"line 6: error: port 22 open to the world (open-admin-port)",
+				"line 6: error: port 3389 open to the world (open-admin-port)",
+			},
+		},
+		{
+			name: "instances without keypair", rule: "instance-without-keypair",
+			text: `create instance name=one keypair=mykey
+create instance name=two`,
+			expect: []string{"line 2: warning: instance created without keypair, you might not be able to connect to it (instance-without-keypair)"},
+		},
+		{
+			name: "untagged resources", rule: "untagged-resource",
+			text: `create vpc cidr=10.0.0.0/16 name=myvpc
+vol = create volume size=10
+create tag resource=$vol key=Env value=prod
+create volume size=20
+create bucket name=mybucket`,
+			expect: []string{"line 4: info: volume created without tags (untagged-resource)"},
+		},
+		{
+			name: "deletes without check", rule: "delete-without-check",
+			text: `check instance id=$inst state=stopped timeout=180
+delete instance id=$inst
+delete instance id=i-1234
+delete subnet id=sub-1234`,
+			expect: []string{"line 3: info: instance deleted without a preceding `check instance` (delete-without-check)"},
+		},
+	}
+
+	rules := make(map[string]*template.LintRule)
+	for _, r := range template.LintRules() {
+		rules[r.Name] = r
+	}
+
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			rule, ok := rules[tcase.rule]
+			if !ok {
+				t.Fatalf("rule %s not registered", tcase.rule)
+			}
+			var got []string
+			for _, f := range template.MustParse(tcase.text).Lint(rule) {
+				got = append(got, f.String())
+			}
+			if want := tcase.expect; !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %q, want %q", got, want)
+			}
+		})
+	}
+}